@@ -0,0 +1,289 @@
+// Package aggregate merge the swagger 2.0 documents of several services into one portal document.
+// The paths are prefixed with the basePaths of the documents (like: a gateway routes /books/* to the service books),
+// the tags are prefixed with the names of the sources, the conflicting global parameters and definitions are namespaced
+// by the names of the sources (like: books.Error), and the other conflicts are errors (like: the same operation in two documents).
+package aggregate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Source a swagger document of a service
+// Fields:
+//   Name -- the name of the service, it is the prefix of the tags (like: books -> "books/authors"),
+//           the tag of the operations without tags, and the namespace of the conflicting definitions (like: books.Error).
+//           It is required and unique.
+//   Document -- the swagger 2.0 document
+type Source struct {
+	Name     string
+	Document *swagger.Swagger
+}
+
+// Merge merge the documents of the sources into one document, the sources are not changed.
+// The schemes, consumes, produces and security of the documents are set to their operations,
+// the global parameters, the definitions and the security definitions are merged by the names.
+// If a global parameter or a definition conflicts with another source (the same name and a different value),
+// it is renamed with the name of the source (like: books.Error), and its references in the source are rewritten.
+func Merge(info *swagger.Info, sources []*Source) (*swagger.Swagger, error) {
+	m := &merger{
+		doc: &swagger.Swagger{
+			SwaggerVersion: "2.0",
+			Info:           info,
+			Paths:          map[string]*swagger.Item{},
+		},
+		owners: map[string]string{},
+	}
+	names := map[string]bool{}
+	for _, source := range sources {
+		if source.Name == "" {
+			return nil, fmt.Errorf("the source has no name")
+		}
+		if names[source.Name] {
+			return nil, fmt.Errorf("the name of the source %s is duplicated", source.Name)
+		}
+		names[source.Name] = true
+		if source.Document == nil {
+			return nil, fmt.Errorf("the source %s has no document", source.Name)
+		}
+		if err := m.merge(source); err != nil {
+			return nil, err
+		}
+	}
+	return m.doc, nil
+}
+
+type merger struct {
+	doc *swagger.Swagger
+	// owners the names of the sources by the keys of the merged objects (like: "definition Book")
+	owners map[string]string
+}
+
+func (m *merger) merge(source *Source) error {
+	src, err := m.namespace(source)
+	if err != nil {
+		return err
+	}
+	for _, name := range getSortedKeys(src.Parameters) {
+		if m.doc.Parameters == nil {
+			m.doc.Parameters = map[string]*swagger.Parameter{}
+		}
+		if err := m.claim("parameter "+name, source.Name, m.doc.Parameters[name], src.Parameters[name]); err != nil {
+			return err
+		}
+		m.doc.Parameters[name] = src.Parameters[name]
+	}
+	for _, name := range getSortedKeys(src.Definitions) {
+		if m.doc.Definitions == nil {
+			m.doc.Definitions = map[string]*swagger.Schema{}
+		}
+		if err := m.claim("definition "+name, source.Name, m.doc.Definitions[name], src.Definitions[name]); err != nil {
+			return err
+		}
+		m.doc.Definitions[name] = src.Definitions[name]
+	}
+	for _, name := range getSortedKeys(src.SecurityDefinitions) {
+		if m.doc.SecurityDefinitions == nil {
+			m.doc.SecurityDefinitions = map[string]*swagger.Security{}
+		}
+		if err := m.claim("security definition "+name, source.Name, m.doc.SecurityDefinitions[name], src.SecurityDefinitions[name]); err != nil {
+			return err
+		}
+		m.doc.SecurityDefinitions[name] = src.SecurityDefinitions[name]
+	}
+	for _, tag := range src.Tags {
+		prefixed := *tag
+		prefixed.Name = prefixTag(source.Name, tag.Name)
+		m.doc.Tags = append(m.doc.Tags, &prefixed)
+	}
+	basePath := strings.TrimRight(src.BasePath, "/")
+	for _, path := range getSortedKeys(src.Paths) {
+		item := m.doc.Paths[basePath+path]
+		if item == nil {
+			item = &swagger.Item{}
+			m.doc.Paths[basePath+path] = item
+		}
+		srcItem := src.Paths[path]
+		operations := []struct {
+			method string
+			src    *swagger.Operation
+			dst    **swagger.Operation
+		}{
+			{"GET", srcItem.Get, &item.Get},
+			{"PUT", srcItem.Put, &item.Put},
+			{"POST", srcItem.Post, &item.Post},
+			{"DELETE", srcItem.Delete, &item.Delete},
+			{"OPTIONS", srcItem.Options, &item.Options},
+			{"HEAD", srcItem.Head, &item.Head},
+			{"PATCH", srcItem.Patch, &item.Patch},
+		}
+		for _, operation := range operations {
+			if operation.src == nil {
+				continue
+			}
+			key := "operation " + operation.method + " " + basePath + path
+			if owner, ok := m.owners[key]; ok {
+				return fmt.Errorf("the %s of %s conflicts with %s", key, source.Name, owner)
+			}
+			m.owners[key] = source.Name
+			*operation.dst = m.toOperation(source, operation.src)
+		}
+	}
+	return nil
+}
+
+// toOperation a copy of the operation, the tags are prefixed, and the options of the document are set to the operation
+func (m *merger) toOperation(source *Source, operation *swagger.Operation) *swagger.Operation {
+	src := source.Document
+	op := *operation
+	op.Tags = nil
+	for _, tag := range operation.Tags {
+		op.Tags = append(op.Tags, prefixTag(source.Name, tag))
+	}
+	if len(op.Tags) == 0 {
+		op.Tags = []string{source.Name}
+	}
+	if len(op.Schemes) == 0 {
+		op.Schemes = src.Schemes
+	}
+	if len(op.Consumes) == 0 {
+		op.Consumes = src.Consumes
+	}
+	if len(op.Produces) == 0 {
+		op.Produces = src.Produces
+	}
+	if op.Security == nil {
+		op.Security = src.Security
+	}
+	return &op
+}
+
+// namespace the document of the source, the global parameters and the definitions conflicting with the other sources
+// are renamed with the name of the source, and their references are rewritten. A definition referring to a renamed one
+// is changed, so the renaming is repeated until there is no new conflict. The document is copied if anything is renamed.
+func (m *merger) namespace(source *Source) (*swagger.Swagger, error) {
+	parameters := map[string]string{}
+	definitions := map[string]string{}
+	doc := source.Document
+	for changed := true; changed; {
+		changed = false
+		for _, name := range getSortedKeys(source.Document.Parameters) {
+			if _, ok := parameters[name]; !ok && m.conflicts("parameter "+name, m.doc.Parameters[name], doc.Parameters[name]) {
+				parameters[name] = source.Name + "." + name
+				changed = true
+			}
+		}
+		for _, name := range getSortedKeys(source.Document.Definitions) {
+			if _, ok := definitions[name]; !ok && m.conflicts("definition "+name, m.doc.Definitions[name], doc.Definitions[name]) {
+				definitions[name] = source.Name + "." + name
+				changed = true
+			}
+		}
+		if changed {
+			var err error
+			if doc, err = renameReferences(source.Document, parameters, definitions); err != nil {
+				return nil, fmt.Errorf("the source %s can't be namespaced, %s", source.Name, err)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// conflicts the object of the key is claimed by another source with a different value
+func (m *merger) conflicts(key string, old interface{}, current interface{}) bool {
+	_, ok := m.owners[key]
+	return ok && !isSameValue(old, current)
+}
+
+// renameReferences a copy of the document, the global parameters and the definitions are renamed by the maps
+// (the old name -> the new name), and the references to them are rewritten
+func renameReferences(src *swagger.Swagger, parameters map[string]string, definitions map[string]string) (*swagger.Swagger, error) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+	for name, newName := range parameters {
+		data = replaceJSONString(data, "#/parameters/"+name, "#/parameters/"+newName)
+	}
+	for name, newName := range definitions {
+		data = replaceJSONString(data, "#/definitions/"+name, "#/definitions/"+newName)
+	}
+	doc := &swagger.Swagger{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	for name, newName := range parameters {
+		if _, ok := doc.Parameters[newName]; ok {
+			return nil, fmt.Errorf("the parameter %s already exists", newName)
+		}
+		doc.Parameters[newName] = doc.Parameters[name]
+		delete(doc.Parameters, name)
+	}
+	for name, newName := range definitions {
+		if _, ok := doc.Definitions[newName]; ok {
+			return nil, fmt.Errorf("the definition %s already exists", newName)
+		}
+		doc.Definitions[newName] = doc.Definitions[name]
+		delete(doc.Definitions, name)
+	}
+	return doc, nil
+}
+
+// replaceJSONString replace the JSON strings equal to old (like: the references "#/definitions/Error")
+func replaceJSONString(data []byte, old string, new string) []byte {
+	oldData, _ := json.Marshal(old)
+	newData, _ := json.Marshal(new)
+	return bytes.Replace(data, oldData, newData, -1)
+}
+
+// claim claim the object of the key for the source, it is an error if the object is claimed by another source with a different value
+func (m *merger) claim(key string, sourceName string, old interface{}, current interface{}) error {
+	owner, ok := m.owners[key]
+	if ok && !isSameValue(old, current) {
+		return fmt.Errorf("the %s of %s conflicts with %s", key, sourceName, owner)
+	}
+	if !ok {
+		m.owners[key] = sourceName
+	}
+	return nil
+}
+
+func prefixTag(sourceName string, tag string) string {
+	return sourceName + "/" + tag
+}
+
+// isSameValue the values are the same if their JSON are the same
+func isSameValue(a interface{}, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+func getSortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch v := m.(type) {
+	case map[string]*swagger.Parameter:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Schema:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Security:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Item:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
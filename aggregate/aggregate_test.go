@@ -0,0 +1,178 @@
+package aggregate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testBooksDocument = `{
+  "swagger": "2.0",
+  "basePath": "/books",
+  "produces": ["application/json"],
+  "tags": [{"name": "shelves", "description": "the shelves"}],
+  "securityDefinitions": {"token": {"type": "apiKey", "name": "Authorization", "in": "header"}},
+  "paths": {
+    "/{id}": {
+      "get": {"responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}}}}
+    },
+    "/shelves": {
+      "get": {"tags": ["shelves"], "responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Book": {"type": "object", "properties": {"id": {"type": "string"}}},
+    "Error": {"type": "object", "properties": {"message": {"type": "string"}}}
+  }
+}`
+
+const testAuthorsDocument = `{
+  "swagger": "2.0",
+  "basePath": "/authors/",
+  "security": [{"token": []}],
+  "securityDefinitions": {"token": {"type": "apiKey", "name": "Authorization", "in": "header"}},
+  "paths": {
+    "/{id}": {
+      "get": {"tags": ["authors"], "responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Error": {"type": "object", "properties": {"message": {"type": "string"}}}
+  }
+}`
+
+func parseTestDocument(t *testing.T, document string) *swagger.Swagger {
+	doc, err := swagger.ParseDocument([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestMerge(t *testing.T) {
+	books := parseTestDocument(t, testBooksDocument)
+	authors := parseTestDocument(t, testAuthorsDocument)
+	doc, err := Merge(&swagger.Info{Title: "portal", Version: "v1"}, []*Source{
+		{Name: "books", Document: books},
+		{Name: "authors", Document: authors},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	getBook := doc.Paths["/books/{id}"]
+	if getBook == nil || getBook.Get == nil {
+		t.Fatal("the paths should be prefixed with the basePaths", doc.Paths)
+	}
+	if len(getBook.Get.Tags) != 1 || getBook.Get.Tags[0] != "books" || getBook.Get.Produces[0] != "application/json" {
+		t.Error("the operation without tags should be tagged by the source, and the produces should be set", getBook.Get)
+	}
+	if tags := doc.Paths["/books/shelves"].Get.Tags; len(tags) != 1 || tags[0] != "books/shelves" {
+		t.Error("the tags should be prefixed", tags)
+	}
+	if len(doc.Tags) != 1 || doc.Tags[0].Name != "books/shelves" || books.Tags[0].Name != "shelves" {
+		t.Error("the declared tags should be prefixed, and the sources are not changed", doc.Tags)
+	}
+	getAuthor := doc.Paths["/authors/{id}"]
+	if getAuthor == nil || len(getAuthor.Get.Security) != 1 || doc.SecurityDefinitions["token"] == nil {
+		t.Error("the security should be merged", doc.Paths)
+	}
+	if len(doc.Definitions) != 2 {
+		t.Error("the same definitions should be merged", doc.Definitions)
+	}
+}
+
+func TestMerge_Conflicts(t *testing.T) {
+	nodes := []struct {
+		document string
+		message  string
+	}{
+		{strings.Replace(testAuthorsDocument, `"token": {"type": "apiKey"`, `"token": {"type": "basic"`, 1), "the security definition token of authors conflicts with books"},
+		{strings.Replace(testAuthorsDocument, `"/authors/"`, `"/books"`, 1), "the operation GET /books/{id} of authors conflicts with books"},
+	}
+	for _, node := range nodes {
+		_, err := Merge(&swagger.Info{}, []*Source{
+			{Name: "books", Document: parseTestDocument(t, testBooksDocument)},
+			{Name: "authors", Document: parseTestDocument(t, node.document)},
+		})
+		if err == nil || err.Error() != node.message {
+			t.Error("the conflict should be an error:", node.message, err)
+		}
+	}
+}
+
+func TestMerge_Names(t *testing.T) {
+	nodes := []struct {
+		names   []string
+		message string
+	}{
+		{[]string{"", "authors"}, "the source has no name"},
+		{[]string{"books", "books"}, "the name of the source books is duplicated"},
+	}
+	for _, node := range nodes {
+		_, err := Merge(&swagger.Info{}, []*Source{
+			{Name: node.names[0], Document: parseTestDocument(t, testBooksDocument)},
+			{Name: node.names[1], Document: parseTestDocument(t, testAuthorsDocument)},
+		})
+		if err == nil || err.Error() != node.message {
+			t.Error("the names of the sources should be checked:", node.message, err)
+		}
+	}
+}
+
+const testUsersDocument = `{
+  "swagger": "2.0",
+  "basePath": "/users",
+  "parameters": {"limit": {"name": "limit", "in": "query", "type": "integer", "maximum": 50}},
+  "paths": {
+    "/": {
+      "get": {
+        "parameters": [{"$ref": "#/parameters/limit"}],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Page"}},
+          "default": {"description": "error", "schema": {"$ref": "#/definitions/Error"}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Error": {"type": "object", "properties": {"code": {"type": "integer"}}},
+    "Page": {"type": "object", "properties": {"error": {"$ref": "#/definitions/Error"}}}
+  }
+}`
+
+func TestMerge_Namespaces(t *testing.T) {
+	books := strings.Replace(testBooksDocument, `"definitions": {`, `"parameters": {"limit": {"name": "limit", "in": "query", "type": "integer", "maximum": 10}},
+  "definitions": {
+    "Page": {"type": "object", "properties": {"error": {"$ref": "#/definitions/Error"}}},`, 1)
+	users := parseTestDocument(t, testUsersDocument)
+	doc, err := Merge(&swagger.Info{}, []*Source{
+		{Name: "books", Document: parseTestDocument(t, books)},
+		{Name: "users", Document: users},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Error", "Page", "users.Error", "users.Page"} {
+		if doc.Definitions[name] == nil {
+			t.Error("the conflicting definitions should be namespaced:", name, doc.Definitions)
+		}
+	}
+	if doc.Parameters["limit"] == nil || doc.Parameters["users.limit"] == nil {
+		t.Error("the conflicting global parameters should be namespaced", doc.Parameters)
+	}
+	if ref := doc.Definitions["users.Page"].Properties["error"].Ref; ref != "#/definitions/users.Error" {
+		t.Error("the references of the definitions should be rewritten", ref)
+	}
+	if ref := doc.Definitions["Page"].Properties["error"].Ref; ref != "#/definitions/Error" {
+		t.Error("the definitions of the first source should not be changed", ref)
+	}
+	operation := doc.Paths["/users/"].Get
+	if operation.Parameters[0].Ref != "#/parameters/users.limit" || operation.Responses["200"].Schema.Ref != "#/definitions/users.Page" ||
+		operation.Responses["default"].Schema.Ref != "#/definitions/users.Error" {
+		t.Error("the references of the operation should be rewritten", operation)
+	}
+	if users.Definitions["Error"] == nil || users.Paths["/"].Get.Responses["default"].Schema.Ref != "#/definitions/Error" {
+		t.Error("the source should not be changed")
+	}
+}
@@ -3,7 +3,9 @@ package ehttp
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
+	"time"
 
 	"github.com/enjoy-web/ehttp/swagger"
 )
@@ -13,6 +15,7 @@ type APIDoc interface {
 	ToSwaggerOperation() (*swagger.Operation, error)
 	ToSwaggerDefinitions() (map[string]*swagger.Schema, error)
 	GetParameters() map[string]Parameter
+	GetRequest() *Request
 	SetMethod(string)
 }
 
@@ -30,6 +33,12 @@ type APIDoc interface {
 //                 There can be one "body" parameter at most.
 //   Request -- the request in http body.
 //   Responses -- An object to hold responses that can be used across operations
+//   OperationID -- Unique string used to identify the operation. If it is empty, it is derived from the name of the handler function,
+//                  or from the method and the path if the handler is an anonymous function (like: GET /books/{id}, getBooksId).
+//   Deprecated -- Declares this operation to be deprecated. The responses of a deprecated operation have the header "Deprecation: true".
+//   Sunset -- the time when the deprecated operation will become unresponsive, the responses have the header Sunset (x-sunset in the document).
+//   ExternalDocs -- Additional external documentation for this operation.
+//   Schemes -- The transfer protocol for the operation. The value overrides the Config.Schemes.
 type APIDocCommon struct {
 	Tags                 []string
 	Summary              string
@@ -40,9 +49,33 @@ type APIDocCommon struct {
 	Parameters           map[string]Parameter
 	Request              *Request
 	Responses            map[int]Response
+	OperationID          string
+	Deprecated           bool
+	Sunset               time.Time
+	ExternalDocs         *ExternalDocs
+	Schemes              []Scheme
 	method               string
 }
 
+// ExternalDocs Allows referencing an external resource for extended documentation.
+// Fields:
+//   Description -- A short description of the target documentation.
+//   URL -- The URL for the target documentation. (required)
+type ExternalDocs struct {
+	Description string
+	URL         string
+}
+
+func (docs *ExternalDocs) toSwaggerExternalDocs() (*swagger.ExternalDocs, error) {
+	if docs == nil {
+		return nil, nil
+	}
+	if docs.URL == "" {
+		return nil, errors.New("ExternalDocs.URL should not be empty")
+	}
+	return &swagger.ExternalDocs{Description: docs.Description, URL: docs.URL}, nil
+}
+
 func (doc *APIDocCommon) SetMethod(method string) {
 	doc.method = method
 }
@@ -52,7 +85,26 @@ func (doc APIDocCommon) ToSwaggerOperation() (*swagger.Operation, error) {
 	if err := doc.check(); err != nil {
 		return nil, err
 	}
-	operation := &swagger.Operation{Summary: doc.Summary, Description: doc.Description}
+	operation := &swagger.Operation{
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		OperationID: doc.OperationID,
+		Deprecated:  doc.Deprecated,
+	}
+	// set Sunset
+	if !doc.Sunset.IsZero() {
+		operation.Sunset = doc.Sunset.UTC().Format(http.TimeFormat)
+	}
+	// set ExternalDocs
+	externalDocs, err := doc.ExternalDocs.toSwaggerExternalDocs()
+	if err != nil {
+		return nil, err
+	}
+	operation.ExternalDocs = externalDocs
+	// set Schemes
+	for _, scheme := range doc.Schemes {
+		operation.Schemes = append(operation.Schemes, string(scheme))
+	}
 	// set Tags
 	if len(doc.Tags) > 0 {
 		operation.Tags = doc.Tags
@@ -85,7 +137,7 @@ func (doc APIDocCommon) ToSwaggerOperation() (*swagger.Operation, error) {
 	}
 
 	if doc.Request != nil {
-		param, err := doc.Request.toSwaggerParameter()
+		param, err := doc.Request.toSwaggerParameterWithConsumes(doc.Consumes)
 		if err != nil {
 			return nil, err
 		}
@@ -101,7 +153,7 @@ func (doc APIDocCommon) ToSwaggerOperation() (*swagger.Operation, error) {
 		if statusCode == -1 {
 			code = "default"
 		}
-		swaggerResponse, err := response.ToSwaggerResponse()
+		swaggerResponse, err := response.toSwaggerResponseWithProduces(doc.Produces)
 		if err != nil {
 			return nil, err
 		}
@@ -112,7 +164,11 @@ func (doc APIDocCommon) ToSwaggerOperation() (*swagger.Operation, error) {
 
 // ToSwaggerDefinitions to map[string]*swagger.Schema (swagger Definitions)
 func (doc APIDocCommon) ToSwaggerDefinitions() (map[string]*swagger.Schema, error) {
-	creater := StructDocCreater{}
+	return doc.toSwaggerDefinitions(&StructDocCreater{})
+}
+
+// toSwaggerDefinitions the definitions are got by the creater (like: the creater of an Engine with Config.DocCommentsFromSource)
+func (doc APIDocCommon) toSwaggerDefinitions(creater *StructDocCreater) (map[string]*swagger.Schema, error) {
 	structDocs := map[string]*StructDoc{}
 	// Request
 	if doc.Request != nil && doc.Request.Model != nil {
@@ -144,6 +200,11 @@ func (doc APIDocCommon) GetParameters() map[string]Parameter {
 	return doc.Parameters
 }
 
+// GetRequest Get Request
+func (doc APIDocCommon) GetRequest() *Request {
+	return doc.Request
+}
+
 func (doc APIDocCommon) check() error {
 	if doc.hasformData() {
 		if doc.Request != nil {
@@ -161,6 +222,14 @@ func (doc APIDocCommon) check() error {
 			return errors.New("In method GET, doc.Request should be nil")
 		}
 	}
+	if !doc.Sunset.IsZero() && !doc.Deprecated {
+		return errors.New("doc.Sunset is set, doc.Deprecated should be true")
+	}
+	for _, scheme := range doc.Schemes {
+		if scheme != SchemeHTTP && scheme != SchemeHTTPS && scheme != "ws" && scheme != "wss" {
+			return errors.New("invalid scheme " + string(scheme))
+		}
+	}
 	return nil
 }
 
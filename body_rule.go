@@ -0,0 +1,131 @@
+package ehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+// requestBodyRule the rule of the request body, check the JSON request body.
+// The fields with the tag readonly:"true" are rejected,
+// and the value of a registered interface (see RegisterPolymorphic) is checked as the struct type selected by the discriminator.
+type requestBodyRule struct {
+	structUUID string
+	structDocs map[string]*StructDoc
+}
+
+// newRequestBodyRule return nil if the request model has no read only fields and no registered interfaces
+func newRequestBodyRule(request *Request) (parameterRule, error) {
+	if request == nil || request.Model == nil {
+		return nil, nil
+	}
+	creater := StructDocCreater{}
+	structDocs, err := creater.GetStructDocMap(request.Model)
+	if err != nil {
+		return nil, err
+	}
+	if !hasReadOnlyStructField(structDocs) && !hasPolymorphicStructDoc(structDocs) {
+		return nil, nil
+	}
+	structType, err := getStructReflectType(request.Model)
+	if err != nil {
+		return nil, err
+	}
+	structUUID, err := getStructUUID(structType)
+	if err != nil {
+		return nil, err
+	}
+	return &requestBodyRule{structUUID: structUUID, structDocs: structDocs}, nil
+}
+
+// Check if the request body is valid. The body is restored, so that the handler can read it again.
+func (r requestBodyRule) Check(c Context) error {
+	request := c.Request()
+	contentType := request.Header.Get("Content-Type")
+	if contentType != "" && !strings.Contains(contentType, "json") {
+		return nil
+	}
+	if request.Body == nil {
+		return nil
+	}
+	data, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return errors.New("invalid request body, " + err.Error())
+	}
+	return r.checkValue(r.structUUID, body, "")
+}
+
+func (r requestBodyRule) checkValue(structUUID string, value interface{}, path string) error {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if err := r.checkValue(structUUID, item, path); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		doc, ok := r.structDocs[structUUID]
+		if !ok {
+			return nil
+		}
+		if doc.IsPolymorphic() {
+			if doc.Discriminator == "" {
+				return nil
+			}
+			discriminator, _ := v[doc.Discriminator].(string)
+			if discriminator == "" {
+				return errors.New("miss the discriminator " + path + doc.Discriminator)
+			}
+			structUUID, ok := doc.DiscriminatorMapping[discriminator]
+			if !ok {
+				return errors.New("invalid discriminator " + path + doc.Discriminator + " (" + discriminator + ")")
+			}
+			return r.checkValue(structUUID, v, path)
+		}
+		for _, field := range doc.StructFields {
+			fieldValue, ok := v[field.Name]
+			if !ok {
+				continue
+			}
+			if field.ReadOnly {
+				return errors.New("the field " + path + field.Name + " is read only")
+			}
+			if field.IsStruct {
+				if err := r.checkValue(field.RefStructUUID, fieldValue, path+field.Name+"."); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasReadOnlyStructField(structDocs map[string]*StructDoc) bool {
+	for _, doc := range structDocs {
+		for _, field := range doc.StructFields {
+			if field.ReadOnly {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasPolymorphicStructDoc(structDocs map[string]*StructDoc) bool {
+	for _, doc := range structDocs {
+		if doc.IsPolymorphic() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package ehttp
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testReadOnlyAuthor struct {
+	ID   string `json:"id" readonly:"true"`
+	Name string `json:"name"`
+}
+
+type testReadOnlyBook struct {
+	ID       string                `json:"id" readonly:"true"`
+	Title    string                `json:"title"`
+	Password string                `json:"password" writeonly:"true"`
+	Authors  []*testReadOnlyAuthor `json:"authors"`
+}
+
+func newTestBodyContext(contentType, body string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(POST, "/books", bytes.NewBufferString(body))
+	if contentType != "" {
+		c.Request.Header.Set("Content-Type", contentType)
+	}
+	return c
+}
+
+func TestRequestBodyRule(t *testing.T) {
+	rule, err := newRequestBodyRule(&Request{Model: &testReadOnlyBook{}})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if rule == nil {
+		testError(t, "rule should not be nil")
+		return
+	}
+
+	validBodys := []string{
+		`{"title":"book","password":"123456"}`,
+		`{"title":"book","authors":[{"name":"John"}]}`,
+		``,
+	}
+	for _, body := range validBodys {
+		c := newTestBodyContext(Application_Json, body)
+		if err := rule.Check(ginContext{c}); err != nil {
+			testError(t, body, err)
+		}
+		// the body can be read again
+		data, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			testError(t, err)
+		}
+		if string(data) != body {
+			testError(t, string(data)+" should be equal to "+body)
+		}
+	}
+
+	invalidBodys := []string{
+		`{"id":"123","title":"book"}`,
+		`{"title":"book","authors":[{"id":"1","name":"John"}]}`,
+		`{"title":`,
+	}
+	for _, body := range invalidBodys {
+		c := newTestBodyContext("", body)
+		if err := rule.Check(ginContext{c}); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, body+" should be invalid")
+		}
+	}
+
+	// the body is not json
+	c := newTestBodyContext(Application_Xml, `<book><id>1</id></book>`)
+	if err := rule.Check(ginContext{c}); err != nil {
+		testError(t, err)
+	}
+
+	// no read only field, no rule
+	type noReadOnly struct {
+		ID string `json:"id"`
+	}
+	rule, err = newRequestBodyRule(&Request{Model: &noReadOnly{}})
+	if err != nil {
+		testError(t, err)
+	}
+	if rule != nil {
+		testError(t, "rule should be nil")
+	}
+}
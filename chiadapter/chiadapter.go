@@ -0,0 +1,121 @@
+// Package chiadapter register the documented operations of an ehttp.Engine on a chi router (github.com/go-chi/chi/v5).
+// The paths of chi are in the swagger style (like: /books/{id}), the values of the parameters in path are got by chi.URLParam.
+// The requests are checked like gin: the parameters, the request body and CORS (see Config.AllowOrigin),
+// the error is passed to the handler. The mock mode and the validation of the responses are supported too (see ehttp.Route.Serve).
+//
+// example:
+//    router := ehttp.NewEngine(conf)
+//    r := chiadapter.New(router, chi.NewRouter())
+//    r.GET("/books/{id}", doc, func(w http.ResponseWriter, req *http.Request, err error) {
+//        if err != nil {
+//            http.Error(w, err.Error(), http.StatusBadRequest)
+//            return
+//        }
+//        fmt.Fprint(w, chi.URLParam(req, "id"))
+//    })
+//    http.ListenAndServe(":8000", r)
+package chiadapter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/enjoy-web/ehttp/httpadapter"
+	"github.com/go-chi/chi/v5"
+)
+
+// Router register the documented operations of the Engine on the chi router
+// Fields:
+//   Engine -- the engine of the documents
+type Router struct {
+	Engine     *ehttp.Engine
+	router     chi.Router
+	preflights map[string]bool
+}
+
+// New new a Router of the engine, if router is nil, chi.NewRouter() is used.
+// The documents are served on the router if the Config.OpenAPIDocumentURL is true.
+func New(engine *ehttp.Engine, router chi.Router) *Router {
+	if router == nil {
+		router = chi.NewRouter()
+	}
+	r := &Router{Engine: engine, router: router, preflights: map[string]bool{}}
+	if engine.Conf.OpenAPIDocumentURL {
+		for url, handler := range engine.DocumentHandlers() {
+			router.Get(r.getPath(url), handler)
+		}
+	}
+	return r
+}
+
+// ServeHTTP serve the requests by the chi router
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.router.ServeHTTP(w, req)
+}
+
+// GET register the documented operation GET on the path (like: /books/{id}, without the BasePath)
+func (r *Router) GET(path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	return r.Handle(ehttp.GET, path, doc, handler)
+}
+
+// POST register the documented operation POST on the path
+func (r *Router) POST(path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	return r.Handle(ehttp.POST, path, doc, handler)
+}
+
+// PUT register the documented operation PUT on the path
+func (r *Router) PUT(path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	return r.Handle(ehttp.PUT, path, doc, handler)
+}
+
+// PATCH register the documented operation PATCH on the path
+func (r *Router) PATCH(path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	return r.Handle(ehttp.PATCH, path, doc, handler)
+}
+
+// DELETE register the documented operation DELETE on the path
+func (r *Router) DELETE(path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	return r.Handle(ehttp.DELETE, path, doc, handler)
+}
+
+// Handle register the documented operation on the path, the path is in the swagger style or the gin style (like: /books/{id}, /books/:id)
+func (r *Router) Handle(method string, path string, doc ehttp.APIDoc, handler httpadapter.HandlerFunc) error {
+	if handler == nil {
+		return errors.New(method + " " + path + " miss HandlerFunc")
+	}
+	// the operation is documented only if the chi router accepts the path
+	route, err := r.Engine.RegisterRoute(method, path, doc, handler, func(route *ehttp.Route) error {
+		return r.handleFunc(method, route.Path, func(w http.ResponseWriter, req *http.Request) {
+			route.Serve(httpadapter.NewContext(w, req, chi.URLParam), w, func(w http.ResponseWriter, err error) {
+				handler(w, req, err)
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if r.Engine.Conf.AllowOrigin && !r.preflights[route.Path] {
+		r.preflights[route.Path] = true
+		return r.handleFunc(ehttp.OPTIONS, route.Path, httpadapter.PreflightHandler(r.Engine, route.Path, chi.URLParam))
+	}
+	return nil
+}
+
+// handleFunc register the handler on the chi router, the invalid patterns are errors (chi panics)
+func (r *Router) handleFunc(method string, path string, handler http.HandlerFunc) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("%v", v)
+		}
+	}()
+	r.router.MethodFunc(method, r.getPath(path), handler)
+	return nil
+}
+
+// getPath the path of chi with the BasePath, the swagger path is a chi path (like: /v1/books/{id})
+func (r *Router) getPath(path string) string {
+	return strings.TrimRight(r.Engine.Conf.BasePath, "/") + path
+}
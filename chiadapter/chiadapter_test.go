@@ -0,0 +1,125 @@
+package chiadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/go-chi/chi/v5"
+)
+
+func newTestRouter(t *testing.T, conf *ehttp.Config) *Router {
+	router := New(ehttp.NewEngine(conf), nil)
+	getBook := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{
+			"id":    ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}},
+			"limit": ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Max: "10"}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	err := router.GET("/books/{id}", getBook, func(w http.ResponseWriter, r *http.Request, err error) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(chi.URLParam(r, "id") + ":" + r.URL.Query().Get("limit")))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func serve(router *Router, method string, url string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, nil)
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestRouter(t *testing.T) {
+	router := newTestRouter(t, &ehttp.Config{BasePath: "/v1", OpenAPIDocumentURL: true})
+	nodes := []struct {
+		url  string
+		code int
+		resp string
+	}{
+		{"/v1/books/1?limit=2", 200, "1:2"},
+		{"/v1/books/abc", 400, "invalid syntax"},
+		{"/v1/books/1?limit=11", 400, "greater than the maximum"},
+		{"/v1/docs/swagger.json", 200, `"/books/{id}"`},
+		{"/v1/docs/reference.md", 200, "/books/{id}"},
+	}
+	for _, node := range nodes {
+		w := serve(router, "GET", node.url, nil)
+		if w.Code != node.code || !strings.Contains(w.Body.String(), node.resp) {
+			t.Error(node.url, "unexpected response", w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestRouter_RejectedPath(t *testing.T) {
+	router := newTestRouter(t, &ehttp.Config{})
+	getBook := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "string"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	// chi rejects the duplicate param key
+	if err := router.GET("/authors/{id}/{id}", getBook, func(w http.ResponseWriter, r *http.Request, err error) {}); err == nil {
+		t.Error("the invalid path should be an error")
+	}
+	if _, ok := router.Engine.Swagger.Paths["/authors/{id}/{id}"]; ok {
+		t.Error("the operation of the rejected path should not be documented")
+	}
+}
+
+func TestRouter_CORS(t *testing.T) {
+	router := newTestRouter(t, &ehttp.Config{AllowOrigin: true, Origins: []string{"http://a.com"}})
+	w := serve(router, "OPTIONS", "/books/1", map[string]string{"Origin": "http://a.com"})
+	if w.Code != 200 || w.Header().Get("Access-Control-Allow-Origin") != "http://a.com" {
+		t.Error("the preflight request should be allowed", w.Code, w.Header())
+	}
+	w = serve(router, "GET", "/books/1", map[string]string{"Origin": "http://b.com"})
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "is not allow") {
+		t.Error("the origin should not be allowed", w.Code, w.Body.String())
+	}
+}
+
+func TestRouter_MockMode(t *testing.T) {
+	router := New(ehttp.NewEngine(&ehttp.Config{MockMode: true}), nil)
+	doc := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}}},
+		Responses:  map[int]ehttp.Response{204: ehttp.Response{Description: "deleted"}},
+	}
+	err := router.DELETE("/books/{id}", doc, func(w http.ResponseWriter, r *http.Request, err error) {
+		t.Error("the handler should not be called in the mock mode")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(router, "DELETE", "/books/1", nil); w.Code != 204 {
+		t.Error("the mock response should be written", w.Code, w.Body.String())
+	}
+	if w := serve(router, "DELETE", "/books/abc", nil); w.Code != 400 {
+		t.Error("the invalid parameter should be 400", w.Code, w.Body.String())
+	}
+}
+
+func TestRouter_StrictResponses(t *testing.T) {
+	router := New(ehttp.NewEngine(&ehttp.Config{StrictResponses: true, ResponseViolationHook: func(*ehttp.ResponseViolation) {}}), nil)
+	doc := &ehttp.APIDocCommon{Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}}}
+	err := router.GET("/books", doc, func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(router, "GET", "/books", nil); w.Code != 500 || !strings.Contains(w.Body.String(), "201") {
+		t.Error("the undocumented status should be replaced by 500", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/aggregate"
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/ghodss/yaml"
+)
+
+func init() {
+	commands["aggregate"] = &command{
+		Usage: "aggregate [-title title] [-version version] [-o file] [name=]swagger.json...    merge the swagger 2.0 documents of the services into one portal document",
+		Run:   runAggregate,
+	}
+}
+
+// runAggregate merge the documents, the tags are prefixed with the names of the sources (default is the file name, like: books.json -> books)
+func runAggregate(args []string) error {
+	flags := flag.NewFlagSet("aggregate", flag.ContinueOnError)
+	title := flags.String("title", "API portal", "the title of the portal document")
+	version := flags.String("version", "1.0", "the version of the portal document")
+	output := flags.String("o", "", "the output file (YAML if the extension is .yaml or .yml, else JSON), default is the standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("the swagger documents are required")
+	}
+	sources := []*aggregate.Source{}
+	for _, arg := range flags.Args() {
+		name, fileName := "", arg
+		if i := strings.Index(arg, "="); i >= 0 {
+			name, fileName = arg[:i], arg[i+1:]
+		} else {
+			name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+		}
+		doc, err := readSwaggerDocument(fileName)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, &aggregate.Source{Name: name, Document: doc})
+	}
+	doc, err := aggregate.Merge(&swagger.Info{Title: *title, Version: *version}, sources)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if ext := strings.ToLower(filepath.Ext(*output)); ext == ".yaml" || ext == ".yml" {
+		data, err = yaml.Marshal(doc)
+	} else {
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(*output, data, 0644)
+}
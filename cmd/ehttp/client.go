@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"path/filepath"
+)
+
+func init() {
+	commands["client"] = &command{
+		Usage: "client [-lang go|ts] [-func RegisterAPIs] [-pkg client] [-o file] [dir]    generate a typed Go or TypeScript client of the registered APIs",
+		Run:   runClient,
+	}
+}
+
+// runClient generate the client with the registration function of the package (see runGenerate).
+// example:
+//    //go:generate go run github.com/enjoy-web/ehttp/cmd/ehttp client -func RegisterAPIs -pkg bookclient -o ../bookclient/client.go
+//    //go:generate go run github.com/enjoy-web/ehttp/cmd/ehttp client -lang ts -func RegisterAPIs -o ../web/src/api.ts
+func runClient(args []string) error {
+	flags := flag.NewFlagSet("client", flag.ContinueOnError)
+	lang := flags.String("lang", "go", "the language of the client: go or ts (TypeScript)")
+	funcName := flags.String("func", "RegisterAPIs", "the registration function of the package")
+	packageName := flags.String("pkg", "client", "the package name of the Go client")
+	output := flags.String("o", "", "the output file, default is client/client.go or client/client.ts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+	if *lang != "go" && *lang != "ts" {
+		return errors.New("unsupported language " + *lang)
+	}
+	if *output == "" {
+		*output = "client/client." + *lang
+	}
+	fileName, err := filepath.Abs(*output)
+	if err != nil {
+		return err
+	}
+	if *lang == "ts" {
+		return runMainStub(dir, *funcName, "-ts-client", fileName)
+	}
+	return runMainStub(dir, *funcName, "-go-client", fileName, "-go-package", *packageName)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"go/build"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/enjoy-web/ehttp"
+)
+
+func init() {
+	commands["comments"] = &command{
+		Usage: "comments [-o ehttp_comments.go] [-pkg importPath] [dir]    generate a Go file which registers the doc comments of the models",
+		Run:   runComments,
+	}
+}
+
+// runComments parse the Go source files in the dir, and generate a file registering the doc comments.
+// example:
+//    //go:generate go run github.com/enjoy-web/ehttp/cmd/ehttp comments -o ehttp_comments.go
+func runComments(args []string) error {
+	flags := flag.NewFlagSet("comments", flag.ContinueOnError)
+	output := flags.String("o", "ehttp_comments.go", "the output file, relative to the dir")
+	pkgPath := flags.String("pkg", "", "the import path of the package, default is the result of `go list`")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return err
+	}
+	if *pkgPath == "" {
+		*pkgPath, err = getImportPath(dir, pkg.Name)
+		if err != nil {
+			return err
+		}
+	}
+	outputFile := filepath.Join(dir, *output)
+	files := []string{}
+	for _, name := range pkg.GoFiles {
+		file := filepath.Join(dir, name)
+		if file != outputFile {
+			files = append(files, file)
+		}
+	}
+	comments, err := ehttp.ParseDocComments(*pkgPath, files...)
+	if err != nil {
+		return err
+	}
+	source, err := ehttp.GenerateDocCommentsSource(pkg.Name, comments)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(outputFile, source, 0644)
+}
+
+// getImportPath the reflect package path of the types in a main package is "main"
+func getImportPath(dir, pkgName string) (string, error) {
+	if pkgName == "main" {
+		return "main", nil
+	}
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}}", dir).Output()
+	if err != nil {
+		return "", errors.New("go list " + dir + ", " + err.Error() + ", use -pkg to set the import path")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/enjoy-web/ehttp/diff"
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/ghodss/yaml"
+)
+
+func init() {
+	commands["diff"] = &command{
+		Usage: "diff [-breaking] old.json new.json    compare two swagger 2.0 documents (JSON or YAML), exit 1 if there are breaking changes",
+		Run:   runDiff,
+	}
+}
+
+// runDiff print the changes between the old and the new documents, and return an error if there are breaking changes.
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	onlyBreaking := flags.Bool("breaking", false, "only print the breaking changes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("the old and the new documents are required")
+	}
+	old, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	new, err := readSwaggerDocument(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+	changes := diff.Compare(old, new)
+	for _, change := range changes {
+		if change.Breaking || !*onlyBreaking {
+			fmt.Println(change)
+		}
+	}
+	if n := len(diff.BreakingChanges(changes)); n > 0 {
+		return fmt.Errorf("%d breaking changes", n)
+	}
+	return nil
+}
+
+// readSwaggerDocument read the swagger 2.0 document, the YAML decoder can decode JSON too
+func readSwaggerDocument(fileName string) (*swagger.Swagger, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.New(fileName + " " + err.Error())
+	}
+	doc, err := swagger.ParseDocument(data)
+	if err != nil {
+		return nil, errors.New(fileName + " " + err.Error())
+	}
+	return doc, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/enjoy-web/ehttp/export"
+)
+
+func init() {
+	commands["export"] = &command{
+		Usage: "export [-format postman|http|curl] [-o file] swagger.json    export a swagger 2.0 document to a Postman collection, a .http file or a curl script",
+		Run:   runExport,
+	}
+}
+
+// runExport export the document to the file of the HTTP clients
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "postman", "the format, postman (Postman Collection v2.1), http (.http file) or curl (shell script)")
+	output := flags.String("o", "", "the output file, default is the standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the swagger document is required")
+	}
+	doc, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch *format {
+	case "postman":
+		data, err = json.MarshalIndent(export.ToPostmanCollection(doc), "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	case "http":
+		data = []byte(export.ToHTTPFile(doc))
+	case "curl":
+		data = []byte(export.ToCurlScript(doc))
+	default:
+		return errors.New("invalid format " + *format + ", the format must be postman, http or curl")
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(*output, data, 0644)
+}
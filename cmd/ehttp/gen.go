@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/enjoy-web/ehttp/gen"
+)
+
+func init() {
+	commands["gen"] = &command{
+		Usage: "gen server [-pkg name] [-func RegisterAPIs] [-o api] [-force] swagger.yaml    generate the models, the APIDocs and the handler stubs of a swagger 2.0 document",
+		Run:   runGen,
+	}
+}
+
+// runGen generate the Go source of a server from the swagger document (contract-first).
+// The files models.go and apis.go are regenerated, handlers.go is not overwritten unless -force is set.
+// The parts of the document which can't be generated exactly are printed as the warnings.
+// example:
+//    ehttp gen server -o ./bookapi spec.yaml
+//    ehttp generate -func NewEngine -o ./docs ./bookapi
+func runGen(args []string) error {
+	if len(args) == 0 || args[0] != "server" {
+		return errors.New("the target is required, like: ehttp gen server spec.yaml")
+	}
+	flags := flag.NewFlagSet("gen server", flag.ContinueOnError)
+	packageName := flags.String("pkg", "", "the package name, default is the name of the output dir, or api")
+	funcName := flags.String("func", "RegisterAPIs", "the name of the registration function")
+	output := flags.String("o", "api", "the output dir")
+	force := flags.Bool("force", false, "overwrite handlers.go if it exists")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the swagger document is required")
+	}
+	doc, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *packageName == "" {
+		*packageName = "api"
+		if dir, err := filepath.Abs(*output); err == nil && token.IsIdentifier(filepath.Base(dir)) {
+			*packageName = filepath.Base(dir)
+		}
+	}
+	files, err := gen.Server(doc, &gen.Options{Package: *packageName, FuncName: *funcName})
+	if err != nil {
+		return err
+	}
+	for _, warning := range files.Warnings {
+		fmt.Fprintln(os.Stderr, "warning: "+warning)
+	}
+	if err := os.MkdirAll(*output, 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(*output, "models.go"), files.Models, 0644); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(*output, "apis.go"), files.APIs, 0644); err != nil {
+		return err
+	}
+	handlersFile := filepath.Join(*output, "handlers.go")
+	if _, err := os.Stat(handlersFile); err == nil && !*force {
+		fmt.Fprintln(os.Stderr, handlersFile+" exists, it is not overwritten (use -force), add the handlers of the new operations to it")
+		return nil
+	}
+	return ioutil.WriteFile(handlersFile, files.Handlers, 0644)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"go/build"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+)
+
+func init() {
+	commands["generate"] = &command{
+		Usage: "generate [-func RegisterAPIs] [-o docs] [dir]    write the swagger 2.0 and OpenAPI 3 documents without starting the server",
+		Run:   runGenerate,
+	}
+}
+
+// runGenerate generate a main stub which calls the registration function of the package, run it, and write the documents.
+// The registration function is one of:
+//    func() *ehttp.Engine
+//    func() (*ehttp.Engine, error)
+//    func(router *ehttp.Engine) error  (the Engine is created by ehttp.NewEngine(&ehttp.Config{}))
+// example:
+//    //go:generate go run github.com/enjoy-web/ehttp/cmd/ehttp generate -func RegisterAPIs -o ../docs
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	funcName := flags.String("func", "RegisterAPIs", "the registration function of the package")
+	output := flags.String("o", "docs", "the output dir")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+	outputDir, err := filepath.Abs(*output)
+	if err != nil {
+		return err
+	}
+	return runMainStub(dir, *funcName, "-o", outputDir)
+}
+
+// runMainStub generate the main stub which calls the registration function of the package in the dir,
+// and run it with the arguments
+func runMainStub(dir, funcName string, args ...string) error {
+	if !token.IsExported(funcName) {
+		return errors.New("the registration function " + funcName + " must be exported")
+	}
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return err
+	}
+	if pkg.Name == "main" {
+		return errors.New("a main package can't be imported, move the registration function to a library package")
+	}
+	importPath, err := getImportPath(dir, pkg.Name)
+	if err != nil {
+		return err
+	}
+	source, err := generateMainStub(importPath, funcName)
+	if err != nil {
+		return err
+	}
+	// the stub is in a hidden dir of the package, so the imports are resolved by the module of the package
+	stubDir, err := ioutil.TempDir(dir, ".ehttp-generate-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stubDir)
+	if err := ioutil.WriteFile(filepath.Join(stubDir, "main.go"), source, 0644); err != nil {
+		return err
+	}
+	cmd := exec.Command("go", append([]string{"run", "./" + filepath.Base(stubDir)}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+var mainStubTemplate = template.Must(template.New("main").Parse(`// Code generated by ehttp generate. DO NOT EDIT.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/gin-gonic/gin"
+
+	api "{{.ImportPath}}"
+)
+
+func main() {
+	output := flag.String("o", "", "the output dir of the documents")
+	goClient := flag.String("go-client", "", "the output file of the Go client")
+	goPackage := flag.String("go-package", "client", "the package name of the Go client")
+	tsClient := flag.String("ts-client", "", "the output file of the TypeScript client")
+	flag.Parse()
+	gin.SetMode(gin.ReleaseMode)
+	router, err := register(api.{{.FuncName}})
+	if err == nil && *output != "" {
+		err = router.WriteDocuments(*output)
+	}
+	if err == nil && *goClient != "" {
+		err = writeGoClient(router, *goClient, *goPackage)
+	}
+	if err == nil && *tsClient != "" {
+		err = writeTypeScriptClient(router, *tsClient)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func writeGoClient(router *ehttp.Engine, fileName, packageName string) error {
+	source, err := router.GenerateGoClient(packageName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, source, 0644)
+}
+
+func writeTypeScriptClient(router *ehttp.Engine, fileName string) error {
+	source, err := router.GenerateTypeScript()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, []byte(source), 0644)
+}
+
+func register(f interface{}) (*ehttp.Engine, error) {
+	switch f := f.(type) {
+	case func() *ehttp.Engine:
+		return f(), nil
+	case func() (*ehttp.Engine, error):
+		return f()
+	case func(*ehttp.Engine) error:
+		router := ehttp.NewEngine(&ehttp.Config{})
+		return router, f(router)
+	}
+	return nil, fmt.Errorf("{{.FuncName}} is %T, it must be func() *ehttp.Engine, func() (*ehttp.Engine, error) or func(*ehttp.Engine) error", f)
+}
+`))
+
+func generateMainStub(importPath, funcName string) ([]byte, error) {
+	b := bytes.Buffer{}
+	data := struct{ ImportPath, FuncName string }{importPath, funcName}
+	if err := mainStubTemplate.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/lint"
+)
+
+func init() {
+	commands["lint"] = &command{
+		Usage: "lint [-rules rule1,rule2] [-list] swagger.json    lint a swagger 2.0 document (JSON or YAML), exit 1 if there are problems",
+		Run:   runLint,
+	}
+}
+
+// runLint print the problems of the document, and return an error if there are problems.
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	ruleNames := flags.String("rules", "", "the enabled rules separated by commas, all the rules are enabled by default")
+	list := flags.Bool("list", false, "list the rules")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *list {
+		for _, rule := range lint.Rules() {
+			fmt.Printf("%-24s %s\n", rule.Name, rule.Description)
+		}
+		return nil
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the swagger document is required")
+	}
+	doc, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	enabledRules := []string{}
+	if *ruleNames != "" {
+		enabledRules = strings.Split(*ruleNames, ",")
+	}
+	problems, err := lint.Lint(doc, enabledRules...)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems", len(problems))
+	}
+	return nil
+}
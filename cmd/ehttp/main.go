@@ -0,0 +1,63 @@
+// Command ehttp is the command line tool of ehttp.
+//
+// Usage:
+//    ehttp <command> [arguments]
+//
+// The commands are:
+//    aggregate   merge the swagger 2.0 documents of the services into one portal document
+//    client      generate a typed Go or TypeScript client of the APIs registered by a package
+//    comments    generate a Go file which registers the doc comments of the models in a package
+//    diff        compare two swagger 2.0 documents, exit 1 if there are breaking changes
+//    export      export a swagger 2.0 document to a Postman collection, a .http file or a curl script
+//    gen         generate the models, the APIDocs and the handler stubs of a server from a swagger 2.0 document
+//    generate    write the swagger 2.0 and OpenAPI 3 documents of a package without starting the server
+//    lint        lint a swagger 2.0 document, exit 1 if there are problems
+//    mock        serve the mock server of a swagger 2.0 document
+//    reference   render the Markdown or the HTML API reference of a swagger 2.0 document
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command a sub command of ehttp
+type command struct {
+	Usage string
+	Run   func(args []string) error
+}
+
+var commands = map[string]*command{}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "ehttp: unknown command "+name)
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.Run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "ehttp "+name+": "+err.Error())
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: ehttp <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "The commands are:")
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "    %s\n", commands[name].Usage)
+	}
+}
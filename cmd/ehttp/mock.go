@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	commands["mock"] = &command{
+		Usage: "mock [-addr :8080] [-seed 0] [-cors] swagger.json    serve the mock server of a swagger 2.0 document, the responses are synthesized from the documented responses",
+		Run:   runMock,
+	}
+}
+
+// runMock serve the operations of the document in the mock mode (see ehttp.Config.MockMode).
+// The parameters are validated, the status code of the response is selected by the header Prefer.
+// example:
+//    ehttp mock -addr :8080 spec.yaml
+//    curl -H "Prefer: code=404" http://localhost:8080/v1/books/1
+func runMock(args []string) error {
+	flags := flag.NewFlagSet("mock", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "the listen address")
+	seed := flags.Int64("seed", 0, "the seed of the random values")
+	cors := flags.Bool("cors", false, "allow the cross-origin requests of all the origins")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the swagger document is required")
+	}
+	doc, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	gin.SetMode(gin.ReleaseMode)
+	conf := &ehttp.Config{
+		BasePath:   doc.BasePath,
+		MockMode:   true,
+		MockSeed:   *seed,
+		DomainName: doc.Host,
+	}
+	if doc.Info != nil {
+		conf.Title, conf.Version, conf.Description = doc.Info.Title, doc.Info.Version, doc.Info.Description
+	}
+	if *cors {
+		conf.AllowOrigin = true
+		conf.Origins = []string{"*"}
+	}
+	router := ehttp.NewEngine(conf)
+	// the handler is replaced by the mock responses
+	unused := func(c *gin.Context, err error) {
+		c.Status(http.StatusNotImplemented)
+	}
+	if err := router.HandleDocument(doc, unused); err != nil {
+		return err
+	}
+	fmt.Printf("the mock server of %s is listening on %s\n", flags.Arg(0), *addr)
+	return router.Run(*addr)
+}
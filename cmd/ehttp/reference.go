@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/enjoy-web/ehttp/reference"
+)
+
+func init() {
+	commands["reference"] = &command{
+		Usage: "reference [-format md|html] [-template file] [-o file] swagger.json    render the offline API reference of a swagger 2.0 document",
+		Run:   runReference,
+	}
+}
+
+// runReference render the Markdown or the HTML reference, the default templates can be overridden by -template
+// (the data of the template is *reference.Reference).
+func runReference(args []string) error {
+	flags := flag.NewFlagSet("reference", flag.ContinueOnError)
+	format := flags.String("format", "md", "the format of the reference, md or html")
+	templateFile := flags.String("template", "", "the file of the template overriding the default template")
+	output := flags.String("o", "", "the output file, default is the standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("the swagger document is required")
+	}
+	if *format != "md" && *format != "html" {
+		return errors.New("invalid format " + *format + ", the format must be md or html")
+	}
+	doc, err := readSwaggerDocument(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	renderer := reference.NewRenderer()
+	if *templateFile != "" {
+		text, err := ioutil.ReadFile(*templateFile)
+		if err != nil {
+			return err
+		}
+		if *format == "md" {
+			renderer.MarkdownTemplate, err = reference.ParseMarkdownTemplate(string(text))
+		} else {
+			renderer.HTMLTemplate, err = reference.ParseHTMLTemplate(string(text))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	b := bytes.Buffer{}
+	if *format == "md" {
+		err = renderer.Markdown(&b, doc)
+	} else {
+		err = renderer.HTML(&b, doc)
+	}
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(b.Bytes())
+		return err
+	}
+	return ioutil.WriteFile(*output, b.Bytes(), 0644)
+}
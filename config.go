@@ -1,5 +1,13 @@
 package ehttp
 
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
 // Config config for server
 // Fields:
 //   Schemes -- such as SCHEMES_HTTP, SCHEMES_HTTPS, SCHEMES_HTTP_AND_HTTPS
@@ -11,16 +19,153 @@ package ehttp
 //   Origins -- ( Cross-Origin Resource Sharing ) Access-Control-Allow-Origin
 //   OpenAPIDocumentURL -- open the url /docs/swagger.json
 //   APIDocumentURL -- the url to get openAPI(swagger) document, default value is /docs/swagger.json
+//   ReferenceMarkdownURL -- the url to get the Markdown API reference, default value is /docs/reference.md
+//   ReferenceHTMLURL -- the url to get the HTML API reference, default value is /docs/reference.html
+//   TypeScriptURL -- the url to get the TypeScript models and client (see Engine.GenerateTypeScript), default value is /docs/client.ts
+//   DocCommentsFromSource -- use the Go doc comments of the models as descriptions if the tag desc is absent,
+//                            the comments are parsed from the Go source of the packages at runtime (see DocComments)
+//   TermsOfService -- the terms of service for the API
+//   Contact -- the contact information for the API
+//   License -- the license information for the API
+//   Tags -- the tags used by the operations with additional metadata. The order of the tags in the document is the order of the declaration.
+//           If the tags are declared, the engine warns on the tags of the operations which are not declared.
+//   ExternalDocs -- additional external documentation of the API
+//   The invalid Contact, License, Tags and ExternalDocs are not in the document, the errors are returned by Config.Validate and Engine.Run
+//   InferTags -- infer the tag of the API without tags, the tag is the name of the RouterGroup or the first path segment (see DefaultTagInferrer)
+//   TagInferrer -- the customizable inference function, the tag inference is opened if it is set
+//   Lint -- lint the swagger document when the server runs, the problems are logged as warnings (see package lint)
+//   StrictLint -- lint the swagger document when the server runs, and panic if there are problems
+//   LintRules -- the enabled lint rules (see lint.RuleNames), all the rules are enabled if it is empty
+//   MockMode -- the registered APIs validate the parameters, but respond the responses synthesized from the documented responses
+//               instead of calling the handlers (see package mock), the status code is selected by the header Prefer (like: Prefer: code=404)
+//   MockSeed -- the seed of the random values of the mock responses, the same seed and the same request respond the same response
+//   ValidateResponses -- check the responses of the handlers with the documented Responses (the status code, the model of the JSON body and the headers),
+//                        the violations are logged as warnings, or reported to the ResponseViolationHook. It is for the debug and the test modes.
+//   StrictResponses -- check the responses like ValidateResponses, and replace the response with a violation by 500 and the violation (for the tests)
+//   ResponseViolationHook -- the function reporting the violations of the responses instead of the log (see ResponseViolation)
 type Config struct {
-	Schemes            []Scheme
-	BasePath           string
-	Version            string
-	Title              string
-	Description        string
-	AllowOrigin        bool
-	Origins            []string
-	OpenAPIDocumentURL bool
-	APIDocumentURL     string
-	YAMLAPIDocumentURL string
-	DomainName         string
+	Schemes               []Scheme
+	BasePath              string
+	Version               string
+	Title                 string
+	Description           string
+	AllowOrigin           bool
+	Origins               []string
+	OpenAPIDocumentURL    bool
+	APIDocumentURL        string
+	YAMLAPIDocumentURL    string
+	ReferenceMarkdownURL  string
+	ReferenceHTMLURL      string
+	TypeScriptURL         string
+	DomainName            string
+	DocCommentsFromSource bool
+	TermsOfService        string
+	Contact               *Contact
+	License               *License
+	Tags                  []Tag
+	ExternalDocs          *ExternalDocs
+	InferTags             bool
+	TagInferrer           TagInferrer
+	Lint                  bool
+	StrictLint            bool
+	LintRules             []string
+	MockMode              bool
+	MockSeed              int64
+	ValidateResponses     bool
+	StrictResponses       bool
+	ResponseViolationHook func(*ResponseViolation)
+}
+
+// Contact information for the exposed API.
+// Fields:
+//   Name -- the identifying name of the contact person/organization
+//   URL -- the URL pointing to the contact information, it must be an absolute URL
+//   Email -- the email address of the contact person/organization, it must be an email address
+type Contact struct {
+	Name  string
+	URL   string
+	Email string
+}
+
+// License information for the exposed API.
+// Fields:
+//   Name -- the license name used for the API. (required)
+//   URL -- the URL to the license used for the API
+type License struct {
+	Name string
+	URL  string
+}
+
+// Tag adds metadata to a tag that is used by the operations (APIDocCommon.Tags).
+// Fields:
+//   Name -- the name of the tag. (required)
+//   Description -- a short description for the tag
+//   ExternalDocs -- additional external documentation for the tag
+type Tag struct {
+	Name         string
+	Description  string
+	ExternalDocs *ExternalDocs
+}
+
+// Validate check the metadata of the document: the Contact, the License, the Tags and the ExternalDocs.
+// The invalid values are not in the document of the engine.
+func (conf *Config) Validate() error {
+	return errors.Join(conf.validate()...)
+}
+
+func (conf *Config) validate() []error {
+	errs := []error{}
+	if _, err := conf.Contact.toSwaggerContact(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := conf.License.toSwaggerLicense(); err != nil {
+		errs = append(errs, err)
+	}
+	for _, tag := range conf.Tags {
+		if _, err := tag.toSwaggerTag(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if _, err := conf.ExternalDocs.toSwaggerExternalDocs(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (contact *Contact) toSwaggerContact() (*swagger.Contact, error) {
+	if contact == nil {
+		return nil, nil
+	}
+	if contact.URL != "" {
+		if u, err := url.Parse(contact.URL); err != nil || !u.IsAbs() {
+			return nil, errors.New("Contact.URL " + contact.URL + " should be an absolute URL")
+		}
+	}
+	if contact.Email != "" {
+		if _, err := mail.ParseAddress(contact.Email); err != nil {
+			return nil, errors.New("Contact.Email " + contact.Email + " should be an email address")
+		}
+	}
+	return &swagger.Contact{Name: contact.Name, URL: contact.URL, EMail: contact.Email}, nil
+}
+
+func (license *License) toSwaggerLicense() (*swagger.License, error) {
+	if license == nil {
+		return nil, nil
+	}
+	if license.Name == "" {
+		return nil, errors.New("License.Name should not be empty")
+	}
+	return &swagger.License{Name: license.Name, URL: license.URL}, nil
+}
+
+func (tag Tag) toSwaggerTag() (*swagger.Tag, error) {
+	if tag.Name == "" {
+		return nil, errors.New("Tag.Name should not be empty")
+	}
+	externalDocs, err := tag.ExternalDocs.toSwaggerExternalDocs()
+	if err != nil {
+		return nil, errors.New("the tag " + tag.Name + " " + err.Error())
+	}
+	return &swagger.Tag{Name: tag.Name, Description: tag.Description, ExternalDocs: externalDocs}, nil
 }
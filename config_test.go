@@ -0,0 +1,89 @@
+package ehttp
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestConfigDocumentMetadata(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	conf := &Config{
+		Title:          "book store APIS",
+		Version:        "v1",
+		TermsOfService: "https://example.com/terms",
+		Contact:        &Contact{Name: "API Support", Email: "support@example.com"},
+		License:        &License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"},
+		Tags: []Tag{
+			Tag{Name: "books", Description: "the books"},
+			Tag{Name: "authors", Description: "the authors", ExternalDocs: &ExternalDocs{URL: "https://example.com/authors"}},
+			Tag{Name: "books", Description: "declared again"},
+			Tag{Description: "no name"},
+		},
+		ExternalDocs: &ExternalDocs{Description: "more", URL: "https://example.com/docs"},
+	}
+	router := NewEngine(conf)
+	info := router.Swagger.Info
+	if info.TermsOfService != conf.TermsOfService || info.Contact == nil || info.Contact.EMail != "support@example.com" {
+		testError(t, "the termsOfService and the contact should be set", info)
+	}
+	if info.License == nil || info.License.Name != "MIT" {
+		testError(t, "the license should be set", info.License)
+	}
+	if router.Swagger.ExternalDocs == nil || router.Swagger.ExternalDocs.URL != "https://example.com/docs" {
+		testError(t, "the externalDocs should be set", router.Swagger.ExternalDocs)
+	}
+	tags := router.Swagger.Tags
+	if len(tags) != 2 || tags[0].Name != "books" || tags[0].Description != "the books" || tags[1].Name != "authors" {
+		testError(t, "the tags should be [books authors] by the order of the declaration", tags)
+	} else if tags[1].ExternalDocs == nil {
+		testError(t, "the externalDocs of the tag authors should be set")
+	}
+
+	if err := router.GET("/books", &APIDocCommon{Tags: []string{"books", "unknown"}}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if !router.unknownTags["unknown"] || router.unknownTags["books"] {
+		testError(t, "the tag unknown should be warned", router.unknownTags)
+	}
+
+	router = NewEngine(&Config{License: &License{URL: "https://opensource.org/licenses/MIT"}})
+	if router.Swagger.Info.License != nil {
+		testError(t, "the license without a name should be ignored")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	conf := &Config{
+		Contact:      &Contact{Name: "API Support", URL: "https://example.com/support", Email: "support@example.com"},
+		License:      &License{Name: "MIT"},
+		Tags:         []Tag{Tag{Name: "books"}},
+		ExternalDocs: &ExternalDocs{URL: "https://example.com/docs"},
+	}
+	if err := conf.Validate(); err != nil {
+		testError(t, err)
+	}
+	invalids := []*Config{
+		&Config{Contact: &Contact{URL: "example.com/support"}},
+		&Config{Contact: &Contact{Email: "support"}},
+		&Config{License: &License{URL: "https://opensource.org/licenses/MIT"}},
+		&Config{Tags: []Tag{Tag{Name: "books"}, Tag{Description: "no name"}}},
+		&Config{Tags: []Tag{Tag{Name: "books", ExternalDocs: &ExternalDocs{}}}},
+		&Config{ExternalDocs: &ExternalDocs{Description: "no url"}},
+	}
+	for _, conf := range invalids {
+		if err := conf.Validate(); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, "the config should be invalid", conf)
+		}
+		if err := NewEngine(conf).Run(":0"); err == nil {
+			testError(t, "the error of the config should be returned by Run")
+		}
+	}
+	router := NewEngine(&Config{Contact: &Contact{Email: "support"}, License: &License{}})
+	if router.Swagger.Info.Contact != nil || router.Swagger.Info.License != nil {
+		testError(t, "the invalid contact and license should not be in the document", router.Swagger.Info)
+	}
+}
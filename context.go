@@ -0,0 +1,48 @@
+package ehttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context the accessor of the request of an HTTP framework (like: gin, net/http, chi, echo),
+// the rules of the parameters, the request body and CORS check the requests by it (see Route.Check).
+// Methods:
+//   Request -- the HTTP request
+//   Param -- the value of the parameter in path (like: the id of /books/{id})
+//   ResponseHeader -- the header of the response, which is written by the rules (like: the CORS headers)
+type Context interface {
+	Request() *http.Request
+	Param(name string) string
+	ResponseHeader() http.Header
+}
+
+// ginContext the Context of gin
+type ginContext struct {
+	c *gin.Context
+}
+
+func (g ginContext) Request() *http.Request {
+	return g.c.Request
+}
+
+func (g ginContext) Param(name string) string {
+	return g.c.Param(name)
+}
+
+func (g ginContext) ResponseHeader() http.Header {
+	return g.c.Writer.Header()
+}
+
+// defaultMultipartMemory the max memory of parsing the multipart form, it is the default of gin
+const defaultMultipartMemory = 32 << 20
+
+// getPostForm get the value of the formData parameter, the urlencoded and the multipart forms are supported
+func getPostForm(r *http.Request, name string) string {
+	r.ParseMultipartForm(defaultMultipartMemory)
+	if values := r.PostForm[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
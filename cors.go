@@ -2,8 +2,6 @@ package ehttp
 
 import (
 	"errors"
-
-	"github.com/gin-gonic/gin"
 )
 
 type corsInfos struct {
@@ -111,25 +109,26 @@ type accessControlAllow struct {
 // (CORS) is a mechanism that uses additional HTTP headers to tell a browser
 // to let a web application running at one origin (domain) have permission to access selected resources
 // from a server at a different origin.
-func (a *accessControlAllow) cors(c *gin.Context) error {
+func (a *accessControlAllow) cors(c Context) error {
+	header := c.ResponseHeader()
 	if _, ok := a.Origins["*"]; ok {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		header.Set("Access-Control-Allow-Origin", "*")
 	} else {
-		origin := c.Request.Header.Get("Origin")
+		origin := c.Request().Header.Get("Origin")
 		if origin != "" {
 			_, ok := a.Origins[origin]
 			if ok {
-				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+				header.Set("Access-Control-Allow-Origin", origin)
 			} else {
 				return errors.New("Origin " + origin + " is not allow")
 			}
 		}
 	}
 
-	c.Writer.Header().Set("Access-Control-Allow-Methods", a.Methods)
-	c.Writer.Header().Set("Access-Control-Allow-Headers", a.Headers)
+	header.Set("Access-Control-Allow-Methods", a.Methods)
+	header.Set("Access-Control-Allow-Headers", a.Headers)
 	if a.Credentials {
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		header.Set("Access-Control-Allow-Credentials", "true")
 	}
 	return nil
 }
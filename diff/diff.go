@@ -0,0 +1,295 @@
+// Package diff compare two swagger documents, and classify the changes as breaking or non-breaking.
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Change a change between the old and the new documents
+// Fields:
+//   Breaking -- the clients of the old document may be broken by the change
+//   Operation -- like "GET /books/{id}", empty if the change is not in an operation
+//   Message -- like "the required query parameter fields is added"
+type Change struct {
+	Breaking  bool
+	Operation string
+	Message   string
+}
+
+func (c *Change) String() string {
+	level := "non-breaking"
+	if c.Breaking {
+		level = "breaking"
+	}
+	if c.Operation == "" {
+		return level + ": " + c.Message
+	}
+	return level + ": " + c.Operation + ": " + c.Message
+}
+
+// Compare compare the old and the new swagger documents.
+// The changes are sorted, the breaking changes are first.
+// The rules of the request (parameters and body) and the response are opposite:
+//   request -- removed operations, new required parameters or properties, narrowed enums or ranges are breaking
+//   response -- removed properties or status codes, widened enums or ranges are breaking
+//   both -- changed types or formats are breaking
+func Compare(old, new *swagger.Swagger) []*Change {
+	c := &comparer{old: old, new: new, visiting: map[string]bool{}}
+	if old.BasePath != new.BasePath {
+		c.add(true, "the basePath is changed from %q to %q", old.BasePath, new.BasePath)
+	}
+	oldOperations := getOperations(old)
+	newOperations := getOperations(new)
+	for _, key := range getSortedKeys(oldOperations) {
+		oldOperation := oldOperations[key]
+		newOperation, ok := newOperations[key]
+		if !ok {
+			c.add(true, "the operation %s %s is removed", oldOperation.method, oldOperation.path)
+			continue
+		}
+		c.operation = newOperation.method + " " + newOperation.path
+		c.compareOperation(oldOperation, newOperation)
+		c.operation = ""
+	}
+	for _, key := range getSortedKeys(newOperations) {
+		if _, ok := oldOperations[key]; !ok {
+			newOperation := newOperations[key]
+			c.add(false, "the operation %s %s is added", newOperation.method, newOperation.path)
+		}
+	}
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Breaking && !c.changes[j].Breaking
+	})
+	return c.changes
+}
+
+// BreakingChanges the breaking changes of the changes
+func BreakingChanges(changes []*Change) []*Change {
+	breakingChanges := []*Change{}
+	for _, change := range changes {
+		if change.Breaking {
+			breakingChanges = append(breakingChanges, change)
+		}
+	}
+	return breakingChanges
+}
+
+type comparer struct {
+	old, new  *swagger.Swagger
+	operation string
+	changes   []*Change
+	visiting  map[string]bool
+}
+
+func (c *comparer) add(breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, &Change{
+		Breaking:  breaking,
+		Operation: c.operation,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+type operationInfo struct {
+	method    string
+	path      string
+	operation *swagger.Operation
+}
+
+var pathParameterRegexp = regexp.MustCompile(`\{[^}]*\}`)
+
+// getOperations the operations of the document, the key is like "GET /books/{}",
+// so the operations are same if only the names of the path parameters are changed.
+func getOperations(doc *swagger.Swagger) map[string]*operationInfo {
+	operations := map[string]*operationInfo{}
+	for path, item := range doc.Paths {
+		if item == nil {
+			continue
+		}
+		methods := map[string]*swagger.Operation{
+			"GET":     item.Get,
+			"PUT":     item.Put,
+			"POST":    item.Post,
+			"DELETE":  item.Delete,
+			"OPTIONS": item.Options,
+			"HEAD":    item.Head,
+			"PATCH":   item.Patch,
+		}
+		for method, operation := range methods {
+			if operation != nil {
+				key := method + " " + pathParameterRegexp.ReplaceAllString(path, "{}")
+				operations[key] = &operationInfo{method: method, path: path, operation: operation}
+			}
+		}
+	}
+	return operations
+}
+
+func (c *comparer) compareOperation(oldOperation, newOperation *operationInfo) {
+	old, new := oldOperation.operation, newOperation.operation
+	if !old.Deprecated && new.Deprecated {
+		c.add(false, "the operation is deprecated")
+	}
+	c.compareMimeTypes("consumes", getMimeTypes(old.Consumes, c.old.Consumes), getMimeTypes(new.Consumes, c.new.Consumes))
+	c.compareMimeTypes("produces", getMimeTypes(old.Produces, c.old.Produces), getMimeTypes(new.Produces, c.new.Produces))
+	c.compareParameters(c.getParameters(c.old, oldOperation.path, old.Parameters), c.getParameters(c.new, newOperation.path, new.Parameters))
+	c.compareResponses(old.Responses, new.Responses)
+}
+
+func getMimeTypes(operationMimeTypes, documentMimeTypes []string) []string {
+	if len(operationMimeTypes) > 0 {
+		return operationMimeTypes
+	}
+	return documentMimeTypes
+}
+
+// compareMimeTypes the removed MIME types are breaking
+func (c *comparer) compareMimeTypes(name string, old, new []string) {
+	removed, added := diffValues(toInterfaces(old), toInterfaces(new))
+	if len(removed) > 0 {
+		c.add(true, "%s %s are removed", name, strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		c.add(false, "%s %s are added", name, strings.Join(added, ", "))
+	}
+}
+
+func (c *comparer) compareParameters(oldParameters, newParameters map[string]*swagger.Parameter) {
+	for _, key := range getSortedKeys(oldParameters) {
+		oldParameter := oldParameters[key]
+		newParameter, ok := newParameters[key]
+		if !ok {
+			c.add(false, "the %s is removed", getParameterLocation(oldParameter))
+			continue
+		}
+		location := getParameterLocation(newParameter)
+		if !oldParameter.Required && newParameter.Required {
+			c.add(true, "the %s becomes required", location)
+		} else if oldParameter.Required && !newParameter.Required {
+			c.add(false, "the %s becomes optional", location)
+		}
+		if oldParameter.In == "body" {
+			c.compareSchema(location, fromSchema(oldParameter.Schema), fromSchema(newParameter.Schema), request)
+		} else {
+			c.compareSchema(location, fromParameter(oldParameter), fromParameter(newParameter), request)
+		}
+	}
+	for _, key := range getSortedKeys(newParameters) {
+		if _, ok := oldParameters[key]; !ok {
+			newParameter := newParameters[key]
+			if newParameter.Required {
+				c.add(true, "the required %s is added", getParameterLocation(newParameter))
+			} else {
+				c.add(false, "the optional %s is added", getParameterLocation(newParameter))
+			}
+		}
+	}
+}
+
+// getParameters the parameters of the operation, the key is like "query.fields".
+// The key of a path parameter is its position in the path, so the renamed path parameters are same.
+func (c *comparer) getParameters(doc *swagger.Swagger, path string, parameters []*swagger.Parameter) map[string]*swagger.Parameter {
+	pathParameters := pathParameterRegexp.FindAllString(path, -1)
+	result := map[string]*swagger.Parameter{}
+	for _, parameter := range parameters {
+		if parameter == nil {
+			continue
+		}
+		if strings.HasPrefix(parameter.Ref, swaggerParameters) {
+			parameter = doc.Parameters[strings.TrimPrefix(parameter.Ref, swaggerParameters)]
+			if parameter == nil {
+				continue
+			}
+		}
+		if parameter.In == "body" {
+			result["body"] = parameter
+		} else if index := indexOf(pathParameters, "{"+parameter.Name+"}"); parameter.In == "path" && index >= 0 {
+			result[fmt.Sprintf("path.%d", index)] = parameter
+		} else {
+			result[parameter.In+"."+parameter.Name] = parameter
+		}
+	}
+	return result
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func getParameterLocation(parameter *swagger.Parameter) string {
+	if parameter.In == "body" {
+		return "request body"
+	}
+	return parameter.In + " parameter " + parameter.Name
+}
+
+func (c *comparer) compareResponses(old, new map[string]*swagger.Response) {
+	for _, code := range getSortedKeys(old) {
+		location := "response " + code
+		oldResponse := old[code]
+		newResponse, ok := new[code]
+		if !ok {
+			c.add(true, "the %s is removed", location)
+			continue
+		}
+		if oldResponse == nil || newResponse == nil {
+			continue
+		}
+		if oldResponse.Schema != nil && newResponse.Schema == nil {
+			c.add(true, "the body of the %s is removed", location)
+		} else {
+			c.compareSchema(location+" body", fromSchema(oldResponse.Schema), fromSchema(newResponse.Schema), response)
+		}
+		for _, name := range getSortedKeys(oldResponse.Headers) {
+			newHeader, ok := newResponse.Headers[name]
+			if !ok {
+				c.add(true, "the header %s of the %s is removed", name, location)
+				continue
+			}
+			c.compareSchema("header "+name+" of the "+location, fromHeader(oldResponse.Headers[name]), fromHeader(newHeader), response)
+		}
+	}
+	for _, code := range getSortedKeys(new) {
+		if _, ok := old[code]; !ok {
+			c.add(false, "the response %s is added", code)
+		}
+	}
+}
+
+// getSortedKeys the sorted keys of a map[string]T
+func getSortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string]*operationInfo:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Parameter:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Response:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Header:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*schema:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
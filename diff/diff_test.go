@@ -0,0 +1,149 @@
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testOldDocument = `{
+  "swagger": "2.0",
+  "basePath": "/v1",
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all", "brief"]},
+          {"name": "limit", "in": "query", "type": "integer", "minimum": 1, "maximum": 100}
+        ],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}},
+          "404": {"description": "not found"}
+        }
+      },
+      "put": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "book", "in": "body", "schema": {"$ref": "#/definitions/Book"}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/authors": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "readOnly": true},
+        "title": {"type": "string", "required": true},
+        "price": {"type": "number"},
+        "status": {"type": "string", "enum": ["on", "off"]},
+        "related": {"type": "array", "items": {"$ref": "#/definitions/Book"}}
+      }
+    }
+  }
+}`
+
+const testNewDocument = `{
+  "swagger": "2.0",
+  "basePath": "/v1",
+  "paths": {
+    "/books/{bookId}": {
+      "get": {
+        "parameters": [
+          {"name": "bookId", "in": "path", "required": true, "type": "string"},
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all"]},
+          {"name": "limit", "in": "query", "type": "integer", "minimum": 1, "maximum": 1000},
+          {"name": "lang", "in": "query", "required": true, "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}}
+        }
+      },
+      "put": {
+        "parameters": [
+          {"name": "bookId", "in": "path", "required": true, "type": "string"},
+          {"name": "book", "in": "body", "schema": {"$ref": "#/definitions/Book"}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/stores": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "integer", "readOnly": true},
+        "title": {"type": "string", "required": true},
+        "status": {"type": "string", "enum": ["on", "off", "deleted"]},
+        "isbn": {"type": "string", "required": true},
+        "related": {"type": "array", "items": {"$ref": "#/definitions/Book"}}
+      }
+    }
+  }
+}`
+
+func testParseDocument(t *testing.T, document string) *swagger.Swagger {
+	doc := &swagger.Swagger{}
+	if err := json.Unmarshal([]byte(document), doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestCompare(t *testing.T) {
+	old := testParseDocument(t, testOldDocument)
+	new := testParseDocument(t, testNewDocument)
+	changes := Compare(old, new)
+	got := map[string]bool{}
+	for _, change := range changes {
+		got[change.String()] = true
+		t.Log(change)
+	}
+	expected := []string{
+		"breaking: the operation GET /authors is removed",
+		"non-breaking: the operation GET /stores is added",
+		"breaking: GET /books/{bookId}: the enum values [brief] of the query parameter fields are removed",
+		"non-breaking: GET /books/{bookId}: the maximum of the query parameter limit is widened from 100 to 1000",
+		"breaking: GET /books/{bookId}: the required query parameter lang is added",
+		"breaking: GET /books/{bookId}: the response 404 is removed",
+		"breaking: GET /books/{bookId}: the response 200 body.price is removed",
+		"breaking: GET /books/{bookId}: the enum values [deleted] of the response 200 body.status are added",
+		"breaking: GET /books/{bookId}: the type of the response 200 body.id is changed from string to integer",
+		"non-breaking: GET /books/{bookId}: the required response 200 body.isbn is added",
+		"non-breaking: PUT /books/{bookId}: the request body.price is removed",
+		"non-breaking: PUT /books/{bookId}: the enum values [deleted] of the request body.status are added",
+		"breaking: PUT /books/{bookId}: the required request body.isbn is added",
+	}
+	for _, change := range expected {
+		if !got[change] {
+			t.Error("missing change: " + change)
+		}
+	}
+	for change := range got {
+		if strings.Contains(change, "PUT") && strings.Contains(change, "body.id") {
+			t.Error("the readOnly properties should be ignored in the request: " + change)
+		}
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("expected %d changes, got %d", len(expected), len(changes))
+	}
+	breakingChanges := BreakingChanges(changes)
+	for i, change := range changes {
+		if i < len(breakingChanges) != change.Breaking {
+			t.Error("the breaking changes should be first")
+		}
+	}
+	if len(Compare(old, old)) != 0 {
+		t.Error("the same documents should have no changes")
+	}
+}
@@ -0,0 +1,367 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const (
+	swaggerDefinitions = "#/definitions/"
+	swaggerParameters  = "#/parameters/"
+)
+
+// direction the direction of the data, the rules of the request and the response are opposite
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+// schema the common part of swagger.Schema, swagger.Propertie, swagger.Parameter and swagger.Header
+type schema struct {
+	ref        string
+	typ        string
+	format     string
+	enum       []interface{}
+	minimum    *float64
+	maximum    *float64
+	minLength  *float64
+	maxLength  *float64
+	required   bool
+	nullable   bool
+	readOnly   bool
+	writeOnly  bool
+	properties map[string]*schema
+	items      *schema
+	allOf      []*schema
+}
+
+func fromSchema(s *swagger.Schema) *schema {
+	if s == nil {
+		return nil
+	}
+	result := &schema{
+		ref:        s.Ref,
+		typ:        s.Type,
+		format:     s.Format,
+		enum:       s.Enum,
+		items:      fromSchema(s.Items),
+		properties: map[string]*schema{},
+	}
+	for name, propertie := range s.Properties {
+		result.properties[name] = fromPropertie(propertie)
+	}
+	for _, item := range s.AllOf {
+		result.allOf = append(result.allOf, fromSchema(item))
+	}
+	return result
+}
+
+func fromPropertie(p *swagger.Propertie) *schema {
+	if p == nil {
+		return nil
+	}
+	result := &schema{
+		ref:        p.Ref,
+		typ:        p.Type,
+		format:     p.Format,
+		enum:       p.Enum,
+		minimum:    p.Minimum,
+		maximum:    p.Maximum,
+		minLength:  int64ToFloat64(p.MinLength),
+		maxLength:  int64ToFloat64(p.MaxLength),
+		required:   p.Required,
+		nullable:   p.Nullable,
+		readOnly:   p.ReadOnly,
+		writeOnly:  p.WriteOnly,
+		items:      fromPropertie(p.Items),
+		properties: map[string]*schema{},
+	}
+	for name, propertie := range p.Properties {
+		result.properties[name] = fromPropertie(propertie)
+	}
+	for _, item := range p.AllOf {
+		result.allOf = append(result.allOf, fromPropertie(item))
+	}
+	return result
+}
+
+func fromParameter(p *swagger.Parameter) *schema {
+	result := &schema{
+		typ:       p.Type,
+		format:    p.Format,
+		enum:      p.Enum,
+		minimum:   p.Minimum,
+		maximum:   p.Maximum,
+		minLength: int64ToFloat64(p.MinLength),
+		maxLength: int64ToFloat64(p.MaxLength),
+	}
+	if p.Items != nil {
+		result.items = &schema{typ: p.Items.Type, format: p.Items.Format}
+	}
+	return result
+}
+
+func fromHeader(h *swagger.Header) *schema {
+	if h == nil {
+		return nil
+	}
+	return &schema{
+		typ:       h.Type,
+		format:    h.Format,
+		enum:      h.Enum,
+		minimum:   h.Minimum,
+		maximum:   h.Maximum,
+		minLength: int64ToFloat64(h.MinLength),
+		maxLength: int64ToFloat64(h.MaxLength),
+	}
+}
+
+func int64ToFloat64(i *int64) *float64 {
+	if i == nil {
+		return nil
+	}
+	f := float64(*i)
+	return &f
+}
+
+// resolve resolve the $ref to the definition, and merge the properties of allOf
+func resolve(doc *swagger.Swagger, s *schema) *schema {
+	for i := 0; s != nil && s.ref != "" && i < 32; i++ {
+		definition := fromSchema(doc.Definitions[strings.TrimPrefix(s.ref, swaggerDefinitions)])
+		if definition == nil {
+			return s
+		}
+		// the property of a $ref is wrapped by allOf, the constraints are on the wrapper
+		definition.required, definition.nullable = s.required, s.nullable
+		definition.readOnly, definition.writeOnly = s.readOnly, s.writeOnly
+		s = definition
+	}
+	if s == nil || len(s.allOf) == 0 {
+		return s
+	}
+	merged := *s
+	merged.properties = map[string]*schema{}
+	for _, item := range s.allOf {
+		item = resolve(doc, item)
+		if item == nil {
+			continue
+		}
+		if merged.typ == "" {
+			merged.typ = item.typ
+		}
+		if merged.format == "" {
+			merged.format = item.format
+		}
+		if merged.items == nil {
+			merged.items = item.items
+		}
+		for name, propertie := range item.properties {
+			merged.properties[name] = propertie
+		}
+	}
+	for name, propertie := range s.properties {
+		merged.properties[name] = propertie
+	}
+	merged.allOf = nil
+	return &merged
+}
+
+func (c *comparer) compareSchema(location string, old, new *schema, dir direction) {
+	if old == nil || new == nil {
+		return
+	}
+	// the recursive models are compared once
+	if oldRef, newRef := getRef(old), getRef(new); oldRef != "" || newRef != "" {
+		key := fmt.Sprintf("%d %s %s", dir, oldRef, newRef)
+		if c.visiting[key] {
+			return
+		}
+		c.visiting[key] = true
+		defer delete(c.visiting, key)
+	}
+	old, new = resolve(c.old, old), resolve(c.new, new)
+	if old.typ != new.typ {
+		c.add(true, "the type of the %s is changed from %s to %s", location, getTypeName(old.typ), getTypeName(new.typ))
+		return
+	}
+	if old.format != new.format {
+		c.add(true, "the format of the %s is changed from %s to %s", location, getTypeName(old.format), getTypeName(new.format))
+	}
+	if dir == request && old.nullable && !new.nullable {
+		c.add(true, "the %s is not nullable", location)
+	}
+	if dir == response && !old.nullable && new.nullable {
+		c.add(true, "the %s becomes nullable", location)
+	}
+	c.compareEnum(location, old.enum, new.enum, dir)
+	c.compareRange("minimum", location, old.minimum, new.minimum, true, dir)
+	c.compareRange("maximum", location, old.maximum, new.maximum, false, dir)
+	c.compareRange("minLength", location, old.minLength, new.minLength, true, dir)
+	c.compareRange("maxLength", location, old.maxLength, new.maxLength, false, dir)
+	c.compareProperties(location, old.properties, new.properties, dir)
+	c.compareSchema(location+"[]", old.items, new.items, dir)
+}
+
+// getRef the $ref of the schema, or the $refs of allOf
+func getRef(s *schema) string {
+	if s.ref != "" || len(s.allOf) == 0 {
+		return s.ref
+	}
+	refs := []string{}
+	for _, item := range s.allOf {
+		if item != nil {
+			refs = append(refs, item.ref)
+		}
+	}
+	return strings.Join(refs, ",")
+}
+
+func getTypeName(typ string) string {
+	if typ == "" {
+		return "none"
+	}
+	return typ
+}
+
+// compareProperties
+//   request -- the new required properties are breaking, the removed properties are not
+//   response -- the removed properties are breaking, the new properties are not
+// The readOnly properties are not in the request, and the writeOnly properties are not in the response.
+func (c *comparer) compareProperties(location string, old, new map[string]*schema, dir direction) {
+	isIgnored := func(s *schema) bool {
+		return (dir == request && s.readOnly) || (dir == response && s.writeOnly)
+	}
+	for _, name := range getSortedKeys(old) {
+		oldPropertie := old[name]
+		if oldPropertie == nil || isIgnored(oldPropertie) {
+			continue
+		}
+		propertieLocation := location + "." + name
+		newPropertie, ok := new[name]
+		if !ok || newPropertie == nil || isIgnored(newPropertie) {
+			c.add(dir == response, "the %s is removed", propertieLocation)
+			continue
+		}
+		if !oldPropertie.required && newPropertie.required {
+			c.add(dir == request, "the %s becomes required", propertieLocation)
+		} else if oldPropertie.required && !newPropertie.required {
+			c.add(dir == response, "the %s becomes optional", propertieLocation)
+		}
+		c.compareSchema(propertieLocation, oldPropertie, newPropertie, dir)
+	}
+	for _, name := range getSortedKeys(new) {
+		newPropertie := new[name]
+		if newPropertie == nil || isIgnored(newPropertie) {
+			continue
+		}
+		if oldPropertie, ok := old[name]; ok && oldPropertie != nil && !isIgnored(oldPropertie) {
+			continue
+		}
+		if newPropertie.required {
+			c.add(dir == request, "the required %s.%s is added", location, name)
+		} else {
+			c.add(false, "the optional %s.%s is added", location, name)
+		}
+	}
+}
+
+// compareEnum
+//   request -- the removed values are breaking (narrowed)
+//   response -- the added values are breaking (widened)
+func (c *comparer) compareEnum(location string, old, new []interface{}, dir direction) {
+	if len(old) == 0 && len(new) == 0 {
+		return
+	}
+	if len(old) == 0 {
+		c.add(dir == request, "the enum [%s] of the %s is added", strings.Join(toStrings(new), ", "), location)
+		return
+	}
+	if len(new) == 0 {
+		c.add(dir == response, "the enum of the %s is removed", location)
+		return
+	}
+	removed, added := diffValues(old, new)
+	if len(removed) > 0 {
+		c.add(dir == request, "the enum values [%s] of the %s are removed", strings.Join(removed, ", "), location)
+	}
+	if len(added) > 0 {
+		c.add(dir == response, "the enum values [%s] of the %s are added", strings.Join(added, ", "), location)
+	}
+}
+
+// compareRange
+//   request -- the narrowed ranges are breaking
+//   response -- the widened ranges are breaking
+func (c *comparer) compareRange(name, location string, old, new *float64, isMin bool, dir direction) {
+	if old == nil && new == nil {
+		return
+	}
+	if old != nil && new != nil && *old == *new {
+		return
+	}
+	var narrowed bool
+	switch {
+	case old == nil:
+		narrowed = true
+	case new == nil:
+		narrowed = false
+	case isMin:
+		narrowed = *new > *old
+	default:
+		narrowed = *new < *old
+	}
+	if narrowed {
+		c.add(dir == request, "the %s of the %s is narrowed from %s to %s", name, location, formatLimit(old), formatLimit(new))
+	} else {
+		c.add(dir == response, "the %s of the %s is widened from %s to %s", name, location, formatLimit(old), formatLimit(new))
+	}
+}
+
+func formatLimit(f *float64) string {
+	if f == nil {
+		return "none"
+	}
+	return fmt.Sprint(*f)
+}
+
+// diffValues the removed values and the added values, the values are compared by fmt.Sprint,
+// so 1 (int) and 1 (float64 from JSON) are same.
+func diffValues(old, new []interface{}) (removed []string, added []string) {
+	oldValues := map[string]bool{}
+	for _, value := range toStrings(old) {
+		oldValues[value] = true
+	}
+	newValues := map[string]bool{}
+	for _, value := range toStrings(new) {
+		newValues[value] = true
+		if !oldValues[value] {
+			added = append(added, value)
+		}
+	}
+	for _, value := range toStrings(old) {
+		if !newValues[value] {
+			removed = append(removed, value)
+		}
+	}
+	return removed, added
+}
+
+func toStrings(values []interface{}) []string {
+	result := []string{}
+	for _, value := range values {
+		result = append(result, fmt.Sprint(value))
+	}
+	return result
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := []interface{}{}
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package ehttp
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DocComments the Go doc comments of types and struct fields, used as descriptions when the tag desc is absent.
+// The key of a type is GoPkgPath + "." + TypeName (like: github.com/x/model.Book),
+// the key of a struct field is GoPkgPath + "." + TypeName + "." + FieldName (like: github.com/x/model.Book.Title).
+type DocComments map[string]string
+
+// RegisterDocComments register the doc comments, it is usually called by the file generated by the command `ehttp comments`
+//
+// example:
+//    //go:generate go run github.com/enjoy-web/ehttp/cmd/ehttp comments -o ehttp_comments.go
+func RegisterDocComments(comments DocComments) {
+	docComments.register(comments)
+}
+
+// LoadDocCommentsFromSource parse the Go source files of the package, and return the doc comments of the types and struct fields in it.
+// The pkgPath "main" is the package in the current working directory.
+func LoadDocCommentsFromSource(pkgPath string) (DocComments, error) {
+	var pkg *build.Package
+	var err error
+	if pkgPath == "main" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		pkg, err = build.ImportDir(dir, 0)
+	} else {
+		pkg, err = build.Import(pkgPath, "", 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, name := range pkg.GoFiles {
+		files = append(files, filepath.Join(pkg.Dir, name))
+	}
+	return ParseDocComments(pkgPath, files...)
+}
+
+// ParseDocComments parse the Go source files of the package (pkgPath), and return the doc comments of the types and struct fields in them.
+func ParseDocComments(pkgPath string, files ...string) (DocComments, error) {
+	comments := DocComments{}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				typeKey := pkgPath + "." + typeSpec.Name.Name
+				comment := getCommentText(typeSpec.Doc)
+				if comment == "" && len(genDecl.Specs) == 1 {
+					comment = getCommentText(genDecl.Doc)
+				}
+				if comment != "" {
+					comments[typeKey] = comment
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					comment := getCommentText(field.Doc)
+					if comment == "" {
+						comment = getCommentText(field.Comment)
+					}
+					if comment == "" {
+						continue
+					}
+					for _, name := range field.Names {
+						comments[typeKey+"."+name.Name] = comment
+					}
+				}
+			}
+		}
+	}
+	return comments, nil
+}
+
+// GenerateDocCommentsSource generate the Go source of a file which registers the doc comments in the package pkgName
+func GenerateDocCommentsSource(pkgName string, comments DocComments) ([]byte, error) {
+	if pkgName == "" {
+		return nil, errors.New("the package name should not be empty")
+	}
+	keys := []string{}
+	for key := range comments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	b := bytes.Buffer{}
+	b.WriteString("// Code generated by ehttp comments; DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkgName + "\n\n")
+	b.WriteString("import \"github.com/enjoy-web/ehttp\"\n\n")
+	b.WriteString("func init() {\n")
+	b.WriteString("\tehttp.RegisterDocComments(ehttp.DocComments{\n")
+	for _, key := range keys {
+		b.WriteString("\t\t" + strconv.Quote(key) + ": " + strconv.Quote(comments[key]) + ",\n")
+	}
+	b.WriteString("\t})\n")
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+// getCommentText get the text of the comment, the lines are joined by spaces
+func getCommentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
+
+// getDocCommentKey get the key of the type in DocComments.
+// The key of an instantiated generic type is the key of the generic type (like: github.com/x/model.Page[int] -> github.com/x/model.Page)
+func getDocCommentKey(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i > 0 {
+		name = name[:i]
+	}
+	return t.PkgPath() + "." + name
+}
+
+var docComments = &docCommentRegistry{}
+
+// docCommentRegistry the registered doc comments, and the doc comments loaded from the source.
+// The doc comments of a package are loaded from the source when they are needed from the source at the first time.
+type docCommentRegistry struct {
+	lock     sync.Mutex
+	comments DocComments
+	sources  DocComments
+	loaded   map[string]bool
+}
+
+func (r *docCommentRegistry) register(comments DocComments) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.comments == nil {
+		r.comments = DocComments{}
+	}
+	for key, comment := range comments {
+		r.comments[key] = comment
+	}
+}
+
+// get the doc comment by the key, the pkgPath is the package path of the type.
+// If fromSource is true and the comment is not registered, the comment is got from the source of the package.
+func (r *docCommentRegistry) get(pkgPath, key string, fromSource bool) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if comment, ok := r.comments[key]; ok || !fromSource {
+		return comment
+	}
+	if !r.loaded[pkgPath] {
+		if r.loaded == nil {
+			r.loaded = map[string]bool{}
+			r.sources = DocComments{}
+		}
+		r.loaded[pkgPath] = true
+		comments, err := LoadDocCommentsFromSource(pkgPath)
+		if err != nil {
+			logWarning("load doc comments of the package " + pkgPath + ", " + err.Error())
+		}
+		for key, comment := range comments {
+			r.sources[key] = comment
+		}
+	}
+	return r.sources[key]
+}
@@ -0,0 +1,100 @@
+package ehttp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/gin-gonic/gin"
+)
+
+// testCommentBook is a book for the doc comments test.
+type testCommentBook struct {
+	// ID of the book
+	ID string `json:"id"`
+	// Title is described by the tag desc
+	Title string `json:"title" desc:"the title"`
+	Note  string `json:"note,omitempty"` // a note of the book
+}
+
+func TestParseDocComments(t *testing.T) {
+	comments, err := ParseDocComments("github.com/enjoy-web/ehttp", "doc_comments_test.go")
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	expected := DocComments{
+		"github.com/enjoy-web/ehttp.testCommentBook":       "testCommentBook is a book for the doc comments test.",
+		"github.com/enjoy-web/ehttp.testCommentBook.ID":    "ID of the book",
+		"github.com/enjoy-web/ehttp.testCommentBook.Title": "Title is described by the tag desc",
+		"github.com/enjoy-web/ehttp.testCommentBook.Note":  "a note of the book",
+	}
+	for key, comment := range expected {
+		if comments[key] != comment {
+			testError(t, key+": "+comments[key]+" should be equal to "+comment)
+		}
+	}
+
+	source, err := GenerateDocCommentsSource("ehttp_test", comments)
+	if err != nil {
+		testError(t, err)
+	} else if !strings.Contains(string(source), `"github.com/enjoy-web/ehttp.testCommentBook.ID":`) || !strings.Contains(string(source), `"ID of the book",`) {
+		testError(t, "the generated source should register the comments", string(source))
+	}
+
+	if _, err := ParseDocComments("github.com/enjoy-web/ehttp", "not_exist.go"); err == nil {
+		testError(t, "err should not be nil")
+	}
+}
+
+func TestDocCommentsDescription(t *testing.T) {
+	RegisterDocComments(DocComments{
+		"github.com/enjoy-web/ehttp.testCommentBook":       "testCommentBook is a book for the doc comments test.",
+		"github.com/enjoy-web/ehttp.testCommentBook.ID":    "ID of the book",
+		"github.com/enjoy-web/ehttp.testCommentBook.Title": "Title is described by the tag desc",
+		"github.com/enjoy-web/ehttp.testCommentBook.Note":  "a note of the book",
+	})
+	defer func(annotation string) { OmitemptyAnnotation = annotation }(OmitemptyAnnotation)
+	OmitemptyAnnotation = OmitemptyAnnotationEN
+
+	creater := StructDocCreater{}
+	docs, err := creater.GetStructDocMap(&testCommentBook{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	schema := getDefinitionsFromStructDocMap(docs)["testCommentBook"]
+	if schema.Description != "testCommentBook is a book for the doc comments test." {
+		testError(t, "the description of testCommentBook should be the doc comment")
+	}
+	if schema.Properties["id"].Description != "ID of the book" {
+		testError(t, "the description of id should be the doc comment")
+	}
+	if schema.Properties["title"].Description != "the title" {
+		testError(t, "the description of title should be the tag desc")
+	}
+	if schema.Properties["note"].Description != "a note of the book "+OmitemptyAnnotationEN {
+		testError(t, "the description of note should be the doc comment with the omitempty annotation")
+	}
+}
+
+func TestEngineDocCommentsFromSource(t *testing.T) {
+	description := "Tag adds metadata to a tag that is used by the operations (APIDocCommon.Tags)."
+	newDefinitions := func(conf *Config) map[string]*swagger.Schema {
+		router := NewEngine(conf)
+		doc := &APIDocCommon{Responses: map[int]Response{200: Response{Model: &Tag{}}}}
+		if err := router.GET("/tags", doc, func(c *gin.Context, err error) {}); err != nil {
+			testError(t, err)
+		}
+		return router.Swagger.Definitions
+	}
+	definitions := newDefinitions(&Config{DocCommentsFromSource: true})
+	if tag := definitions["Tag"]; tag == nil || !strings.HasPrefix(tag.Description, description) {
+		testError(t, "the description of Tag should be the doc comment from the source", definitions["Tag"])
+	}
+	// the other engines are not changed
+	definitions = newDefinitions(&Config{})
+	if tag := definitions["Tag"]; tag == nil || tag.Description != "" {
+		testError(t, "the description of Tag should be empty without DocCommentsFromSource", definitions["Tag"])
+	}
+}
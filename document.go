@@ -0,0 +1,230 @@
+package ehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/enjoy-web/ehttp/export"
+	"github.com/enjoy-web/ehttp/openapi3"
+	"github.com/enjoy-web/ehttp/reference"
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/ghodss/yaml"
+)
+
+// The file names of the documents written by Engine.WriteDocuments
+const (
+	SwaggerJSONFileName  = "swagger.json"
+	SwaggerYAMLFileName  = "swagger.yaml"
+	OpenAPI3JSONFileName = "openapi.json"
+	OpenAPI3YAMLFileName = "openapi.yaml"
+)
+
+// GetOpenAPI3Document get the OpenAPI 3 document converted from the swagger document
+func (e *Engine) GetOpenAPI3Document() (*openapi3.OpenAPI, error) {
+	return openapi3.FromSwagger(e.getStaticSwagger())
+}
+
+// GetOpenAPI3JSONDocument get the OpenAPI 3 JSON document
+func (e *Engine) GetOpenAPI3JSONDocument() (string, error) {
+	doc, err := e.GetOpenAPI3Document()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetOpenAPI3YAMLDocument get the OpenAPI 3 YAML document
+func (e *Engine) GetOpenAPI3YAMLDocument() (string, error) {
+	doc, err := e.GetOpenAPI3Document()
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetPostmanCollectionJSON get the Postman Collection v2.1 of the document, the requests are grouped by the tags into folders.
+// The baseUrl variable of the collection is from the Config.DomainName.
+func (e *Engine) GetPostmanCollectionJSON() (string, error) {
+	data, err := json.MarshalIndent(export.ToPostmanCollection(e.getStaticSwagger()), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetHTTPFile get the .http file (JetBrains HTTP Client, VS Code REST Client) of the document
+func (e *Engine) GetHTTPFile() string {
+	return export.ToHTTPFile(e.getStaticSwagger())
+}
+
+// GetCurlScript get the shell script of the curl commands of the document
+func (e *Engine) GetCurlScript() string {
+	return export.ToCurlScript(e.getStaticSwagger())
+}
+
+// WriteDocuments write the swagger 2.0 and the OpenAPI 3 documents (JSON and YAML) to the dir, without starting the server.
+// The host of the documents is the Config.DomainName.
+// The keys of the documents are sorted, so the files are stable for diffs.
+func (e *Engine) WriteDocuments(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	doc := e.getStaticSwagger()
+	openAPI, err := openapi3.FromSwagger(doc)
+	if err != nil {
+		return err
+	}
+	documents := []struct {
+		fileName string
+		doc      interface{}
+		marshal  func(interface{}) ([]byte, error)
+	}{
+		{SwaggerJSONFileName, doc, marshalIndentJSON},
+		{SwaggerYAMLFileName, doc, yaml.Marshal},
+		{OpenAPI3JSONFileName, openAPI, marshalIndentJSON},
+		{OpenAPI3YAMLFileName, openAPI, yaml.Marshal},
+	}
+	for _, document := range documents {
+		data, err := document.marshal(document.doc)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, document.fileName), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getStaticSwagger a copy of the swagger document, the host is the Config.DomainName
+func (e *Engine) getStaticSwagger() *swagger.Swagger {
+	doc := *e.Swagger
+	doc.Host = e.Conf.DomainName
+	return &doc
+}
+
+func marshalIndentJSON(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// DocumentHandlers the handlers of the documents by the urls (without the BasePath, like: /docs/swagger.json):
+// the swagger JSON and YAML documents, the Markdown and the HTML API references, and the TypeScript models and client.
+// The host of the documents is the Config.DomainName or the host of the request.
+// They are served on gin if the Config.OpenAPIDocumentURL is true, and on the other routers by the adapters (see package httpadapter).
+func (e *Engine) DocumentHandlers() map[string]http.HandlerFunc {
+	renderer := e.renderer
+	if renderer == nil {
+		renderer = reference.NewRenderer()
+	}
+	return map[string]http.HandlerFunc{
+		e.getAPIDocumentURL(): e.swaggerHandler("application/json; charset=utf-8", func(v interface{}) ([]byte, error) {
+			return json.MarshalIndent(v, "", "    ")
+		}),
+		e.getYAMLAPIDocumentURL():   e.swaggerHandler("application/x-yaml; charset=utf-8", yaml.Marshal),
+		e.getReferenceMarkdownURL(): e.referenceHandler("text/markdown; charset=utf-8", renderer.Markdown),
+		e.getReferenceHTMLURL():     e.referenceHandler("text/html; charset=utf-8", renderer.HTML),
+		e.getTypeScriptURL(): func(w http.ResponseWriter, r *http.Request) {
+			source, err := e.GenerateTypeScript()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeDocument(w, "application/typescript; charset=utf-8", []byte(source))
+		},
+	}
+}
+
+// SetReferenceRenderer set the renderer of the API reference with the customized templates, default is reference.NewRenderer()
+func (e *Engine) SetReferenceRenderer(renderer *reference.Renderer) {
+	e.renderer = renderer
+}
+
+// swaggerHandler the handler of the swagger document marshaled by marshal, the headers of CORS are set if the Config.AllowOrigin is true
+func (e *Engine) swaggerHandler(contentType string, marshal func(interface{}) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if e.Conf.AllowOrigin {
+			setDocumentAccessControlAllow(w.Header())
+		}
+		data, err := marshal(e.getRequestSwagger(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeDocument(w, contentType, data)
+	}
+}
+
+// referenceHandler the handler of the API reference rendered by render
+func (e *Engine) referenceHandler(contentType string, render func(io.Writer, *swagger.Swagger) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b := bytes.Buffer{}
+		if err := render(&b, e.getRequestSwagger(r)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeDocument(w, contentType, b.Bytes())
+	}
+}
+
+func writeDocument(w http.ResponseWriter, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// setDocumentAccessControlAllow set the headers of CORS of the swagger documents
+func setDocumentAccessControlAllow(header http.Header) {
+	header.Set("Access-Control-Allow-Methods", "GET,OPTIONS")
+	header.Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin,Access-Control-Allow-Method,Content-Type")
+	header.Set("Access-Control-Allow-Origin", "*")
+}
+
+func (e *Engine) getTypeScriptURL() string {
+	docURL := e.Conf.TypeScriptURL
+	if docURL == "" {
+		docURL = DefalutTypeScriptUrl
+	}
+	return docURL
+}
+
+func (e *Engine) getReferenceMarkdownURL() string {
+	docURL := e.Conf.ReferenceMarkdownURL
+	if docURL == "" {
+		docURL = DefalutReferenceMarkdownUrl
+	}
+	return docURL
+}
+
+func (e *Engine) getReferenceHTMLURL() string {
+	docURL := e.Conf.ReferenceHTMLURL
+	if docURL == "" {
+		docURL = DefalutReferenceHTMLUrl
+	}
+	return docURL
+}
+
+// getRequestSwagger a copy of the swagger document, the host is the Config.DomainName or the host of the request
+func (e *Engine) getRequestSwagger(r *http.Request) *swagger.Swagger {
+	doc := e.getStaticSwagger()
+	if doc.Host == "" {
+		doc.Host = r.Host
+	}
+	return doc
+}
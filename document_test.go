@@ -0,0 +1,217 @@
+package ehttp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/openapi3"
+	"github.com/enjoy-web/ehttp/reference"
+	"github.com/gin-gonic/gin"
+)
+
+type testDocumentBook struct {
+	ID    string  `json:"id" readonly:"true" desc:"the id"`
+	Title string  `json:"title" req:"true" example:"Demo book"`
+	Note  *string `json:"note"`
+}
+
+func newTestDocumentEngine(t *testing.T) *Engine {
+	gin.SetMode(gin.ReleaseMode)
+	registerTestEvent(t)
+	router := NewEngine(&Config{
+		Schemes:    []Scheme{SchemeHTTPS},
+		BasePath:   "/v1",
+		Title:      "book store APIS",
+		Version:    "v1",
+		DomainName: "api.example.com",
+	})
+	docGET := &APIDocCommon{
+		Summary: "Get book info by id",
+		Parameters: map[string]Parameter{
+			"id":     Parameter{InPath: &ValueInfo{Type: "string"}},
+			"fields": Parameter{InQuery: &ValueInfo{Type: "string", Enum: "all brief", Example: "brief"}},
+		},
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation", Model: &testDocumentBook{}},
+		},
+	}
+	docPOST := &APIDocCommon{
+		Request: &Request{Model: &testDocumentBook{}, Example: `{"title":"Demo book"}`},
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation", Model: &testDocumentBook{}},
+		},
+	}
+	docUpload := &APIDocCommon{
+		Parameters: map[string]Parameter{
+			"file": Parameter{InFormData: &ValueInfo{Type: "file", Desc: "the cover"}},
+		},
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation"},
+		},
+	}
+	docEvents := &APIDocCommon{
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation", Model: &testEventList{}},
+		},
+	}
+	if err := router.GET("/books/:id", docGET, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if err := router.POST("/books", docPOST, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if err := router.POST("/covers", docUpload, func(c *gin.Context, err error) {}); err != nil {
+		testError(t, err)
+	}
+	if err := router.GET("/events", docEvents, func(c *gin.Context, err error) {}); err != nil {
+		testError(t, err)
+	}
+	return router
+}
+
+func TestOpenAPI3Document(t *testing.T) {
+	router := newTestDocumentEngine(t)
+	doc, err := router.GetOpenAPI3Document()
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if doc.OpenAPI != openapi3.Version || len(doc.Servers) != 1 || doc.Servers[0].URL != "https://api.example.com/v1" {
+		testError(t, "the version and the servers should be set", doc.OpenAPI, doc.Servers)
+	}
+
+	get := doc.Paths["/books/{id}"].Get
+	if len(get.Parameters) != 2 || get.Parameters[0].Name != "fields" || get.Parameters[0].Schema.Type != "string" || get.Parameters[0].Example != "brief" {
+		testError(t, "the parameters should have the schemas and the examples", get.Parameters)
+	}
+	if content := get.Responses["200"].Content["application/json"]; content == nil || content.Schema.Ref != "#/components/schemas/testDocumentBook" {
+		testError(t, "the response should refer to the components", get.Responses["200"])
+	}
+
+	post := doc.Paths["/books"].Post
+	if post.RequestBody == nil || post.RequestBody.Content["application/json"] == nil || post.RequestBody.Content["application/json"].Example == nil {
+		testError(t, "the body parameter should be the requestBody with the example", post.RequestBody)
+	}
+
+	upload := doc.Paths["/covers"].Post
+	if upload.RequestBody == nil || upload.RequestBody.Content["multipart/form-data"] == nil {
+		testError(t, "the formData parameters should be the multipart/form-data requestBody", upload.RequestBody)
+	} else if file := upload.RequestBody.Content["multipart/form-data"].Schema.Properties["file"]; file.Type != "string" || file.Format != "binary" {
+		testError(t, "the file should be a binary string", file)
+	}
+
+	book := doc.Components.Schemas["testDocumentBook"]
+	if len(book.Required) != 1 || book.Required[0] != "title" {
+		testError(t, "the required properties should be the list of the schema", book.Required)
+	}
+	if !book.Properties["id"].ReadOnly || !book.Properties["note"].Nullable || book.Properties["title"].Example != "Demo book" {
+		testError(t, "readOnly, nullable and example should be converted", book.Properties)
+	}
+	event := doc.Components.Schemas["testEvent"]
+	if len(event.OneOf) != 2 || event.Discriminator == nil || event.Discriminator.PropertyName != "type" ||
+		event.Discriminator.Mapping["created"] != "#/components/schemas/testEventCreated" {
+		testError(t, "the polymorphic schema should have oneOf and the discriminator", event)
+	}
+}
+
+func TestEngine_WriteDocuments(t *testing.T) {
+	router := newTestDocumentEngine(t)
+	dir, err := ioutil.TempDir("", "ehttp-documents")
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	if err := router.WriteDocuments(dir); err != nil {
+		testError(t, err)
+		return
+	}
+	contents := map[string]string{}
+	for _, name := range []string{SwaggerJSONFileName, SwaggerYAMLFileName, OpenAPI3JSONFileName, OpenAPI3YAMLFileName} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			testError(t, err)
+			continue
+		}
+		contents[name] = string(data)
+	}
+	if !strings.Contains(contents[SwaggerJSONFileName], `"host": "api.example.com"`) {
+		testError(t, "the host of the document should be the DomainName")
+	}
+	if !strings.HasPrefix(contents[OpenAPI3YAMLFileName], "components:") || !strings.Contains(contents[OpenAPI3YAMLFileName], "openapi: 3.0.3") {
+		testError(t, "the keys of the YAML document should be sorted")
+	}
+	// the documents are stable
+	if err := router.WriteDocuments(dir); err != nil {
+		testError(t, err)
+		return
+	}
+	for name, content := range contents {
+		data, _ := ioutil.ReadFile(filepath.Join(dir, name))
+		if string(data) != content {
+			testError(t, name+" should be stable")
+		}
+	}
+}
+
+func TestEngine_GetSwaggerJSONDocument_Stable(t *testing.T) {
+	first, err := newTestDocumentEngine(t).GetSwaggerJSONDocument()
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	for i := 0; i < 5; i++ {
+		doc, err := newTestDocumentEngine(t).GetSwaggerJSONDocument()
+		if err != nil {
+			testError(t, err)
+			return
+		}
+		if doc != first {
+			testError(t, "the documents of the same APIs should be the same")
+			return
+		}
+	}
+	if strings.Index(first, `"name": "fields"`) > strings.Index(first, `"name": "id"`) {
+		testError(t, "the parameters should be sorted by the names")
+	}
+}
+
+func TestEngine_ReferenceURL(t *testing.T) {
+	router := newTestDocumentEngine(t)
+	renderer := reference.NewRenderer()
+	renderer.MarkdownTemplate, _ = reference.ParseMarkdownTemplate("{{.Title}} {{.BaseURL}}")
+	router.SetReferenceRenderer(renderer)
+	router.openAPIDocumentURL()
+
+	w := httptest.NewRecorder()
+	router.GinEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/docs/reference.md", nil))
+	if w.Code != 200 || w.Body.String() != "book store APIS https://api.example.com/v1" {
+		testError(t, "the Markdown reference should be rendered by the template of the renderer", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.GinEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/docs/reference.html", nil))
+	if w.Code != 200 || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") || !strings.Contains(w.Body.String(), `id="get-booksid"`) {
+		testError(t, "the HTML reference should be rendered by the default template", w.Code)
+	}
+}
+
+func TestEngine_Export(t *testing.T) {
+	router := newTestDocumentEngine(t)
+	collection, err := router.GetPostmanCollectionJSON()
+	if err != nil {
+		testError(t, err)
+	} else if !strings.Contains(collection, `"value": "https://api.example.com/v1"`) || !strings.Contains(collection, `"raw": "{{baseUrl}}/books/:id?fields=brief"`) {
+		testError(t, "the collection should have the baseUrl and the example values of the parameters")
+	}
+	if file := router.GetHTTPFile(); !strings.Contains(file, "POST {{baseUrl}}/books\nContent-Type: application/json\n\n{\n  \"title\": \"Demo book\"\n}\n") {
+		testError(t, "the .http file should have the example of the request", file)
+	}
+	if script := router.GetCurlScript(); !strings.Contains(script, "curl -X POST \"$BASE_URL\"'/covers' \\\n  -F 'file=@file'") {
+		testError(t, "the curl script should upload the file", script)
+	}
+}
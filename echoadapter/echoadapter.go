@@ -0,0 +1,173 @@
+// Package echoadapter register the documented operations of an ehttp.Engine on an echo server (github.com/labstack/echo/v4).
+// The swagger paths are translated to the paths of echo (like: /books/{id} -> /books/:id), the values of the parameters in path are got by c.Param.
+// The requests are checked like gin: the parameters, the request body and CORS (see Config.AllowOrigin),
+// the error is passed to the handler. The mock mode and the validation of the responses are supported too (see ehttp.Route.Serve).
+//
+// example:
+//    router := ehttp.NewEngine(conf)
+//    e := echoadapter.New(router, echo.New())
+//    e.GET("/books/{id}", doc, func(c echo.Context, err error) error {
+//        if err != nil {
+//            return c.String(http.StatusBadRequest, err.Error())
+//        }
+//        return c.String(http.StatusOK, c.Param("id"))
+//    })
+//    e.Echo.Start(":8000")
+package echoadapter
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/labstack/echo/v4"
+)
+
+// HandlerFunc the handler of a documented operation
+//   err -- the result of checking the request (if the request is valid, err == nil)
+type HandlerFunc func(c echo.Context, err error) error
+
+// Echo register the documented operations of the Engine on the echo server
+// Fields:
+//   Engine -- the engine of the documents
+//   Echo -- the echo server
+type Echo struct {
+	Engine     *ehttp.Engine
+	Echo       *echo.Echo
+	preflights map[string]bool
+}
+
+// New new an Echo of the engine, if e is nil, echo.New() is used.
+// The documents are served on the echo server if the Config.OpenAPIDocumentURL is true.
+func New(engine *ehttp.Engine, e *echo.Echo) *Echo {
+	if e == nil {
+		e = echo.New()
+	}
+	adapter := &Echo{Engine: engine, Echo: e, preflights: map[string]bool{}}
+	if engine.Conf.OpenAPIDocumentURL {
+		for url, handler := range engine.DocumentHandlers() {
+			e.GET(adapter.getPath(url), echo.WrapHandler(handler))
+		}
+	}
+	return adapter
+}
+
+// ServeHTTP serve the requests by the echo server
+func (e *Echo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.Echo.ServeHTTP(w, r)
+}
+
+// GET register the documented operation GET on the path (like: /books/{id}, without the BasePath)
+func (e *Echo) GET(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return e.Handle(ehttp.GET, path, doc, handler)
+}
+
+// POST register the documented operation POST on the path
+func (e *Echo) POST(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return e.Handle(ehttp.POST, path, doc, handler)
+}
+
+// PUT register the documented operation PUT on the path
+func (e *Echo) PUT(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return e.Handle(ehttp.PUT, path, doc, handler)
+}
+
+// PATCH register the documented operation PATCH on the path
+func (e *Echo) PATCH(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return e.Handle(ehttp.PATCH, path, doc, handler)
+}
+
+// DELETE register the documented operation DELETE on the path
+func (e *Echo) DELETE(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return e.Handle(ehttp.DELETE, path, doc, handler)
+}
+
+// Handle register the documented operation on the path, the path is in the swagger style or the echo style (like: /books/{id}, /books/:id)
+func (e *Echo) Handle(method string, path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	if handler == nil {
+		return errors.New(method + " " + path + " miss HandlerFunc")
+	}
+	// the operation is documented only if the path is translated to the path of echo
+	var echoPath string
+	route, err := e.Engine.RegisterRoute(method, path, doc, handler, func(route *ehttp.Route) error {
+		var err error
+		if echoPath, err = swaggerPathToEchoPath(route.Path); err != nil {
+			return err
+		}
+		e.Echo.Add(method, e.getPath(echoPath), func(c echo.Context) error {
+			var result error
+			response := c.Response()
+			writer := response.Writer
+			route.Serve(&context{c}, writer, func(w http.ResponseWriter, err error) {
+				if w == writer {
+					result = handler(c, err)
+					return
+				}
+				// the response is recorded, so the error of the handler is handled before the response is checked
+				response.Writer = w
+				defer func() { response.Writer = writer }()
+				if err := handler(c, err); err != nil {
+					c.Error(err)
+				}
+			})
+			return result
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if e.Engine.Conf.AllowOrigin && !e.preflights[route.Path] {
+		e.preflights[route.Path] = true
+		e.Echo.OPTIONS(e.getPath(echoPath), func(c echo.Context) error {
+			if err := e.Engine.CheckPreflight(&context{c}, route.Path); err != nil {
+				return c.String(http.StatusBadRequest, err.Error())
+			}
+			return c.JSON(http.StatusOK, map[string]interface{}{})
+		})
+	}
+	return nil
+}
+
+// getPath the path with the BasePath
+func (e *Echo) getPath(path string) string {
+	return strings.TrimRight(e.Engine.Conf.BasePath, "/") + path
+}
+
+// swaggerPathToEchoPath translate the swagger path to the path of echo (like: /books/{id} -> /books/:id)
+func swaggerPathToEchoPath(path string) (string, error) {
+	b := bytes.Buffer{}
+	flag := false
+	for i := 0; i < len(path); i++ {
+		if path[i] == '*' {
+			return "", errors.New("path " + path + " is not supported")
+		} else if path[i] == '{' {
+			b.WriteByte(':')
+			flag = true
+		} else if path[i] == '}' && flag {
+			flag = false
+		} else {
+			b.WriteByte(path[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// context the ehttp.Context of echo
+type context struct {
+	c echo.Context
+}
+
+func (c *context) Request() *http.Request {
+	return c.c.Request()
+}
+
+func (c *context) Param(name string) string {
+	return c.c.Param(name)
+}
+
+func (c *context) ResponseHeader() http.Header {
+	return c.c.Response().Header()
+}
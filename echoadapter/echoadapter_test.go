@@ -0,0 +1,147 @@
+package echoadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/labstack/echo/v4"
+)
+
+type book struct {
+	ID    string `json:"id" readonly:"true"`
+	Title string `json:"title"`
+}
+
+func newTestEcho(t *testing.T, conf *ehttp.Config) *Echo {
+	e := New(ehttp.NewEngine(conf), nil)
+	handler := func(c echo.Context, err error) error {
+		if err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		return c.String(http.StatusOK, c.Param("id")+":"+c.QueryParam("limit"))
+	}
+	getBook := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{
+			"id":    ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}},
+			"limit": ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Max: "10"}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := e.GET("/books/{id}", getBook, handler); err != nil {
+		t.Fatal(err)
+	}
+	createBook := &ehttp.APIDocCommon{
+		Request:   &ehttp.Request{Model: &book{}},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := e.POST("/books", createBook, handler); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func serve(e *Echo, method string, url string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	return w
+}
+
+func TestEcho(t *testing.T) {
+	e := newTestEcho(t, &ehttp.Config{BasePath: "/v1", OpenAPIDocumentURL: true})
+	nodes := []struct {
+		method string
+		url    string
+		body   string
+		code   int
+		resp   string
+	}{
+		{"GET", "/v1/books/1?limit=2", "", 200, "1:2"},
+		{"GET", "/v1/books/abc", "", 400, "invalid syntax"},
+		{"GET", "/v1/books/1?limit=11", "", 400, "greater than the maximum"},
+		{"POST", "/v1/books", `{"title":"Go"}`, 200, ":"},
+		{"POST", "/v1/books", `{"id":"1","title":"Go"}`, 400, "read only"},
+		{"GET", "/v1/docs/swagger.json", "", 200, `"/books/{id}"`},
+	}
+	for _, node := range nodes {
+		w := serve(e, node.method, node.url, node.body, map[string]string{"Content-Type": "application/json"})
+		if w.Code != node.code || !strings.Contains(w.Body.String(), node.resp) {
+			t.Error(node.method, node.url, "unexpected response", w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestEcho_CORS(t *testing.T) {
+	e := newTestEcho(t, &ehttp.Config{AllowOrigin: true, Origins: []string{"http://a.com"}})
+	w := serve(e, "OPTIONS", "/books/1", "", map[string]string{"Origin": "http://a.com"})
+	if w.Code != 200 || w.Header().Get("Access-Control-Allow-Origin") != "http://a.com" {
+		t.Error("the preflight request should be allowed", w.Code, w.Header())
+	}
+	w = serve(e, "GET", "/books/1", "", map[string]string{"Origin": "http://b.com"})
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "is not allow") {
+		t.Error("the origin should not be allowed", w.Code, w.Body.String())
+	}
+}
+
+func TestSwaggerPathToEchoPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/books/{id}":                "/books/:id",
+		"/books/{id}/authors/{name}": "/books/:id/authors/:name",
+		"/books":                     "/books",
+	} {
+		if echoPath, err := swaggerPathToEchoPath(path); err != nil || echoPath != expected {
+			t.Error(path, "should be", expected, echoPath, err)
+		}
+	}
+}
+
+func TestEcho_MockMode(t *testing.T) {
+	e := New(ehttp.NewEngine(&ehttp.Config{MockMode: true}), nil)
+	doc := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok", Model: &book{}}},
+	}
+	err := e.GET("/books/{id}", doc, func(c echo.Context, err error) error {
+		t.Error("the handler should not be called in the mock mode")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := serve(e, "GET", "/books/1", "", nil)
+	if w.Code != 200 || !strings.Contains(w.Body.String(), `"title"`) {
+		t.Error("the mock response should be written", w.Code, w.Body.String())
+	}
+	if w := serve(e, "GET", "/books/abc", "", nil); w.Code != 400 {
+		t.Error("the invalid parameter should be 400", w.Code, w.Body.String())
+	}
+}
+
+func TestEcho_StrictResponses(t *testing.T) {
+	e := New(ehttp.NewEngine(&ehttp.Config{StrictResponses: true, ResponseViolationHook: func(*ehttp.ResponseViolation) {}}), nil)
+	doc := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok", Model: &book{}}},
+	}
+	err := e.GET("/books/{id}", doc, func(c echo.Context, err error) error {
+		if c.Param("id") == "1" {
+			return c.JSON(http.StatusOK, &book{ID: "1", Title: "Go"})
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "not found")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(e, "GET", "/books/1", "", nil); w.Code != 200 || !strings.Contains(w.Body.String(), `"title":"Go"`) {
+		t.Error("the valid response should be written", w.Code, w.Body.String())
+	}
+	if w := serve(e, "GET", "/books/2", "", nil); w.Code != 500 || !strings.Contains(w.Body.String(), "404") {
+		t.Error("the error of the handler should be checked", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,325 @@
+package ehttptest
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/enjoy-web/ehttp/example"
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Case a generated contract case of an operation
+// Fields:
+//   Name -- the description of the case (like: "limit: less than the minimum")
+//   Params -- the values of the parameters, the missing parameters are not sent
+//   Body -- the request body
+//   Valid -- the valid cases must reach the handler, the invalid cases must be rejected before the handler
+type Case struct {
+	Name   string
+	Params map[string]interface{}
+	Body   interface{}
+	Valid  bool
+}
+
+// Contract the options of the contract tests
+// Fields:
+//   OperationIDs -- the tested operations, all the registered operations if it is empty
+//   Params -- the valid values of the parameters by the names (like: the id of an existing book), default is synthesized from the ValueInfos
+//   Bodies -- the valid request bodies by the operationIds, default is the Request.Example, or synthesized from the schema (see package example)
+type Contract struct {
+	OperationIDs []string
+	Params       map[string]interface{}
+	Bodies       map[string]interface{}
+}
+
+// GenerateCases generate the cases of the API from the ValueInfos of the parameters:
+// the valid cases (the required parameters, all the parameters), and the invalid cases of each parameter
+// (missing required, wrong type, min-1, max+1, not in the enum, longer than maxLen, shorter than minLen).
+func (c *Client) GenerateCases(api *ehttp.API, contract *Contract) ([]*Case, error) {
+	if contract == nil {
+		contract = &Contract{}
+	}
+	body, err := c.getValidBody(api, contract)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]interface{}{}
+	required := map[string]interface{}{}
+	for _, p := range api.Parameters {
+		value, ok := contract.Params[p.Name]
+		if !ok {
+			value = validValue(p.ValueInfo)
+		}
+		all[p.Name] = value
+		if p.IsRequired() {
+			required[p.Name] = value
+		}
+	}
+	cases := []*Case{
+		{Name: "valid: the required parameters", Params: required, Body: body, Valid: true},
+		{Name: "valid: all the parameters", Params: all, Body: body, Valid: true},
+	}
+	for _, p := range api.Parameters {
+		for _, invalid := range invalidValues(p) {
+			params := map[string]interface{}{}
+			for name, value := range all {
+				params[name] = value
+			}
+			if invalid.value == nil {
+				delete(params, p.Name)
+			} else {
+				params[p.Name] = invalid.value
+			}
+			cases = append(cases, &Case{Name: p.Name + ": " + invalid.name, Params: params, Body: body})
+		}
+	}
+	return cases, nil
+}
+
+// RunContract run the generated cases of the operations as the subtests, the invalid cases must be rejected
+// (the status code is the Client.ValidationStatus), and the valid cases must reach the handler (the status code is not the ValidationStatus)
+func (c *Client) RunContract(t *testing.T, contract *Contract) {
+	t.Helper()
+	apis, err := c.getContractAPIs(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, api := range apis {
+		api := api
+		t.Run(api.Method+" "+api.Path, func(t *testing.T) {
+			cases, err := c.GenerateCases(api, contract)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, cs := range cases {
+				resp, err := c.call(api, &Request{Params: cs.Params, Body: cs.Body})
+				if err != nil {
+					t.Error(cs.Name, err)
+					continue
+				}
+				if cs.Valid && resp.Code == c.ValidationStatus {
+					t.Errorf("%s: the valid request should reach the handler, got %d: %s", cs.Name, resp.Code, resp.Body.String())
+				}
+				if !cs.Valid && resp.Code != c.ValidationStatus {
+					t.Errorf("%s: the invalid request should be rejected (%d), got %d: %s", cs.Name, c.ValidationStatus, resp.Code, resp.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// Fuzz the native fuzz target of the operation, the arguments are the values of the parameters (not file) in the order of the document.
+// The corpus is seeded by the generated cases. The response must not be a server error (5xx),
+// set the Config.StrictResponses to check the responses with the documents too.
+// example:
+//    func FuzzGetBook(f *testing.F) {
+//        ehttptest.New(newRouter()).Fuzz(f, "getBook", nil)
+//    }
+func (c *Client) Fuzz(f *testing.F, operationID string, contract *Contract) {
+	f.Helper()
+	api, err := c.findAPI(func(api *ehttp.API) bool {
+		return api.OperationID == operationID
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	if api == nil {
+		f.Fatal("the operation " + operationID + " is not registered")
+	}
+	cases, err := c.GenerateCases(api, contract)
+	if err != nil {
+		f.Fatal(err)
+	}
+	parameters := []*ehttp.APIParameter{}
+	for _, p := range api.Parameters {
+		if p.ValueInfo.Type != "file" {
+			parameters = append(parameters, p)
+		}
+	}
+	for _, cs := range cases {
+		seed := []interface{}{}
+		for _, p := range parameters {
+			// the missing parameters are empty
+			value, _ := formatValue(p, cs.Params[p.Name])
+			seed = append(seed, value)
+		}
+		f.Add(seed...)
+	}
+	body := cases[0].Body
+	argTypes := []reflect.Type{reflect.TypeOf((*testing.T)(nil))}
+	for range parameters {
+		argTypes = append(argTypes, reflect.TypeOf(""))
+	}
+	target := reflect.MakeFunc(reflect.FuncOf(argTypes, nil, false), func(args []reflect.Value) []reflect.Value {
+		t := args[0].Interface().(*testing.T)
+		params := map[string]interface{}{}
+		for i, p := range parameters {
+			if value := args[i+1].String(); value != "" {
+				params[p.Name] = Raw(value)
+			}
+		}
+		for _, p := range api.Parameters {
+			if _, ok := cases[0].Params[p.Name]; ok && p.ValueInfo.Type == "file" {
+				params[p.Name] = cases[0].Params[p.Name]
+			}
+		}
+		resp, err := c.call(api, &Request{Params: params, Body: body})
+		if err != nil {
+			// the invalid paths (like: a missing parameter in path)
+			return nil
+		}
+		if resp.Code >= http.StatusInternalServerError {
+			t.Errorf("%s %s %v: the status code is %d: %s", api.Method, api.Path, params, resp.Code, resp.Body.String())
+		}
+		return nil
+	})
+	f.Fuzz(target.Interface())
+}
+
+func (c *Client) getContractAPIs(contract *Contract) ([]*ehttp.API, error) {
+	apis, err := c.Engine.GetAPIs()
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil || len(contract.OperationIDs) == 0 {
+		return apis, nil
+	}
+	selected := []*ehttp.API{}
+	for _, operationID := range contract.OperationIDs {
+		found := false
+		for _, api := range apis {
+			if api.OperationID == operationID {
+				selected = append(selected, api)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.New("the operation " + operationID + " is not registered")
+		}
+	}
+	return selected, nil
+}
+
+// getValidBody the valid request body of the API, nil if the API has no request body
+func (c *Client) getValidBody(api *ehttp.API, contract *Contract) (interface{}, error) {
+	if body, ok := contract.Bodies[api.OperationID]; ok {
+		return body, nil
+	}
+	if api.Request == nil {
+		return nil, nil
+	}
+	if api.Request.Example != nil {
+		return api.Request.Example, nil
+	}
+	operation := getSwaggerOperation(c.Engine.Swagger, api.Method, api.Path)
+	if operation == nil {
+		return nil, errors.New("the operation " + api.Method + " " + api.Path + " is not documented")
+	}
+	for _, p := range operation.Parameters {
+		if p.In == "body" {
+			return example.RequestBody(c.Engine.Swagger, p), nil
+		}
+	}
+	return nil, nil
+}
+
+func getSwaggerOperation(doc *swagger.Swagger, method string, path string) *swagger.Operation {
+	item := doc.Paths[path]
+	if item == nil {
+		return nil
+	}
+	switch method {
+	case ehttp.GET:
+		return item.Get
+	case ehttp.POST:
+		return item.Post
+	case ehttp.PUT:
+		return item.Put
+	case ehttp.PATCH:
+		return item.Patch
+	case ehttp.DELETE:
+		return item.Delete
+	}
+	return nil
+}
+
+// validValue the valid value of the ValueInfo: the example, the default value, the first value of the enum,
+// the minimum, the maximum, or a value of the type
+func validValue(v *ehttp.ValueInfo) interface{} {
+	switch {
+	case v.Type == "file":
+		return &File{Name: "file.txt", Content: []byte("file")}
+	case v.Example != "":
+		return Raw(v.Example)
+	case v.Default != "":
+		return Raw(v.Default)
+	case v.Enum != "":
+		return Raw(strings.Fields(v.Enum)[0])
+	case v.Min != "":
+		return Raw(v.Min)
+	case v.Max != "":
+		return Raw(v.Max)
+	case v.Type == "bool":
+		return Raw("true")
+	case v.Type != "string":
+		return Raw("1")
+	}
+	length := 1
+	if minLen, err := strconv.Atoi(v.MinLen); err == nil && minLen > length {
+		length = minLen
+	}
+	return Raw(strings.Repeat("a", length))
+}
+
+type invalidValue struct {
+	name  string
+	value interface{} // nil is missing
+}
+
+// invalidValues the invalid values of the parameter
+func invalidValues(p *ehttp.APIParameter) []invalidValue {
+	v := p.ValueInfo
+	values := []invalidValue{}
+	// the files are not checked by the engine
+	if v.Required && p.In != ehttp.InPath && v.Type != "file" {
+		values = append(values, invalidValue{"missing required", nil})
+	}
+	isInt := strings.HasPrefix(v.Type, "int") || strings.HasPrefix(v.Type, "uint")
+	isFloat := strings.HasPrefix(v.Type, "float")
+	if isInt || isFloat || v.Type == "bool" {
+		values = append(values, invalidValue{"wrong type", Raw("abc")})
+	}
+	if isInt && v.Enum != "" {
+		outsider := int64(0)
+		for _, value := range strings.Fields(v.Enum) {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= outsider {
+				outsider = n + 1
+			}
+		}
+		values = append(values, invalidValue{"not in the enum", Raw(strconv.FormatInt(outsider, 10))})
+	}
+	if v.Type == "string" && v.Enum != "" {
+		outsider := "not-" + strings.Join(strings.Fields(v.Enum), "-")
+		values = append(values, invalidValue{"not in the enum", Raw(outsider)})
+	}
+	if min, err := strconv.ParseFloat(v.Min, 64); err == nil && (isInt || isFloat) {
+		values = append(values, invalidValue{"less than the minimum", Raw(strconv.FormatFloat(min-1, 'f', -1, 64))})
+	}
+	if max, err := strconv.ParseFloat(v.Max, 64); err == nil && (isInt || isFloat) {
+		values = append(values, invalidValue{"greater than the maximum", Raw(strconv.FormatFloat(max+1, 'f', -1, 64))})
+	}
+	if v.Type == "string" {
+		if maxLen, err := strconv.Atoi(v.MaxLen); err == nil {
+			values = append(values, invalidValue{"longer than the maxLength", Raw(strings.Repeat("a", maxLen+1))})
+		}
+		if minLen, err := strconv.Atoi(v.MinLen); err == nil && minLen > 1 {
+			values = append(values, invalidValue{"shorter than the minLength", Raw(strings.Repeat("a", minLen-1))})
+		}
+	}
+	return values
+}
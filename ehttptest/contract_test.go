@@ -0,0 +1,102 @@
+package ehttptest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/gin-gonic/gin"
+)
+
+type author struct {
+	Name string `json:"name" minlen:"1"`
+}
+
+func newContractEngine(t testing.TB) *ehttp.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := ehttp.NewEngine(&ehttp.Config{})
+	listAuthors := &ehttp.APIDocCommon{
+		OperationID: "listAuthors",
+		Parameters: map[string]ehttp.Parameter{
+			"group":  ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64", Enum: "1 2 3"}},
+			"limit":  ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Min: "1", Max: "100", Required: true}},
+			"sort":   ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "string", Enum: "name age"}},
+			"name":   ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "string", MinLen: "2", MaxLen: "8"}},
+			"score":  ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "float64", Max: "9.5"}},
+			"X-Beta": ehttp.Parameter{InHeader: &ehttp.ValueInfo{Type: "bool"}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	handler := func(c *gin.Context, err error) {
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+	if err := router.GET("/groups/:group/authors", listAuthors, handler); err != nil {
+		t.Fatal(err)
+	}
+	createAuthor := &ehttp.APIDocCommon{
+		OperationID: "createAuthor",
+		Request:     &ehttp.Request{Model: &author{}, Example: &author{Name: "Tom"}},
+		Responses:   map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := router.POST("/authors", createAuthor, handler); err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestClient_GenerateCases(t *testing.T) {
+	client := New(newContractEngine(t))
+	apis, err := client.Engine.GetAPIs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases, err := client.GenerateCases(apis[0], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, cs := range cases {
+		names = append(names, cs.Name)
+	}
+	for _, expected := range []string{
+		"valid: the required parameters",
+		"valid: all the parameters",
+		"group: not in the enum",
+		"limit: missing required",
+		"limit: wrong type",
+		"limit: less than the minimum",
+		"limit: greater than the maximum",
+		"sort: not in the enum",
+		"score: greater than the maximum",
+		"X-Beta: wrong type",
+		"name: longer than the maxLength",
+		"name: shorter than the minLength",
+	} {
+		if !strings.Contains(strings.Join(names, "\n"), expected) {
+			t.Error("the cases should contain " + expected)
+		}
+	}
+	if params := cases[0].Params; len(params) != 2 || params["group"] != Raw("1") || params["limit"] != Raw("1") {
+		t.Error("invalid valid case", params)
+	}
+	cases, err = client.GenerateCases(apis[1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, ok := cases[0].Body.(*author); !ok || body.Name != "Tom" {
+		t.Error("the body should be the Request.Example", cases[0].Body)
+	}
+}
+
+func TestClient_RunContract(t *testing.T) {
+	New(newContractEngine(t)).RunContract(t, &Contract{Params: map[string]interface{}{"limit": 10}})
+}
+
+func FuzzListAuthors(f *testing.F) {
+	New(newContractEngine(f)).Fuzz(f, "listAuthors", nil)
+}
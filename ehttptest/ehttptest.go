@@ -0,0 +1,345 @@
+// Package ehttptest call the documented operations of an ehttp.Engine in the tests.
+// The operations are addressed by the method and the path, or by the operationId.
+// The parameters are filled from a map and checked with the types of the ValueInfos, the body is encoded by the Consumes,
+// and the response is decoded into the documented model of the returned status code.
+//
+// example:
+//    client := ehttptest.New(router)
+//    resp, err := client.CallOperation("getBook", &ehttptest.Request{Params: map[string]interface{}{"id": "1", "limit": 10}})
+//    resp.AssertStatus(t, http.StatusOK)
+//    book := resp.MustDecode(t).(*Book)
+package ehttptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+)
+
+// Raw a parameter value sent as it is, without the type check (like: an invalid value to test the validation)
+type Raw string
+
+// File a file of a formData parameter (type file)
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Request the request of a documented operation
+// Fields:
+//   Params -- the values of the parameters (in path, query, header and formData) by the names,
+//             the types of the values must match the ValueInfos (like: int32 -> an integer, bool -> bool), or be Raw
+//   Body -- the request body, it is encoded by the Consumes of the API (JSON, XML). A string or []byte is sent as it is.
+//   Headers -- the headers which are not documented (like: Authorization)
+type Request struct {
+	Params  map[string]interface{}
+	Body    interface{}
+	Headers map[string]string
+}
+
+// Client call the documented operations of the Engine
+// Fields:
+//   Engine -- the engine of the APIs
+//   ValidationStatus -- the status code of the responses of the invalid parameters, default is 400 (see Response.AssertValidationError)
+type Client struct {
+	Engine           *ehttp.Engine
+	ValidationStatus int
+}
+
+// New a Client of the Engine, call it after the APIs are registered
+func New(engine *ehttp.Engine) *Client {
+	return &Client{Engine: engine, ValidationStatus: http.StatusBadRequest}
+}
+
+// Call call the operation of the method and the path, the path is the documented path (like: /books/{id} or /books/:id, without the BasePath)
+func (c *Client) Call(method string, path string, req *Request) (*Response, error) {
+	path = toSwaggerPath(path)
+	api, err := c.findAPI(func(api *ehttp.API) bool {
+		return api.Method == strings.ToUpper(method) && api.Path == path
+	})
+	if err != nil {
+		return nil, err
+	}
+	if api == nil {
+		return nil, errors.New("the operation " + method + " " + path + " is not registered")
+	}
+	return c.call(api, req)
+}
+
+// CallOperation call the operation of the operationId
+func (c *Client) CallOperation(operationID string, req *Request) (*Response, error) {
+	api, err := c.findAPI(func(api *ehttp.API) bool {
+		return api.OperationID == operationID
+	})
+	if err != nil {
+		return nil, err
+	}
+	if api == nil {
+		return nil, errors.New("the operation " + operationID + " is not registered")
+	}
+	return c.call(api, req)
+}
+
+func (c *Client) findAPI(match func(api *ehttp.API) bool) (*ehttp.API, error) {
+	apis, err := c.Engine.GetAPIs()
+	if err != nil {
+		return nil, err
+	}
+	for _, api := range apis {
+		if match(api) {
+			return api, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) call(api *ehttp.API, req *Request) (*Response, error) {
+	if req == nil {
+		req = &Request{}
+	}
+	r, err := newHTTPRequest(c.Engine.Conf.BasePath, api, req)
+	if err != nil {
+		return nil, err
+	}
+	w := httptest.NewRecorder()
+	c.Engine.GinEngine().ServeHTTP(w, r)
+	return &Response{ResponseRecorder: w, API: api, validationStatus: c.ValidationStatus}, nil
+}
+
+// newHTTPRequest the HTTP request of the API, the parameters are checked with the ValueInfos
+func newHTTPRequest(basePath string, api *ehttp.API, req *Request) (*http.Request, error) {
+	path := api.Path
+	query := url.Values{}
+	headers := http.Header{}
+	form := url.Values{}
+	files := map[string]*File{}
+	parameters := map[string]bool{}
+	for _, p := range api.Parameters {
+		parameters[p.Name] = true
+		value, ok := req.Params[p.Name]
+		if !ok {
+			continue
+		}
+		if file, ok := value.(*File); ok && p.ValueInfo.Type == "file" {
+			files[p.Name] = file
+			continue
+		}
+		s, err := formatValue(p, value)
+		if err != nil {
+			return nil, err
+		}
+		switch p.In {
+		case ehttp.InPath:
+			path = strings.Replace(path, "{"+p.Name+"}", url.PathEscape(s), -1)
+		case ehttp.InQuery:
+			query.Set(p.Name, s)
+		case ehttp.InHeader:
+			headers.Set(p.Name, s)
+		case ehttp.InFormData:
+			form.Set(p.Name, s)
+		}
+	}
+	for name := range req.Params {
+		if !parameters[name] {
+			return nil, errors.New("the parameter " + name + " is not documented in " + api.Method + " " + api.Path)
+		}
+	}
+	if strings.Contains(path, "{") {
+		return nil, errors.New("miss the parameters in the path " + path)
+	}
+	u := strings.TrimRight(basePath, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	body, contentType, err := encodeBody(api, req.Body, form, files)
+	if err != nil {
+		return nil, err
+	}
+	r := httptest.NewRequest(api.Method, u, body)
+	for name, values := range headers {
+		r.Header[name] = values
+	}
+	for name, value := range req.Headers {
+		r.Header.Set(name, value)
+	}
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r, nil
+}
+
+// formatValue format the value of the parameter, the type of the value must match the ValueInfo
+func formatValue(p *ehttp.APIParameter, value interface{}) (string, error) {
+	if raw, ok := value.(Raw); ok {
+		return string(raw), nil
+	}
+	v := reflect.ValueOf(value)
+	kind := reflect.Invalid
+	if v.IsValid() {
+		kind = v.Kind()
+	}
+	typ := p.ValueInfo.Type
+	ok := false
+	switch {
+	case typ == "string":
+		ok = kind == reflect.String
+	case typ == "bool":
+		ok = kind == reflect.Bool
+	case strings.HasPrefix(typ, "int"):
+		ok = kind >= reflect.Int && kind <= reflect.Int64 || kind >= reflect.Uint && kind <= reflect.Uint64
+	case strings.HasPrefix(typ, "uint"):
+		ok = kind >= reflect.Uint && kind <= reflect.Uint64 || kind >= reflect.Int && kind <= reflect.Int64 && v.Int() >= 0
+	case strings.HasPrefix(typ, "float"):
+		ok = kind >= reflect.Int && kind <= reflect.Float64
+	}
+	if !ok {
+		return "", fmt.Errorf("the parameter %s (in %s) is %s, the value %#v is %T", p.Name, p.In, typ, value, value)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// encodeBody encode the body by the Consumes of the API, or the formData parameters
+func encodeBody(api *ehttp.API, body interface{}, form url.Values, files map[string]*File) (io.Reader, string, error) {
+	consumes := strings.Join(api.Consumes, " ")
+	if len(files) > 0 || (len(form) > 0 && !strings.Contains(consumes, "application/x-www-form-urlencoded")) {
+		b := &bytes.Buffer{}
+		writer := multipart.NewWriter(b)
+		for name := range form {
+			writer.WriteField(name, form.Get(name))
+		}
+		for name, file := range files {
+			part, err := writer.CreateFormFile(name, file.Name)
+			if err != nil {
+				return nil, "", err
+			}
+			part.Write(file.Content)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", err
+		}
+		return b, writer.FormDataContentType(), nil
+	}
+	if len(form) > 0 {
+		return strings.NewReader(form.Encode()), "application/x-www-form-urlencoded", nil
+	}
+	if body == nil {
+		return nil, "", nil
+	}
+	contentType := "application/json"
+	if len(api.Consumes) > 0 {
+		contentType = api.Consumes[0]
+	}
+	switch b := body.(type) {
+	case string:
+		return strings.NewReader(b), contentType, nil
+	case []byte:
+		return bytes.NewReader(b), contentType, nil
+	}
+	var data []byte
+	var err error
+	if strings.Contains(contentType, "xml") {
+		data, err = xml.Marshal(body)
+	} else {
+		data, err = json.Marshal(body)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(data), contentType, nil
+}
+
+// toSwaggerPath convert the gin style path to the swagger style (like: /books/:id -> /books/{id})
+func toSwaggerPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Response the response of a documented operation
+// Fields:
+//   ResponseRecorder -- the recorded response (Code, Body, Header())
+//   API -- the called API
+type Response struct {
+	*httptest.ResponseRecorder
+	API              *ehttp.API
+	validationStatus int
+}
+
+// Decode decode the body into a new value of the documented model of the status code (like: *Book, *[]Book),
+// the body is decoded as XML if the Content-Type is XML, else JSON
+func (r *Response) Decode() (interface{}, error) {
+	response, ok := r.API.Responses[r.Code]
+	if !ok {
+		if response, ok = r.API.Responses[-1]; !ok {
+			return nil, fmt.Errorf("the response %d of %s %s is not documented", r.Code, r.API.Method, r.API.Path)
+		}
+	}
+	if response.Model == nil {
+		return nil, fmt.Errorf("the response %d of %s %s has no model", r.Code, r.API.Method, r.API.Path)
+	}
+	typ := reflect.TypeOf(response.Model)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	value := reflect.New(typ).Interface()
+	var err error
+	if strings.Contains(r.Header().Get("Content-Type"), "xml") {
+		err = xml.Unmarshal(r.Body.Bytes(), value)
+	} else {
+		err = json.Unmarshal(r.Body.Bytes(), value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode the response %d of %s %s, %s", r.Code, r.API.Method, r.API.Path, err.Error())
+	}
+	return value, nil
+}
+
+// MustDecode decode the body like Decode, the test fails if the body can't be decoded
+func (r *Response) MustDecode(t testing.TB) interface{} {
+	t.Helper()
+	value, err := r.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+// AssertStatus the test fails if the status code is not the code
+func (r *Response) AssertStatus(t testing.TB, code int) bool {
+	t.Helper()
+	if r.Code != code {
+		t.Errorf("%s %s: the status code should be %d, got %d: %s", r.API.Method, r.API.Path, code, r.Code, r.Body.String())
+		return false
+	}
+	return true
+}
+
+// AssertValidationError the test fails if the response is not a validation error: the status code is the Client.ValidationStatus,
+// and the body contains the message (like: "miss parameter id"), the message is not checked if it is empty
+func (r *Response) AssertValidationError(t testing.TB, message string) bool {
+	t.Helper()
+	if !r.AssertStatus(t, r.validationStatus) {
+		return false
+	}
+	if message != "" && !strings.Contains(r.Body.String(), message) {
+		t.Errorf("%s %s: the validation error should contain %q, got %s", r.API.Method, r.API.Path, message, r.Body.String())
+		return false
+	}
+	return true
+}
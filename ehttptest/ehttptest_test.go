@@ -0,0 +1,151 @@
+package ehttptest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/gin-gonic/gin"
+)
+
+type book struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Pages int    `json:"pages"`
+}
+
+func newTestEngine(t *testing.T) *ehttp.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := ehttp.NewEngine(&ehttp.Config{BasePath: "/v1"})
+	getBook := &ehttp.APIDocCommon{
+		OperationID: "getBook",
+		Parameters: map[string]ehttp.Parameter{
+			"id":      ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "string"}},
+			"limit":   ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Min: "1"}},
+			"X-Trace": ehttp.Parameter{InHeader: &ehttp.ValueInfo{Type: "bool"}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Model: &book{}}},
+	}
+	err := router.GET("/books/:id", getBook, func(c *gin.Context, err error) {
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, &book{ID: c.Param("id"), Title: c.Query("limit") + c.GetHeader("X-Trace")})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	createBook := &ehttp.APIDocCommon{
+		Request:   &ehttp.Request{Model: &book{}},
+		Responses: map[int]ehttp.Response{201: ehttp.Response{Model: &book{}}},
+	}
+	err = router.POST("/books", createBook, func(c *gin.Context, err error) {
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		b := &book{}
+		c.BindJSON(b)
+		b.ID = "2"
+		c.JSON(http.StatusCreated, b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadCover := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{
+			"name":  ehttp.Parameter{InFormData: &ehttp.ValueInfo{Type: "string"}},
+			"cover": ehttp.Parameter{InFormData: &ehttp.ValueInfo{Type: "file", Required: true}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{}},
+	}
+	err = router.POST("/covers", uploadCover, func(c *gin.Context, err error) {
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		file, _, _ := c.Request.FormFile("cover")
+		data, _ := ioutil.ReadAll(file)
+		c.String(http.StatusOK, c.PostForm("name")+":"+string(data))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestClient_Call(t *testing.T) {
+	client := New(newTestEngine(t))
+	for _, path := range []string{"/books/{id}", "/books/:id"} {
+		resp, err := client.Call("GET", path, &Request{Params: map[string]interface{}{"id": "1", "limit": 10, "X-Trace": true}})
+		if err != nil {
+			t.Error(path, err)
+			continue
+		}
+		if !resp.AssertStatus(t, http.StatusOK) {
+			continue
+		}
+		if b := resp.MustDecode(t).(*book); b.ID != "1" || b.Title != "10true" {
+			t.Error(path, "unexpected book", b)
+		}
+	}
+	if _, err := client.Call("GET", "/authors/{id}", nil); err == nil {
+		t.Error("the operation which is not registered should be an error")
+	}
+}
+
+func TestClient_CallOperation(t *testing.T) {
+	client := New(newTestEngine(t))
+	resp, err := client.CallOperation("getBook", &Request{Params: map[string]interface{}{"id": "1", "limit": Raw("0")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.AssertValidationError(t, "less than the minimum")
+	nodes := []struct {
+		params map[string]interface{}
+		desc   string
+	}{
+		{map[string]interface{}{"id": "1", "limit": "10"}, "the string value of an int32 parameter"},
+		{map[string]interface{}{"id": 1}, "the int value of a string parameter"},
+		{map[string]interface{}{"id": "1", "page": 1}, "the parameter which is not documented"},
+		{map[string]interface{}{}, "the missing parameter in path"},
+	}
+	for _, node := range nodes {
+		if _, err := client.CallOperation("getBook", &Request{Params: node.params}); err == nil {
+			t.Error(node.desc + " should be an error")
+		}
+	}
+}
+
+func TestClient_Body(t *testing.T) {
+	client := New(newTestEngine(t))
+	resp, err := client.Call("POST", "/books", &Request{Body: &book{Title: "Go", Pages: 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.AssertStatus(t, http.StatusCreated)
+	if b := resp.MustDecode(t).(*book); b.ID != "2" || b.Title != "Go" || b.Pages != 100 {
+		t.Error("unexpected book", b)
+	}
+	resp, err = client.Call("POST", "/books", &Request{Body: `{"title": 1}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.AssertValidationError(t, "")
+	if _, err := resp.Decode(); err == nil {
+		t.Error("the response 400 is not documented, it should not be decoded")
+	}
+
+	resp, err = client.Call("POST", "/covers", &Request{Params: map[string]interface{}{
+		"name":  "go",
+		"cover": &File{Name: "go.png", Content: []byte("png")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.AssertStatus(t, http.StatusOK) && resp.Body.String() != "go:png" {
+		t.Error("unexpected response " + resp.Body.String())
+	}
+}
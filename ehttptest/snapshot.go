@@ -0,0 +1,109 @@
+package ehttptest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+	"github.com/enjoy-web/ehttp/diff"
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/ghodss/yaml"
+)
+
+// the -update flag of the tests, it is declared by the package, so the tests which import ehttptest should not declare it again
+var update = flag.Bool("update", false, "update the golden files of ehttptest.AssertDocument")
+
+// AssertDocument compare the swagger document of the engine with the golden file, the test fails if they are different.
+// The golden file is YAML if the extension is .yaml or .yml, else JSON. The keys of the document are sorted,
+// and the parameters are sorted by the names, so the document is the same between the runs.
+// Run the tests with -update to write the document to the golden file (like: go test ./api -update),
+// then review the changes of the golden file.
+// example:
+//    func TestDocument(t *testing.T) {
+//        ehttptest.AssertDocument(t, newRouter(), "testdata/swagger.json")
+//    }
+func AssertDocument(t testing.TB, engine *ehttp.Engine, goldenFile string) {
+	t.Helper()
+	var document string
+	var err error
+	if isYAMLFile(goldenFile) {
+		document, err = engine.GetSwaggerYAMLDocument()
+	} else {
+		document, err = engine.GetSwaggerJSONDocument()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	document = strings.TrimSuffix(document, "\n") + "\n"
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(goldenFile, []byte(document), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	data, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read the golden file (run the tests with -update to create it), %s", err.Error())
+	}
+	if string(data) == document {
+		return
+	}
+	t.Errorf("the document is different from the golden file %s (run the tests with -update if the change is intended)\n%s",
+		goldenFile, describeDocumentChanges(goldenFile, string(data), document))
+}
+
+func isYAMLFile(fileName string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// describeDocumentChanges the first different line, and the changes of the APIs (see package diff) if the golden file is a valid document
+func describeDocumentChanges(goldenFile string, golden string, document string) string {
+	goldenLines := strings.Split(golden, "\n")
+	lines := strings.Split(document, "\n")
+	b := strings.Builder{}
+	for i := 0; i < len(goldenLines) || i < len(lines); i++ {
+		var goldenLine, line string
+		if i < len(goldenLines) {
+			goldenLine = goldenLines[i]
+		}
+		if i < len(lines) {
+			line = lines[i]
+		}
+		if goldenLine != line {
+			fmt.Fprintf(&b, "line %d:\n  golden: %s\n  actual: %s\n", i+1, goldenLine, line)
+			break
+		}
+	}
+	old, err := parseDocument(goldenFile, golden)
+	if err != nil {
+		return b.String()
+	}
+	current, err := parseDocument(goldenFile, document)
+	if err != nil {
+		return b.String()
+	}
+	for _, change := range diff.Compare(old, current) {
+		b.WriteString(change.String() + "\n")
+	}
+	return b.String()
+}
+
+func parseDocument(fileName string, document string) (*swagger.Swagger, error) {
+	data := []byte(document)
+	if isYAMLFile(fileName) {
+		var err error
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, err
+		}
+	}
+	return swagger.ParseDocument(data)
+}
@@ -0,0 +1,50 @@
+package ehttptest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAssertDocument(t *testing.T) {
+	AssertDocument(t, newTestEngine(t), "testdata/swagger.json")
+	AssertDocument(t, newTestEngine(t), "testdata/swagger.yaml")
+}
+
+func TestAssertDocument_Update(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ehttptest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	goldenFile := filepath.Join(dir, "docs", "swagger.json")
+	updated := *update
+	*update = true
+	AssertDocument(t, newTestEngine(t), goldenFile)
+	*update = updated
+	data, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"/books/{id}"`) {
+		t.Error("the document should be written to the golden file")
+	}
+	AssertDocument(t, newTestEngine(t), goldenFile)
+}
+
+func TestDescribeDocumentChanges(t *testing.T) {
+	golden, err := newTestEngine(t).GetSwaggerJSONDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := strings.Replace(golden, `"name": "limit"`, `"name": "size"`, 1)
+	changes := describeDocumentChanges("swagger.json", golden, document)
+	if !strings.Contains(changes, `golden:             "name": "limit"`) || !strings.Contains(changes, `actual:             "name": "size"`) {
+		t.Error("the first different line should be described", changes)
+	}
+	if !strings.Contains(changes, "GET /books/{id}") {
+		t.Error("the changes of the APIs should be described", changes)
+	}
+}
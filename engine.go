@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"net/http"
 	"strings"
 
+	"github.com/enjoy-web/ehttp/reference"
 	"github.com/enjoy-web/ehttp/swagger"
 	"github.com/ghodss/yaml"
 	"github.com/gin-gonic/gin"
@@ -13,6 +15,9 @@ import (
 
 const DefalutAPIDocumentUrl = "/docs/swagger.json"
 const DefalutYAMLAPIDocumentUrl = "/docs/swagger.yaml"
+const DefalutReferenceMarkdownUrl = "/docs/reference.md"
+const DefalutReferenceHTMLUrl = "/docs/reference.html"
+const DefalutTypeScriptUrl = "/docs/client.ts"
 
 // Engine is the framework's instance, it contains the configuration settings and *gin.Engine.
 // Create an instance of Engine, by using NewEngine(*rest.config)
@@ -22,6 +27,76 @@ type Engine struct {
 	Swagger          *swagger.Swagger
 	pathCorsInfos    map[string]*corsInfos
 	globalParameters map[string]Parameter
+	operationIDs     map[string]string
+	unknownTags      map[string]bool
+	apis             []*registeredAPI
+	routes           []*handledRoute
+	mocker           *engineMocker
+	confErr          error
+	renderer         *reference.Renderer
+}
+
+// registeredAPI an API registered with an APIDoc, the path is the swagger path (like: /books/{id})
+type registeredAPI struct {
+	method    string
+	path      string
+	doc       APIDoc
+	operation *swagger.Operation
+}
+
+// apiParameter a parameter (not the body) of a registered API
+type apiParameter struct {
+	name      string
+	in        string
+	valueInfo *ValueInfo
+}
+
+func (p *apiParameter) isRequired() bool {
+	return p.valueInfo.Required || p.in == InPath
+}
+
+// getAPIParameters the parameters of the API (without the body) in the order of the swagger operation,
+// the references of the global parameters are resolved
+func (e *Engine) getAPIParameters(api *registeredAPI) ([]*apiParameter, error) {
+	parameters := []*apiParameter{}
+	for _, swaggerParameter := range api.operation.Parameters {
+		name, in := swaggerParameter.Name, swaggerParameter.In
+		var parameter Parameter
+		if strings.HasPrefix(swaggerParameter.Ref, "#/parameters/") {
+			name = strings.TrimPrefix(swaggerParameter.Ref, "#/parameters/")
+			p, ok := e.globalParameters[name]
+			if !ok {
+				return nil, errors.New("the ref " + swaggerParameter.Ref + " is not found")
+			}
+			parameter = p
+			in = p.getIn()
+		} else {
+			parameter = api.doc.GetParameters()[name]
+		}
+		var valueInfo *ValueInfo
+		switch in {
+		case InPath:
+			valueInfo = parameter.InPath
+		case InHeader:
+			valueInfo = parameter.InHeader
+		case InQuery:
+			valueInfo = parameter.InQuery
+		case InFormData:
+			valueInfo = parameter.InFormData
+		}
+		if valueInfo != nil {
+			parameters = append(parameters, &apiParameter{name, in, valueInfo})
+		}
+	}
+	return parameters, nil
+}
+
+// getResponses the responses of the API, only the APIDocCommon has the responses
+func (api *registeredAPI) getResponses() map[int]Response {
+	if doc, ok := api.doc.(*APIDocCommon); ok {
+		return doc.Responses
+	}
+	return nil
 }
 
 // NewEngine new an Engine from the config
@@ -40,8 +115,12 @@ func NewEngineByGin(conf *Config, engine *gin.Engine) *Engine {
 	return e
 }
 
-// Run server
-func (e *Engine) Run(addr ...string) {
+// Run server, the errors of the Config (see Config.Validate) are returned before the server runs
+func (e *Engine) Run(addr ...string) error {
+	if e.confErr != nil {
+		return e.confErr
+	}
+	e.lintOnStartup()
 	// open api document url
 	if e.Conf.OpenAPIDocumentURL {
 		e.openAPIDocumentURL()
@@ -50,18 +129,11 @@ func (e *Engine) Run(addr ...string) {
 	if e.Conf.AllowOrigin {
 		e.allowOrigin()
 	}
-	e.GinEngine().Run(addr...)
+	return e.GinEngine().Run(addr...)
 }
 
 // GET is a shortcut for gin router.Handle("GET", path, handle).
 func (e *Engine) GET(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
-	// set swagger paths
-	if doc != nil {
-		if err := e.setSwaggerPath(relativePath, GET, doc); err != nil {
-			return err
-		}
-	}
-	// handle
 	return e.handle(GET, relativePath, doc, handlers)
 }
 
@@ -128,11 +200,35 @@ func (e *Engine) initSwaggerConf() {
 	e.Swagger.SwaggerVersion = "2.0"
 	// set swagger Info
 	e.Swagger.Info = &swagger.Info{
-		Title:       e.Conf.Title,
-		Description: e.Conf.Description,
-		Version:     e.Conf.Version,
+		Title:          e.Conf.Title,
+		Description:    e.Conf.Description,
+		Version:        e.Conf.Version,
+		TermsOfService: e.Conf.TermsOfService,
+	}
+	// the invalid values are not in the document, the errors are returned by Engine.Run
+	errs := e.Conf.validate()
+	for _, err := range errs {
+		logWarning("Config " + err.Error())
+	}
+	e.confErr = errors.Join(errs...)
+	e.Swagger.Info.Contact, _ = e.Conf.Contact.toSwaggerContact()
+	e.Swagger.Info.License, _ = e.Conf.License.toSwaggerLicense()
+
+	// set swagger tags by the order of the declaration
+	e.Swagger.Tags = nil
+	for _, tag := range e.Conf.Tags {
+		if e.getSwaggerTag(tag.Name) != nil {
+			logWarning("Config the tag " + tag.Name + " is declared more than once")
+			continue
+		}
+		if swaggerTag, err := tag.toSwaggerTag(); err == nil {
+			e.Swagger.Tags = append(e.Swagger.Tags, swaggerTag)
+		}
 	}
 
+	// set swagger externalDocs
+	e.Swagger.ExternalDocs, _ = e.Conf.ExternalDocs.toSwaggerExternalDocs()
+
 	// set swagger basePath
 	e.Swagger.BasePath = e.Conf.BasePath
 
@@ -142,36 +238,78 @@ func (e *Engine) initSwaggerConf() {
 	}
 }
 
-func (e *Engine) setSwaggerPath(relativePath string, method string, doc APIDoc) error {
+func (e *Engine) getSwaggerTag(name string) *swagger.Tag {
+	for _, tag := range e.Swagger.Tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// checkOperationTags warns once on each tag of the operations which is not declared in the Config.Tags.
+func (e *Engine) checkOperationTags(relativePath string, method string, tags []string) {
+	if len(e.Conf.Tags) == 0 {
+		return
+	}
+	if e.unknownTags == nil {
+		e.unknownTags = map[string]bool{}
+	}
+	for _, tag := range tags {
+		if e.getSwaggerTag(tag) != nil || e.unknownTags[tag] {
+			continue
+		}
+		e.unknownTags[tag] = true
+		logWarning(method + " " + relativePath + " the tag " + tag + " is not declared in Config.Tags")
+	}
+}
+
+// getSwaggerPath check the document of the operation, and get the swagger operation and the definitions of it,
+// the engine is not changed until the operation is documented (see Engine.setSwaggerPath)
+func (e *Engine) getSwaggerPath(relativePath string, method string, group string, doc APIDoc, handlerName string) (*swagger.Operation, map[string]*swagger.Schema, error) {
 	// to swagger path
 	relativePath, err := ginPathToSwaggerPath(relativePath)
 	if err != nil {
-		return &engineError{relativePath, method, err}
+		return nil, nil, &engineError{relativePath, method, err}
 	}
-	// set swagger Paths
+	// get swagger operation
 	operation, err := doc.ToSwaggerOperation()
 	if err != nil {
-		return &engineError{relativePath, method, err}
+		return nil, nil, &engineError{relativePath, method, err}
+	}
+	if err := e.setOperationID(relativePath, method, operation, handlerName); err != nil {
+		return nil, nil, &engineError{relativePath, method, err}
 	}
-	e.setSwaggerOperation(relativePath, method, operation)
+	operation.Tags = e.inferTags(relativePath, group, operation.Tags)
+	e.checkOperationTags(relativePath, method, operation.Tags)
 
 	parameters, err := e.getParamters(operation.Parameters)
 	if err != nil {
-		return &engineError{relativePath, method, err}
+		return nil, nil, &engineError{relativePath, method, err}
 	}
 
 	// check paramter in relativePath
 	if err := checkParametersInPath(relativePath, parameters); err != nil {
-		return &engineError{relativePath, method, err}
+		return nil, nil, &engineError{relativePath, method, err}
 	}
 
-	// set swagger Definitions
-	definitions, err := doc.ToSwaggerDefinitions()
+	// get swagger Definitions
+	definitions, err := e.getSwaggerDefinitions(doc)
 	if err != nil {
-		return &engineError{relativePath, method, err}
+		return nil, nil, &engineError{relativePath, method, err}
+	}
+	if err := e.checkSwaggerDefinitions(definitions); err != nil {
+		return nil, nil, &engineError{relativePath, method, err}
 	}
+	return operation, definitions, nil
+}
+
+// setSwaggerPath document the operation checked by Engine.getSwaggerPath
+func (e *Engine) setSwaggerPath(swaggerPath string, method string, doc APIDoc, operation *swagger.Operation, definitions map[string]*swagger.Schema) {
+	e.claimOperationID(swaggerPath, method, operation)
+	e.setSwaggerOperation(swaggerPath, method, operation)
 	e.setSwaggerDefinitions(definitions)
-	return nil
+	e.apis = append(e.apis, &registeredAPI{method, swaggerPath, doc, operation})
 }
 
 func (e *Engine) getParamters(srcParameters []*swagger.Parameter) ([]*swagger.Parameter, error) {
@@ -197,6 +335,26 @@ func (e *Engine) getParamters(srcParameters []*swagger.Parameter) ([]*swagger.Pa
 	return parameters, nil
 }
 
+// getSwaggerDefinitions get the definitions of the APIDoc, the doc comments of the models are got from the source
+// if Config.DocCommentsFromSource is true (only for APIDocCommon)
+func (e *Engine) getSwaggerDefinitions(doc APIDoc) (map[string]*swagger.Schema, error) {
+	if apiDoc, ok := doc.(*APIDocCommon); ok && e.Conf.DocCommentsFromSource {
+		return apiDoc.toSwaggerDefinitions(&StructDocCreater{commentsFromSource: true})
+	}
+	return doc.ToSwaggerDefinitions()
+}
+
+// checkSwaggerDefinitions the definitions with the same name in the engine must be the same,
+// the different models with the same name (like: Page[model.Book] and Page[store.Book]) can't be in one document
+func (e *Engine) checkSwaggerDefinitions(definitions map[string]*swagger.Schema) error {
+	for name, schema := range definitions {
+		if old, ok := e.Swagger.Definitions[name]; ok && !isSameDocumentValue(old, schema) {
+			return errors.New("the definition " + name + " conflicts with a different model of the same name")
+		}
+	}
+	return nil
+}
+
 func (e *Engine) setSwaggerDefinitions(definitions map[string]*swagger.Schema) {
 	for k, v := range definitions {
 		// init swagger Definitions
@@ -245,84 +403,46 @@ func (e engineError) Error() string {
 }
 
 func (e *Engine) handle(method string, relativePath string, doc APIDoc, handlers []HandlerFunc) error {
+	return e.handleInGroup(method, relativePath, "", doc, handlers)
+}
+
+// handleInGroup handle the API in the RouterGroup with the name group ("" if the API is not in a group)
+func (e *Engine) handleInGroup(method string, relativePath string, group string, doc APIDoc, handlers []HandlerFunc) error {
 	if len(handlers) == 0 || handlers[0] == nil {
 		return &engineError{relativePath, GET, errors.New("miss HandlerFunc")}
 	}
 
-	// set method
-	if doc != nil {
-		doc.SetMethod(method)
-	}
-
-	// set swagger paths
-	if doc != nil {
-		if err := e.setSwaggerPath(relativePath, method, doc); err != nil {
-			return err
-		}
-	}
-
-	// to gin style path
-	path, err := swaggerPathToGinPath(relativePath)
-	if err != nil {
-		return err
-	}
-
-	// init cors-origin
-	if e.Conf.AllowOrigin {
-		if err := e.setAllownOrigin(method, path, doc); err != nil {
-			return err
+	// document the route, after it is registered on gin
+	handlerName := nameOfFunction(handlers[0])
+	_, err := e.newRoute(method, relativePath, group, doc, handlerName, func(route *Route) error {
+		// log
+		if gin.IsDebugging() {
+			log.Printf("[ehttp-dbg] %-6s %-25s --> %s \n", method, e.getBasePath()+route.ginPath, handlerName)
 		}
-	}
-
-	// new handle function
-	handler, err := e.newHandleFunc(method, path, doc, handlers)
+		// router
+		return e.router(method, route.ginPath, newHandleFunc(route, handlers))
+	})
 	if err != nil {
 		return err
 	}
-
-	// log
-	if gin.IsDebugging() {
-		handlerName := nameOfFunction(handlers[0])
-		log.Printf("[ehttp-dbg] %-6s %-25s --> %s \n", method, e.getBasePath()+path, handlerName)
-	}
-
-	// router
-	return e.router(method, path, handler)
+	// the route with the handlers of the user, it can be mounted on another engine (see Engine.Mount)
+	e.routes = append(e.routes, &handledRoute{method, relativePath, group, doc, handlers})
+	return nil
 }
 
-func (e *Engine) newHandleFunc(method string, path string, doc APIDoc, handlers []HandlerFunc) (func(*gin.Context), error) {
-	var handler func(*gin.Context)
-	if doc != nil {
-		// get rules of paramters
-		parameters := doc.GetParameters()
-		rules, err := getParameterRules(parameters)
-		if err != nil {
-			return nil, err
-		}
-		// cors-origin
-		accessControlAllow := e.getAccessControlAllow(method, path)
-		// hander func
-		handler = func(c *gin.Context) {
-			for _, rule := range rules {
-				if err := rule.Check(c); err != nil {
-					handlers[0](c, err)
-					return
-				}
-			}
-			if accessControlAllow != nil {
-				if err := accessControlAllow.cors(c); err != nil {
-					handlers[0](c, err)
-					return
-				}
+// newHandleFunc the gin handler of the route, the request is served by Route.Serve
+// (the mock mode and the check of the responses), the handler writes to the recorder of the responses if any.
+func newHandleFunc(route *Route, handlers []HandlerFunc) func(*gin.Context) {
+	return func(c *gin.Context) {
+		writer := c.Writer
+		route.Serve(ginContext{c}, writer, func(w http.ResponseWriter, err error) {
+			if recorder, ok := w.(*responseRecorder); ok {
+				c.Writer = &ginResponseRecorder{ResponseWriter: writer, recorder: recorder}
+				defer func() { c.Writer = writer }()
 			}
-			handlers[0](c, nil)
-		}
-	} else {
-		handler = func(c *gin.Context) {
-			handlers[0](c, nil)
-		}
+			handlers[0](c, err)
+		})
 	}
-	return handler, nil
 }
 
 func (e *Engine) getAccessControlAllow(method string, path string) *accessControlAllow {
@@ -382,19 +502,23 @@ func (e *Engine) setAllownOrigin(method, path string, doc APIDoc) error {
 }
 
 func (e *Engine) getOriginByMethodAndPath(method string, path string) (*corsInfo, error) {
+	return getOriginByMethod(e.corsInfos(path), method)
+}
+
+func getOriginByMethod(infos *corsInfos, method string) (*corsInfo, error) {
 	switch method {
 	case GET:
-		return e.corsInfos(path).GET(), nil
+		return infos.GET(), nil
 	case POST:
-		return e.corsInfos(path).POST(), nil
+		return infos.POST(), nil
 	case PUT:
-		return e.corsInfos(path).PUT(), nil
+		return infos.PUT(), nil
 	case PATCH:
-		return e.corsInfos(path).PATCH(), nil
+		return infos.PATCH(), nil
 	case DELETE:
-		return e.corsInfos(path).DELETE(), nil
+		return infos.DELETE(), nil
 	case OPTIONS:
-		return e.corsInfos(path).OPTIONS(), nil
+		return infos.OPTIONS(), nil
 	default:
 		return nil, errors.New("method " + method + " is not supported")
 	}
@@ -424,7 +548,7 @@ func (e *Engine) allowOrigin() {
 		accessControlAllow := cors.OPTIONS().toAccessControlAllow()
 		e.GinEngine().OPTIONS(e.getBasePath()+path, func(c *gin.Context) {
 			if accessControlAllow != nil {
-				if err := accessControlAllow.cors(c); err != nil {
+				if err := accessControlAllow.cors(ginContext{c}); err != nil {
 					c.String(400, err.Error())
 					return
 				}
@@ -439,7 +563,6 @@ func (e *Engine) getAPIDocumentURL() string {
 	if docURL == "" {
 		docURL = DefalutAPIDocumentUrl
 	}
-	docURL = e.getBasePath() + docURL
 	return docURL
 }
 
@@ -448,53 +571,21 @@ func (e *Engine) getYAMLAPIDocumentURL() string {
 	if docURL == "" {
 		docURL = DefalutYAMLAPIDocumentUrl
 	}
-	docURL = e.getBasePath() + docURL
 	return docURL
 }
 
+// openAPIDocumentURL serve the documents on gin (see Engine.DocumentHandlers)
 func (e *Engine) openAPIDocumentURL() {
-	allowOrigin := e.Conf.AllowOrigin
-	e.GinEngine().GET(e.getAPIDocumentURL(), func(c *gin.Context) {
-		if allowOrigin {
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin,Access-Control-Allow-Method,Content-Type")
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		}
-		swagger := *e.Swagger
-		if e.Conf.DomainName == "" {
-			swagger.Host = c.Request.Host
-		} else {
-			swagger.Host = e.Conf.DomainName
-		}
-		c.IndentedJSON(200, &swagger)
-	})
-	e.GinEngine().GET(e.getYAMLAPIDocumentURL(), func(c *gin.Context) {
-		if allowOrigin {
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin,Access-Control-Allow-Method,Content-Type")
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		}
-		swagger := *e.Swagger
-		if e.Conf.DomainName == "" {
-			swagger.Host = c.Request.Host
-		} else {
-			swagger.Host = e.Conf.DomainName
+	for url, handler := range e.DocumentHandlers() {
+		e.GinEngine().GET(e.getBasePath()+url, gin.WrapF(handler))
+	}
+	if e.Conf.AllowOrigin {
+		for _, url := range []string{e.getAPIDocumentURL(), e.getYAMLAPIDocumentURL()} {
+			e.GinEngine().OPTIONS(e.getBasePath()+url, func(c *gin.Context) {
+				setDocumentAccessControlAllow(c.Writer.Header())
+				c.JSON(200, gin.H{})
+			})
 		}
-		c.YAML(200, &swagger)
-	})
-	if allowOrigin {
-		e.GinEngine().OPTIONS(e.getAPIDocumentURL(), func(c *gin.Context) {
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin,Access-Control-Allow-Method,Content-Type")
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			c.JSON(200, gin.H{})
-		})
-		e.GinEngine().OPTIONS(e.getYAMLAPIDocumentURL(), func(c *gin.Context) {
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET,OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Access-Control-Allow-Origin,Access-Control-Allow-Method,Content-Type")
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			c.JSON(200, gin.H{})
-		})
 	}
 }
 
@@ -0,0 +1,258 @@
+// Package example synthesize the example values of the parameters and the payloads of a swagger document.
+package example
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const swaggerDefinitions = "#/definitions/"
+
+// Direction the readOnly properties are not in the request examples, and the writeOnly properties are not in the response examples
+type Direction int
+
+const (
+	InRequest Direction = iota
+	InResponse
+)
+
+// Parameter the example value of a parameter (not in body): the example, the default value, the first value of the enum,
+// or the placeholder of the type
+func Parameter(parameter *swagger.Parameter) interface{} {
+	if parameter.Example != nil {
+		return parameter.Example
+	}
+	if parameter.Default != nil {
+		return parameter.Default
+	}
+	if len(parameter.Enum) > 0 {
+		return parameter.Enum[0]
+	}
+	if parameter.Type == "array" {
+		if parameter.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{getTypeExample(parameter.Items.Type, parameter.Items.Format, limits{})}
+	}
+	return getTypeExample(parameter.Type, parameter.Format, limits{parameter.Minimum, parameter.Maximum, parameter.MinLength, parameter.MaxLength})
+}
+
+// RequestBody the example of the body parameter: the JSON example of the document, or synthesized from the schema
+func RequestBody(doc *swagger.Swagger, parameter *swagger.Parameter) interface{} {
+	if example, ok := getJSONExample(parameter.Examples); ok {
+		return example
+	}
+	if parameter.Example != nil {
+		return parameter.Example
+	}
+	return Schema(doc, parameter.Schema, InRequest)
+}
+
+// ResponseBody the example of the response: the JSON example of the document, or synthesized from the schema
+func ResponseBody(doc *swagger.Swagger, response *swagger.Response) interface{} {
+	if example, ok := getJSONExample(response.Examples); ok {
+		return example
+	}
+	return Schema(doc, response.Schema, InResponse)
+}
+
+// Schema synthesize the example from the examples of the properties, or the placeholders of the types.
+// The recursive models are synthesized once.
+func Schema(doc *swagger.Swagger, schema *swagger.Schema, dir Direction) interface{} {
+	g := &generator{doc: doc, dir: dir, visiting: map[string]bool{}}
+	return g.getSchemaExample(schema)
+}
+
+// getJSONExample the example of the first JSON MIME type
+func getJSONExample(examples map[string]interface{}) (interface{}, bool) {
+	mimeTypes := []string{}
+	for mimeType := range examples {
+		if strings.Contains(mimeType, "json") {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+	if len(mimeTypes) == 0 {
+		return nil, false
+	}
+	sort.Strings(mimeTypes)
+	return examples[mimeTypes[0]], true
+}
+
+type generator struct {
+	doc      *swagger.Swagger
+	dir      Direction
+	visiting map[string]bool
+}
+
+func (g *generator) isIgnored(propertie *swagger.Propertie) bool {
+	return propertie == nil || (g.dir == InRequest && propertie.ReadOnly) || (g.dir == InResponse && propertie.WriteOnly)
+}
+
+func (g *generator) getSchemaExample(schema *swagger.Schema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		if g.visiting[schema.Ref] {
+			return nil
+		}
+		g.visiting[schema.Ref] = true
+		defer delete(g.visiting, schema.Ref)
+		return g.getSchemaExample(g.doc.Definitions[strings.TrimPrefix(schema.Ref, swaggerDefinitions)])
+	}
+	if len(schema.OneOf) > 0 {
+		return g.getSchemaExample(schema.OneOf[0])
+	}
+	if len(schema.AnyOf) > 0 {
+		return g.getSchemaExample(schema.AnyOf[0])
+	}
+	if schema.Type == "array" {
+		return toArrayExample(g.getSchemaExample(schema.Items))
+	}
+	if len(schema.Properties) == 0 && len(schema.AllOf) == 0 {
+		if len(schema.Enum) > 0 {
+			return schema.Enum[0]
+		}
+		return getTypeExample(schema.Type, schema.Format, limits{})
+	}
+	example := map[string]interface{}{}
+	for _, item := range schema.AllOf {
+		if values, ok := g.getSchemaExample(item).(map[string]interface{}); ok {
+			for name, value := range values {
+				example[name] = value
+			}
+		}
+	}
+	for name, propertie := range schema.Properties {
+		if g.isIgnored(propertie) {
+			continue
+		}
+		if value := g.getPropertieExample(propertie); value != nil {
+			example[name] = value
+		}
+	}
+	return example
+}
+
+func (g *generator) getPropertieExample(propertie *swagger.Propertie) interface{} {
+	if propertie.Example != nil {
+		return propertie.Example
+	}
+	if propertie.Default != nil {
+		return propertie.Default
+	}
+	if propertie.Ref != "" {
+		return g.getSchemaExample(&swagger.Schema{Ref: propertie.Ref})
+	}
+	if len(propertie.AllOf) == 1 {
+		return g.getPropertieExample(propertie.AllOf[0])
+	}
+	if len(propertie.Enum) > 0 {
+		return propertie.Enum[0]
+	}
+	switch propertie.Type {
+	case "array":
+		if propertie.Items == nil {
+			return []interface{}{}
+		}
+		return toArrayExample(g.getPropertieExample(propertie.Items))
+	case "object":
+		example := map[string]interface{}{}
+		for name, p := range propertie.Properties {
+			if g.isIgnored(p) {
+				continue
+			}
+			if value := g.getPropertieExample(p); value != nil {
+				example[name] = value
+			}
+		}
+		if propertie.AdditionalProperties != nil {
+			example["key"] = g.getPropertieExample(propertie.AdditionalProperties)
+		}
+		return example
+	}
+	return getTypeExample(propertie.Type, propertie.Format, limits{propertie.Minimum, propertie.Maximum, propertie.MinLength, propertie.MaxLength})
+}
+
+// toArrayExample the array of the item, empty if the item is nil (a recursive model)
+func toArrayExample(item interface{}) []interface{} {
+	if item == nil {
+		return []interface{}{}
+	}
+	return []interface{}{item}
+}
+
+// limits the minimum and the maximum of the numbers, and the minLength and the maxLength of the strings
+type limits struct {
+	minimum   *float64
+	maximum   *float64
+	minLength *int64
+	maxLength *int64
+}
+
+// getTypeExample the placeholder of the type, in the limits
+func getTypeExample(typ, format string, l limits) interface{} {
+	switch typ {
+	case "string":
+		return l.getString(getStringExample(format))
+	case "integer":
+		return l.getInteger()
+	case "number":
+		return l.getNumber()
+	case "boolean":
+		return false
+	case "object":
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+func getStringExample(format string) string {
+	switch format {
+	case "date-time":
+		return "2006-01-02T15:04:05Z"
+	case "date":
+		return "2006-01-02"
+	case "byte", "binary":
+		return ""
+	}
+	return "string"
+}
+
+// getString the placeholder padded to the minLength, or cut to the maxLength
+func (l limits) getString(value string) string {
+	if l.minLength != nil && int64(len(value)) < *l.minLength {
+		value += strings.Repeat("s", int(*l.minLength)-len(value))
+	}
+	if l.maxLength != nil && int64(len(value)) > *l.maxLength {
+		value = value[:*l.maxLength]
+	}
+	return value
+}
+
+// getInteger 0, or the nearest integer in the minimum and the maximum
+func (l limits) getInteger() int {
+	value := 0
+	if l.minimum != nil && float64(value) < *l.minimum {
+		value = int(math.Ceil(*l.minimum))
+	}
+	if l.maximum != nil && float64(value) > *l.maximum {
+		value = int(math.Floor(*l.maximum))
+	}
+	return value
+}
+
+// getNumber 0.0, or the nearest number in the minimum and the maximum
+func (l limits) getNumber() float64 {
+	value := 0.0
+	if l.minimum != nil && value < *l.minimum {
+		value = *l.minimum
+	}
+	if l.maximum != nil && value > *l.maximum {
+		value = *l.maximum
+	}
+	return value
+}
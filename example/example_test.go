@@ -0,0 +1,56 @@
+package example
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+func TestParameter_Limits(t *testing.T) {
+	one, four, eight := int64(1), int64(4), int64(8)
+	five, minusOne, half := 5.0, -1.0, 1.5
+	nodes := []struct {
+		parameter *swagger.Parameter
+		value     interface{}
+	}{
+		{&swagger.Parameter{Type: "string"}, "string"},
+		{&swagger.Parameter{Type: "string", MaxLength: &four}, "stri"},
+		{&swagger.Parameter{Type: "string", MinLength: &eight}, "stringss"},
+		{&swagger.Parameter{Type: "string", MinLength: &one, MaxLength: &four, Default: "ab"}, "ab"},
+		{&swagger.Parameter{Type: "string", Format: "date", MaxLength: &eight}, "2006-01-"},
+		{&swagger.Parameter{Type: "integer", Minimum: &five}, 5},
+		{&swagger.Parameter{Type: "integer", Maximum: &minusOne}, -1},
+		{&swagger.Parameter{Type: "integer", Minimum: &half}, 2},
+		{&swagger.Parameter{Type: "number", Minimum: &half}, 1.5},
+		{&swagger.Parameter{Type: "number", Maximum: &minusOne}, -1.0},
+		{&swagger.Parameter{Type: "integer", Minimum: &five, Enum: []interface{}{7}}, 7},
+	}
+	for _, node := range nodes {
+		if value := Parameter(node.parameter); !reflect.DeepEqual(value, node.value) {
+			t.Error("the example should be", node.value, "but", value)
+		}
+	}
+}
+
+func TestSchema_Limits(t *testing.T) {
+	four, ten := int64(4), int64(10)
+	five := 5.0
+	doc := &swagger.Swagger{Definitions: map[string]*swagger.Schema{
+		"Book": &swagger.Schema{Type: "object", Properties: map[string]*swagger.Propertie{
+			"code":  &swagger.Propertie{Type: "string", MaxLength: &four},
+			"title": &swagger.Propertie{Type: "string", MinLength: &ten},
+			"count": &swagger.Propertie{Type: "integer", Minimum: &five},
+			"tags":  &swagger.Propertie{Type: "array", Items: &swagger.Propertie{Type: "string", MaxLength: &four}},
+		}},
+	}}
+	expected := map[string]interface{}{
+		"code":  "stri",
+		"title": "stringssss",
+		"count": 5,
+		"tags":  []interface{}{"stri"},
+	}
+	if value := Schema(doc, &swagger.Schema{Ref: swaggerDefinitions + "Book"}, InResponse); !reflect.DeepEqual(value, expected) {
+		t.Error("the example should be in the limits", value)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/xml"
+
 	"github.com/enjoy-web/ehttp"
 	"github.com/gin-gonic/gin"
 )
@@ -11,8 +13,9 @@ type ErrorMessage struct {
 }
 
 type Book struct {
-	ID    string `xml:"id" desc:"the book id"`
-	Title string `xml:"title" desc:"the book title"`
+	XMLName xml.Name `xml:"book"`
+	ID      string   `xml:"id,attr" desc:"the book id"`
+	Title   string   `xml:"title" desc:"the book title"`
 }
 
 var DocGETBook = &ehttp.APIDocCommon{
@@ -0,0 +1,280 @@
+// Package export export the swagger document to the files of the HTTP clients:
+// a Postman Collection v2.1, a .http file (JetBrains HTTP Client, VS Code REST Client) and a curl script.
+// The example values of the parameters are the examples, the default values or the first values of the enums,
+// and the bodies are synthesized from the request models (see package example).
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/example"
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const (
+	mimeTypeJSON           = "application/json"
+	mimeTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	mimeTypeMultipartForm  = "multipart/form-data"
+)
+
+// folder the requests of a tag
+type folder struct {
+	name        string
+	description string
+	requests    []*request
+}
+
+// request an example request of an operation
+type request struct {
+	name        string
+	description string
+	method      string
+	path        string
+	pathValues  []keyValue
+	query       []keyValue
+	headers     []keyValue
+	contentType string
+	body        string
+	formData    []formField
+}
+
+type keyValue struct {
+	key   string
+	value string
+}
+
+type formField struct {
+	key    string
+	value  string
+	isFile bool
+}
+
+// getBaseURL like "https://api.example.com/v1", "http://localhost" if the document has no host
+func getBaseURL(doc *swagger.Swagger) string {
+	host := doc.Host
+	if host == "" {
+		host = "localhost"
+	}
+	scheme := "http"
+	if len(doc.Schemes) > 0 {
+		scheme = doc.Schemes[0]
+	}
+	return scheme + "://" + host + doc.BasePath
+}
+
+// getFolders the requests grouped by the tags, the declared tags are first, then the other tags sorted by the names,
+// and then "default" (the operations without tags)
+func getFolders(doc *swagger.Swagger) []*folder {
+	folders := []*folder{}
+	foldersByName := map[string]*folder{}
+	getFolder := func(name string) *folder {
+		if f, ok := foldersByName[name]; ok {
+			return f
+		}
+		f := &folder{name: name}
+		foldersByName[name] = f
+		folders = append(folders, f)
+		return f
+	}
+	for _, tag := range doc.Tags {
+		if tag != nil {
+			getFolder(tag.Name).description = tag.Description
+		}
+	}
+	operations := getOperations(doc)
+	undeclared := []string{}
+	for _, o := range operations {
+		for _, tag := range o.operation.Tags {
+			if _, ok := foldersByName[tag]; !ok {
+				undeclared = append(undeclared, tag)
+			}
+		}
+	}
+	sort.Strings(undeclared)
+	for _, tag := range undeclared {
+		getFolder(tag)
+	}
+	for _, o := range operations {
+		tags := o.operation.Tags
+		if len(tags) == 0 {
+			tags = []string{"default"}
+		}
+		for _, tag := range tags {
+			f := getFolder(tag)
+			f.requests = append(f.requests, newRequest(doc, o))
+		}
+	}
+	result := []*folder{}
+	for _, f := range folders {
+		if len(f.requests) > 0 {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+type operationInfo struct {
+	method    string
+	path      string
+	operation *swagger.Operation
+}
+
+// getOperations the operations sorted by the paths and the methods
+func getOperations(doc *swagger.Swagger) []*operationInfo {
+	paths := []string{}
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	operations := []*operationInfo{}
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if item == nil {
+			continue
+		}
+		for _, o := range []*operationInfo{
+			{"GET", path, item.Get},
+			{"POST", path, item.Post},
+			{"PUT", path, item.Put},
+			{"PATCH", path, item.Patch},
+			{"DELETE", path, item.Delete},
+			{"HEAD", path, item.Head},
+			{"OPTIONS", path, item.Options},
+		} {
+			if o.operation != nil {
+				operations = append(operations, o)
+			}
+		}
+	}
+	return operations
+}
+
+var pathParameterRegexp = regexp.MustCompile(`\{([^}]*)\}`)
+
+func newRequest(doc *swagger.Swagger, o *operationInfo) *request {
+	r := &request{
+		name:        o.operation.Summary,
+		description: o.operation.Description,
+		method:      o.method,
+		path:        o.path,
+	}
+	if r.name == "" {
+		r.name = o.method + " " + o.path
+	}
+	pathValues := map[string]string{}
+	hasFile := false
+	for _, parameter := range o.operation.Parameters {
+		if parameter != nil && strings.HasPrefix(parameter.Ref, "#/parameters/") {
+			parameter = doc.Parameters[strings.TrimPrefix(parameter.Ref, "#/parameters/")]
+		}
+		if parameter == nil {
+			continue
+		}
+		switch parameter.In {
+		case "body":
+			r.body = toJSON(example.RequestBody(doc, parameter))
+		case "path":
+			pathValues[parameter.Name] = formatValue(example.Parameter(parameter))
+		case "query":
+			r.query = append(r.query, keyValue{parameter.Name, formatValue(example.Parameter(parameter))})
+		case "header":
+			r.headers = append(r.headers, keyValue{parameter.Name, formatValue(example.Parameter(parameter))})
+		case "formData":
+			isFile := parameter.Type == "file"
+			value := formatValue(example.Parameter(parameter))
+			if isFile {
+				hasFile = true
+				value = parameter.Name
+			}
+			r.formData = append(r.formData, formField{parameter.Name, value, isFile})
+		}
+	}
+	for _, match := range pathParameterRegexp.FindAllStringSubmatch(o.path, -1) {
+		value, ok := pathValues[match[1]]
+		if !ok {
+			value = match[1]
+		}
+		r.pathValues = append(r.pathValues, keyValue{match[1], value})
+	}
+	if produces := getMimeTypes(o.operation.Produces, doc.Produces); len(produces) > 0 {
+		r.headers = append(r.headers, keyValue{"Accept", produces[0]})
+	}
+	switch {
+	case r.body != "":
+		r.contentType = mimeTypeJSON
+		if consumes := getMimeTypes(o.operation.Consumes, doc.Consumes); len(consumes) > 0 {
+			r.contentType = consumes[0]
+		}
+	case hasFile:
+		r.contentType = mimeTypeMultipartForm
+	case len(r.formData) > 0:
+		r.contentType = mimeTypeFormURLEncoded
+	}
+	return r
+}
+
+func getMimeTypes(operationMimeTypes, documentMimeTypes []string) []string {
+	if len(operationMimeTypes) > 0 {
+		return operationMimeTypes
+	}
+	return documentMimeTypes
+}
+
+// getURL the url with the example values, like "{{baseUrl}}/books/123?fields=all"
+func (r *request) getURL(baseURL string) string {
+	path := r.path
+	for _, v := range r.pathValues {
+		path = strings.Replace(path, "{"+v.key+"}", url.PathEscape(v.value), 1)
+	}
+	return baseURL + path + r.getQueryString()
+}
+
+func (r *request) getQueryString() string {
+	if len(r.query) == 0 {
+		return ""
+	}
+	values := []string{}
+	for _, v := range r.query {
+		values = append(values, url.QueryEscape(v.key)+"="+url.QueryEscape(v.value))
+	}
+	return "?" + strings.Join(values, "&")
+}
+
+func (r *request) getFormURLEncoded() string {
+	values := []string{}
+	for _, field := range r.formData {
+		values = append(values, url.QueryEscape(field.key)+"="+url.QueryEscape(field.value))
+	}
+	return strings.Join(values, "&")
+}
+
+// formatValue the value in the url or the header, the values of an array are separated by commas (collectionFormat csv)
+func formatValue(value interface{}) string {
+	if values, ok := value.([]interface{}); ok {
+		s := []string{}
+		for _, v := range values {
+			s = append(s, fmt.Sprint(v))
+		}
+		return strings.Join(s, ",")
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func toJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
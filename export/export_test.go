@@ -0,0 +1,126 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "book store APIS", "version": "v1"},
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": ["https"],
+  "produces": ["application/json"],
+  "tags": [{"name": "books", "description": "the books"}],
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "tags": ["books"],
+        "summary": "Get a book",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string", "x-example": "123"},
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all", "brief"]},
+          {"name": "limit", "in": "query", "type": "integer", "default": 10},
+          {"name": "X-Token", "in": "header", "type": "string"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      },
+      "put": {
+        "tags": ["books"],
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "book", "in": "body", "schema": {"$ref": "#/definitions/Book"}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/covers": {
+      "post": {
+        "summary": "Upload a cover",
+        "parameters": [
+          {"name": "file", "in": "formData", "type": "file"},
+          {"name": "title", "in": "formData", "type": "string", "x-example": "it's a cover"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "readOnly": true},
+        "title": {"type": "string", "example": "Go"},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    }
+  }
+}`
+
+func testParseDocument(t *testing.T) *swagger.Swagger {
+	doc := &swagger.Swagger{}
+	if err := json.Unmarshal([]byte(testDocument), doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestToPostmanCollection(t *testing.T) {
+	collection := ToPostmanCollection(testParseDocument(t))
+	if collection.Info.Name != "book store APIS" || collection.Info.Schema != PostmanSchema || collection.Variable[0].Value != "https://api.example.com/v1" {
+		t.Error("the info and the variable baseUrl should be set", collection.Info, collection.Variable[0])
+	}
+	if len(collection.Item) != 2 || collection.Item[0].Name != "books" || len(collection.Item[0].Item) != 2 || collection.Item[1].Name != "default" {
+		t.Fatal("the requests should be grouped by the tags into folders", collection.Item)
+	}
+	get := collection.Item[0].Item[0]
+	if get.Name != "Get a book" || get.Request.URL.Raw != "{{baseUrl}}/books/:id?fields=all&limit=10" ||
+		get.Request.URL.Variable[0].Value != "123" || strings.Join(get.Request.URL.Path, "/") != "books/:id" {
+		t.Error("the url should have the example values", get.Request.URL)
+	}
+	if len(get.Request.Header) != 2 || get.Request.Header[0].Key != "X-Token" || get.Request.Header[1].Value != "application/json" {
+		t.Error("the headers should be the header parameters and Accept", get.Request.Header)
+	}
+	put := collection.Item[0].Item[1]
+	if put.Name != "PUT /books/{id}" || put.Request.Body == nil || put.Request.Body.Raw != "{\n  \"tags\": [\n    \"string\"\n  ],\n  \"title\": \"Go\"\n}" {
+		t.Error("the body should be synthesized from the model without the readOnly properties", put.Request.Body)
+	}
+	upload := collection.Item[1].Item[0].Request
+	if upload.Body == nil || upload.Body.Mode != "formdata" || upload.Body.FormData[0].Type != "file" || upload.Body.FormData[1].Value != "it's a cover" {
+		t.Error("the formData parameters should be the formdata body", upload.Body)
+	}
+	if _, err := json.Marshal(collection); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestToHTTPFile(t *testing.T) {
+	file := ToHTTPFile(testParseDocument(t))
+	for _, s := range []string{
+		"@baseUrl = https://api.example.com/v1\n",
+		"\n# books\n\n### Get a book\nGET {{baseUrl}}/books/123?fields=all&limit=10\nX-Token: string\nAccept: application/json\n",
+		"### PUT /books/{id}\nPUT {{baseUrl}}/books/string\nAccept: application/json\nContent-Type: application/json\n\n{\n",
+		"Content-Disposition: form-data; name=\"file\"; filename=\"file\"\n\n< ./file\n",
+	} {
+		if !strings.Contains(file, s) {
+			t.Error("the .http file should contain " + s)
+		}
+	}
+}
+
+func TestToCurlScript(t *testing.T) {
+	script := ToCurlScript(testParseDocument(t))
+	for _, s := range []string{
+		"BASE_URL=${BASE_URL:-'https://api.example.com/v1'}\n",
+		"curl -X GET \"$BASE_URL\"'/books/123?fields=all&limit=10' \\\n  -H 'X-Token: string' \\\n  -H 'Accept: application/json'\n",
+		"  -F 'file=@file' \\\n  -F 'title=it'\\''s a cover'\n",
+	} {
+		if !strings.Contains(script, s) {
+			t.Error("the curl script should contain " + s)
+		}
+	}
+}
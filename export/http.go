@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// multipartBoundary the boundary of the multipart/form-data bodies in the .http file
+const multipartBoundary = "ehttp-boundary"
+
+// ToHTTPFile export the document to a .http file (JetBrains HTTP Client, VS Code REST Client).
+// The base url is the variable baseUrl, the requests are grouped by the tags (the comments "# tag"),
+// and the files of the multipart/form-data bodies are read from "./<parameter name>".
+func ToHTTPFile(doc *swagger.Swagger) string {
+	b := &bytes.Buffer{}
+	b.WriteString("@baseUrl = " + getBaseURL(doc) + "\n")
+	for _, f := range getFolders(doc) {
+		b.WriteString("\n# " + f.name + "\n")
+		for _, r := range f.requests {
+			b.WriteString("\n### " + r.name + "\n")
+			b.WriteString(r.method + " " + r.getURL("{{baseUrl}}") + "\n")
+			for _, header := range r.headers {
+				b.WriteString(header.key + ": " + header.value + "\n")
+			}
+			switch {
+			case r.body != "":
+				b.WriteString("Content-Type: " + r.contentType + "\n\n" + r.body + "\n")
+			case r.contentType == mimeTypeMultipartForm:
+				b.WriteString("Content-Type: " + mimeTypeMultipartForm + "; boundary=" + multipartBoundary + "\n\n")
+				for _, field := range r.formData {
+					b.WriteString("--" + multipartBoundary + "\n")
+					if field.isFile {
+						b.WriteString(`Content-Disposition: form-data; name="` + field.key + `"; filename="` + field.value + `"` + "\n\n")
+						b.WriteString("< ./" + field.value + "\n")
+					} else {
+						b.WriteString(`Content-Disposition: form-data; name="` + field.key + `"` + "\n\n" + field.value + "\n")
+					}
+				}
+				b.WriteString("--" + multipartBoundary + "--\n")
+			case r.contentType == mimeTypeFormURLEncoded:
+				b.WriteString("Content-Type: " + mimeTypeFormURLEncoded + "\n\n" + r.getFormURLEncoded() + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// ToCurlScript export the document to a shell script of curl commands, the base url is the variable BASE_URL
+func ToCurlScript(doc *swagger.Swagger) string {
+	b := &bytes.Buffer{}
+	b.WriteString("#!/bin/sh\n\nBASE_URL=${BASE_URL:-" + shellQuote(getBaseURL(doc)) + "}\n")
+	for _, f := range getFolders(doc) {
+		b.WriteString("\n# " + f.name + "\n")
+		for _, r := range f.requests {
+			b.WriteString("\n# " + r.name + "\n")
+			// the base url is out of the quotes, so the variable is expanded
+			b.WriteString("curl -X " + r.method + ` "$BASE_URL"` + shellQuote(r.getURL("")))
+			for _, header := range r.headers {
+				b.WriteString(" \\\n  -H " + shellQuote(header.key+": "+header.value))
+			}
+			switch {
+			case r.body != "":
+				b.WriteString(" \\\n  -H " + shellQuote("Content-Type: "+r.contentType))
+				b.WriteString(" \\\n  --data-raw " + shellQuote(r.body))
+			case r.contentType == mimeTypeMultipartForm:
+				for _, field := range r.formData {
+					if field.isFile {
+						b.WriteString(" \\\n  -F " + shellQuote(field.key+"=@"+field.value))
+					} else {
+						b.WriteString(" \\\n  -F " + shellQuote(field.key+"="+field.value))
+					}
+				}
+			case r.contentType == mimeTypeFormURLEncoded:
+				for _, field := range r.formData {
+					b.WriteString(" \\\n  --data-urlencode " + shellQuote(field.key+"="+field.value))
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// shellQuote quote the string with single quotes for the shell
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// PostmanSchema the schema of the Postman Collection v2.1
+const PostmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection https://schema.getpostman.com/json/collection/v2.1.0/docs/index.html
+// The base url of the document is the collection variable baseUrl.
+type PostmanCollection struct {
+	Info     *PostmanInfo       `json:"info"`
+	Item     []*PostmanItem     `json:"item"`
+	Variable []*PostmanVariable `json:"variable,omitempty"`
+}
+
+// PostmanInfo the information of the collection
+type PostmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// PostmanItem a folder (with the items) or a request
+type PostmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []*PostmanItem  `json:"item,omitempty"`
+	Request     *PostmanRequest `json:"request,omitempty"`
+}
+
+// PostmanRequest a request of the collection
+type PostmanRequest struct {
+	Method      string              `json:"method"`
+	Header      []*PostmanVariable  `json:"header"`
+	URL         *PostmanURL         `json:"url"`
+	Body        *PostmanRequestBody `json:"body,omitempty"`
+	Description string              `json:"description,omitempty"`
+}
+
+// PostmanURL the url of the request, the path parameters are like ":id"
+type PostmanURL struct {
+	Raw      string             `json:"raw"`
+	Host     []string           `json:"host"`
+	Path     []string           `json:"path"`
+	Query    []*PostmanVariable `json:"query,omitempty"`
+	Variable []*PostmanVariable `json:"variable,omitempty"`
+}
+
+// PostmanVariable a key-value pair (variable, header or query parameter)
+type PostmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+	Src   string `json:"src,omitempty"`
+}
+
+// PostmanRequestBody the body of the request, the mode is raw, urlencoded or formdata
+type PostmanRequestBody struct {
+	Mode       string                 `json:"mode"`
+	Raw        string                 `json:"raw,omitempty"`
+	URLEncoded []*PostmanVariable     `json:"urlencoded,omitempty"`
+	FormData   []*PostmanVariable     `json:"formdata,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+}
+
+// ToPostmanCollection export the document to a Postman Collection v2.1, the requests are grouped by the tags into folders
+func ToPostmanCollection(doc *swagger.Swagger) *PostmanCollection {
+	collection := &PostmanCollection{
+		Info:     &PostmanInfo{Schema: PostmanSchema},
+		Item:     []*PostmanItem{},
+		Variable: []*PostmanVariable{&PostmanVariable{Key: "baseUrl", Value: getBaseURL(doc)}},
+	}
+	if doc.Info != nil {
+		collection.Info.Name, collection.Info.Description = doc.Info.Title, doc.Info.Description
+	}
+	for _, f := range getFolders(doc) {
+		item := &PostmanItem{Name: f.name, Description: f.description}
+		for _, r := range f.requests {
+			item.Item = append(item.Item, &PostmanItem{Name: r.name, Request: r.toPostmanRequest()})
+		}
+		collection.Item = append(collection.Item, item)
+	}
+	return collection
+}
+
+func (r *request) toPostmanRequest() *PostmanRequest {
+	postmanRequest := &PostmanRequest{
+		Method:      r.method,
+		Header:      []*PostmanVariable{},
+		Description: r.description,
+	}
+	for _, header := range r.headers {
+		postmanRequest.Header = append(postmanRequest.Header, &PostmanVariable{Key: header.key, Value: header.value})
+	}
+	if r.contentType != "" && r.contentType != mimeTypeMultipartForm {
+		postmanRequest.Header = append(postmanRequest.Header, &PostmanVariable{Key: "Content-Type", Value: r.contentType})
+	}
+	// the path parameters of Postman are like ":id"
+	path := pathParameterRegexp.ReplaceAllString(r.path, ":$1")
+	postmanURL := &PostmanURL{
+		Raw:  "{{baseUrl}}" + path + r.getQueryString(),
+		Host: []string{"{{baseUrl}}"},
+		Path: strings.Split(strings.TrimPrefix(path, "/"), "/"),
+	}
+	for _, v := range r.query {
+		postmanURL.Query = append(postmanURL.Query, &PostmanVariable{Key: v.key, Value: v.value})
+	}
+	for _, v := range r.pathValues {
+		postmanURL.Variable = append(postmanURL.Variable, &PostmanVariable{Key: v.key, Value: v.value})
+	}
+	postmanRequest.URL = postmanURL
+	switch {
+	case r.body != "":
+		postmanRequest.Body = &PostmanRequestBody{Mode: "raw", Raw: r.body}
+		if strings.Contains(r.contentType, "json") {
+			postmanRequest.Body.Options = map[string]interface{}{"raw": map[string]string{"language": "json"}}
+		}
+	case r.contentType == mimeTypeMultipartForm:
+		postmanRequest.Body = &PostmanRequestBody{Mode: "formdata"}
+		for _, field := range r.formData {
+			if field.isFile {
+				postmanRequest.Body.FormData = append(postmanRequest.Body.FormData, &PostmanVariable{Key: field.key, Type: "file"})
+			} else {
+				postmanRequest.Body.FormData = append(postmanRequest.Body.FormData, &PostmanVariable{Key: field.key, Value: field.value, Type: "text"})
+			}
+		}
+	case r.contentType == mimeTypeFormURLEncoded:
+		postmanRequest.Body = &PostmanRequestBody{Mode: "urlencoded"}
+		for _, field := range r.formData {
+			postmanRequest.Body.URLEncoded = append(postmanRequest.Body.URLEncoded, &PostmanVariable{Key: field.key, Value: field.value})
+		}
+	}
+	return postmanRequest
+}
@@ -0,0 +1,591 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const parametersPrefix = "#/parameters/"
+
+// operation a generated operation, the APIDoc is Doc<name> and the handler is Handle<name>
+type operation struct {
+	method  string
+	path    string
+	ginPath string
+	name    string
+	source  *swagger.Operation
+	doc     string // the literal of the APIDocCommon
+}
+
+// routerMethods the methods of the ehttp.Engine with the APIDocs, in the order of the registration
+var routerMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+func getItemOperations(item *swagger.Item) map[string]*swagger.Operation {
+	return map[string]*swagger.Operation{
+		http.MethodGet:     item.Get,
+		http.MethodPost:    item.Post,
+		http.MethodPut:     item.Put,
+		http.MethodPatch:   item.Patch,
+		http.MethodDelete:  item.Delete,
+		http.MethodHead:    item.Head,
+		http.MethodOptions: item.Options,
+	}
+}
+
+// collectOperations declare the APIDocs of the operations, the paths are sorted
+func (g *generator) collectOperations() {
+	paths := []string{}
+	for path := range g.doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := g.doc.Paths[path]
+		if item == nil {
+			continue
+		}
+		if item.Ref != "" {
+			g.warn(path, "the path item reference %s is not generated", item.Ref)
+			continue
+		}
+		operations := getItemOperations(item)
+		for _, method := range []string{http.MethodHead, http.MethodOptions} {
+			if operations[method] != nil {
+				g.warn(method+" "+path, "the operation is not generated, the APIDocs of ehttp are only registered by GET, POST, PUT, PATCH and DELETE")
+			}
+		}
+		for _, method := range routerMethods {
+			if operations[method] == nil {
+				continue
+			}
+			location := method + " " + path
+			ginPath, ok := toGinPath(path)
+			if !ok {
+				g.warn(location, "the operation is not generated, a path parameter must be a whole path segment (like: /books/{id})")
+				continue
+			}
+			op := &operation{method: method, path: path, ginPath: ginPath, source: operations[method]}
+			op.name = g.newOperationName(method, path, op.source.OperationID)
+			op.doc = g.getAPIDoc(location, op)
+			g.operations = append(g.operations, op)
+		}
+	}
+}
+
+// toGinPath like: /books/{id} -> /books/:id
+func toGinPath(path string) (string, bool) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && strings.Count(segment, "{") == 1 {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		} else if strings.ContainsAny(segment, "{}:*") {
+			return "", false
+		}
+	}
+	return strings.Join(segments, "/"), true
+}
+
+// newOperationName the name of the operation from the operationId, or the method and the path (like: GET /books/{id} -> GetBooksID)
+func (g *generator) newOperationName(method, path, operationID string) string {
+	name := operationID
+	if name == "" {
+		name = strings.ToLower(method) + " " + path
+	}
+	name = toGoName(name)
+	unique := name
+	for i := 2; g.goNames["Doc"+unique] || g.goNames["Handle"+unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.goNames["Doc"+unique] = true
+	g.goNames["Handle"+unique] = true
+	return unique
+}
+
+// getAPIDoc the literal of the APIDocCommon of the operation
+func (g *generator) getAPIDoc(location string, op *operation) string {
+	source := op.source
+	b := &bytes.Buffer{}
+	b.WriteString("&ehttp.APIDocCommon{\n")
+	if len(source.Tags) > 0 {
+		fmt.Fprintf(b, "Tags: %s,\n", stringsLiteral(source.Tags))
+	}
+	if source.Summary != "" {
+		fmt.Fprintf(b, "Summary: %s,\n", strconv.Quote(source.Summary))
+	}
+	if source.Description != "" {
+		fmt.Fprintf(b, "Description: %s,\n", strconv.Quote(source.Description))
+	}
+	// the MIME types of the document are the defaults of the operations
+	produces, consumes := source.Produces, source.Consumes
+	if produces == nil {
+		produces = g.doc.Produces
+	}
+	if consumes == nil {
+		consumes = g.doc.Consumes
+	}
+	if len(produces) > 0 {
+		fmt.Fprintf(b, "Produces: %s,\n", stringsLiteral(produces))
+	}
+	if len(consumes) > 0 {
+		fmt.Fprintf(b, "Consumes: %s,\n", stringsLiteral(consumes))
+	}
+	g.writeParameters(b, location, op)
+	g.writeResponses(b, location, op)
+	if source.OperationID != "" {
+		fmt.Fprintf(b, "OperationID: %s,\n", strconv.Quote(source.OperationID))
+	}
+	if source.Deprecated {
+		b.WriteString("Deprecated: true,\n")
+	}
+	if source.Sunset != "" {
+		sunset, err := http.ParseTime(source.Sunset)
+		if err != nil {
+			g.warn(location, "the x-sunset %s is not generated, it is not an HTTP-date", source.Sunset)
+		} else {
+			sunset = sunset.UTC()
+			fmt.Fprintf(b, "Sunset: time.Date(%d, time.%s, %d, %d, %d, %d, 0, time.UTC),\n",
+				sunset.Year(), sunset.Month(), sunset.Day(), sunset.Hour(), sunset.Minute(), sunset.Second())
+		}
+	}
+	if source.ExternalDocs != nil {
+		fmt.Fprintf(b, "ExternalDocs: %s,\n", externalDocsLiteral(source.ExternalDocs))
+	}
+	if len(source.Schemes) > 0 {
+		fmt.Fprintf(b, "Schemes: %s,\n", g.schemesLiteral(location, source.Schemes))
+	}
+	if len(source.Security) > 0 {
+		g.warn(location, "the security requirements are not generated")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// writeParameters write the GlobalParameterNames, the Parameters and the Request of the APIDoc
+func (g *generator) writeParameters(b *bytes.Buffer, location string, op *operation) {
+	globalNames := []string{}
+	names := []string{}
+	parameters := map[string][]string{} // name -> the fields of the ehttp.Parameter
+	request := ""
+	for _, p := range op.source.Parameters {
+		if p == nil {
+			continue
+		}
+		if p.Ref != "" {
+			name, ok := g.getGlobalParameterName(p.Ref)
+			if !ok {
+				g.warn(location, "the parameter %s is not generated, it is not a global parameter", p.Ref)
+				continue
+			}
+			globalNames = append(globalNames, name)
+			continue
+		}
+		parameterLocation := location + " parameter " + p.Name
+		if p.In == "body" {
+			request = g.getRequest(parameterLocation, op.name, p)
+			continue
+		}
+		field, ok := parameterFields[p.In]
+		if !ok {
+			g.warn(parameterLocation, "the parameter in %s is not generated", p.In)
+			continue
+		}
+		if _, ok := parameters[p.Name]; !ok {
+			names = append(names, p.Name)
+		}
+		parameters[p.Name] = append(parameters[p.Name], field+": &"+g.valueInfoLiteral(parameterLocation, p))
+	}
+	if len(globalNames) > 0 {
+		fmt.Fprintf(b, "GlobalParameterNames: %s,\n", stringsLiteral(globalNames))
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		b.WriteString("Parameters: map[string]ehttp.Parameter{\n")
+		for _, name := range names {
+			fmt.Fprintf(b, "%s: ehttp.Parameter{%s},\n", strconv.Quote(name), strings.Join(parameters[name], ", "))
+		}
+		b.WriteString("},\n")
+	}
+	if request != "" {
+		fmt.Fprintf(b, "Request: %s,\n", request)
+	}
+}
+
+// parameterFields the fields of ehttp.Parameter by the locations
+var parameterFields = map[string]string{
+	"path":     "InPath",
+	"header":   "InHeader",
+	"query":    "InQuery",
+	"formData": "InFormData",
+}
+
+// getRequest the literal of the ehttp.Request of the body parameter, "" if the model can't be declared
+func (g *generator) getRequest(location, operationName string, p *swagger.Parameter) string {
+	if p.Schema == nil {
+		g.warn(location, "the body is not generated, it has no schema")
+		return ""
+	}
+	model := g.getModelType(location, operationName+"Request", p.Schema)
+	if model == "" {
+		return ""
+	}
+	if !p.Required {
+		g.warn(location, "the body is required in the generated document")
+	}
+	fields := []string{}
+	if p.Description != "" {
+		fields = append(fields, "Description: "+strconv.Quote(p.Description))
+	}
+	fields = append(fields, "Model: &"+model+"{}")
+	if example, ok := getExample(p.Examples); ok {
+		if literal, err := jsonLiteral(example); err == nil {
+			fields = append(fields, "Example: "+literal)
+		}
+	}
+	return "&ehttp.Request{\n" + strings.Join(fields, ",\n") + ",\n}"
+}
+
+// writeResponses write the Responses of the APIDoc, the default response is -1
+func (g *generator) writeResponses(b *bytes.Buffer, location string, op *operation) {
+	if len(op.source.Responses) == 0 {
+		return
+	}
+	codes := []string{}
+	for code := range op.source.Responses {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i] == "default" || codes[j] == "default" {
+			return codes[j] == "default" && codes[i] != "default"
+		}
+		return codes[i] < codes[j]
+	})
+	b.WriteString("Responses: map[int]ehttp.Response{\n")
+	for _, code := range codes {
+		response := op.source.Responses[code]
+		responseLocation := location + " response " + code
+		statusCode, err := strconv.Atoi(code)
+		if code == "default" {
+			statusCode, err = -1, nil
+		}
+		if err != nil || response == nil {
+			g.warn(responseLocation, "the response is not generated, the status code is invalid")
+			continue
+		}
+		if response.Ref != "" {
+			g.warn(responseLocation, "the response reference %s is not generated", response.Ref)
+			continue
+		}
+		fields := []string{"Description: " + strconv.Quote(response.Description)}
+		model := ""
+		if response.Schema != nil {
+			suffix := code
+			if code == "default" {
+				suffix = "Default"
+			}
+			model = g.getModelType(responseLocation, op.name+"Response"+suffix, response.Schema)
+		}
+		if model != "" {
+			fields = append(fields, "Model: &"+model+"{}")
+		}
+		if len(response.Headers) > 0 {
+			fields = append(fields, "Headers: "+g.headersLiteral(responseLocation, response.Headers))
+		}
+		if example, ok := getExample(response.Examples); ok && model != "" {
+			if literal, err := jsonLiteral(example); err == nil {
+				fields = append(fields, "Example: "+literal)
+			}
+		}
+		fmt.Fprintf(b, "%d: ehttp.Response{\n%s,\n},\n", statusCode, strings.Join(fields, ",\n"))
+	}
+	b.WriteString("},\n")
+}
+
+func (g *generator) headersLiteral(location string, headers map[string]*swagger.Header) string {
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b := &bytes.Buffer{}
+	b.WriteString("map[string]ehttp.ValueInfo{\n")
+	for _, name := range names {
+		h := headers[name]
+		if h == nil {
+			continue
+		}
+		p := &swagger.Parameter{
+			Description: h.Description,
+			Type:        h.Type,
+			Format:      h.Format,
+			Enum:        h.Enum,
+			Default:     h.Default,
+			Minimum:     h.Minimum,
+			Maximum:     h.Maximum,
+			MinLength:   h.MinLength,
+			MaxLength:   h.MaxLength,
+			Example:     h.Example,
+		}
+		fmt.Fprintf(b, "%s: %s,\n", strconv.Quote(name), g.valueInfoLiteral(location+" header "+name, p))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// valueInfoLiteral the literal of the ehttp.ValueInfo of the parameter, the fields are in the order of the declaration
+func (g *generator) valueInfoLiteral(location string, p *swagger.Parameter) string {
+	typ, warning := goPrimitiveType(p.Type, p.Format)
+	switch {
+	case p.Type == "array":
+		typ, warning = "string", "the array is declared as a string"
+	case typ == "":
+		typ, warning = "string", warning+", it is declared as a string"
+	}
+	if warning != "" {
+		g.warn(location, "%s", warning)
+	}
+	fields := []string{"Type: " + strconv.Quote(typ)}
+	hasEnum := false
+	if len(p.Enum) > 0 {
+		if enum, ok := formatEnum(p.Enum); ok && typ != "float32" && typ != "float64" && typ != "bool" {
+			fields = append(fields, "Enum: "+strconv.Quote(enum))
+			hasEnum = true
+		} else {
+			g.warn(location, "the enum is lost, only the strings without spaces and the integers are supported")
+		}
+	}
+	if (p.Minimum != nil || p.Maximum != nil) && hasEnum {
+		g.warn(location, "the minimum and the maximum are lost, they can't be declared with the enum")
+	} else {
+		if p.Minimum != nil {
+			fields = append(fields, "Min: "+strconv.Quote(formatValue(*p.Minimum)))
+		}
+		if p.Maximum != nil {
+			fields = append(fields, "Max: "+strconv.Quote(formatValue(*p.Maximum)))
+		}
+	}
+	if p.Description != "" {
+		fields = append(fields, "Desc: "+strconv.Quote(p.Description))
+	}
+	if p.MinLength != nil {
+		fields = append(fields, "MinLen: "+strconv.Quote(strconv.FormatInt(*p.MinLength, 10)))
+	}
+	if p.MaxLength != nil {
+		fields = append(fields, "MaxLen: "+strconv.Quote(strconv.FormatInt(*p.MaxLength, 10)))
+	}
+	if p.Default != nil {
+		fields = append(fields, "Default: "+strconv.Quote(formatValue(p.Default)))
+	}
+	if p.Required && p.In != "path" {
+		fields = append(fields, "Required: true")
+	}
+	if p.Example != nil {
+		fields = append(fields, "Example: "+strconv.Quote(formatValue(p.Example)))
+	}
+	return "ehttp.ValueInfo{" + strings.Join(fields, ", ") + "}"
+}
+
+// getGlobalParameterName the name of the global parameter in the GlobalParameters.
+// The name of an ehttp global parameter is the key, so the parameters are declared by their names.
+func (g *generator) getGlobalParameterName(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, parametersPrefix) {
+		return "", false
+	}
+	p, ok := g.doc.Parameters[strings.TrimPrefix(ref, parametersPrefix)]
+	if !ok || p == nil || p.In == "body" || parameterFields[p.In] == "" {
+		return "", false
+	}
+	return p.Name, true
+}
+
+// globalParametersLiteral the literal of the GlobalParameters, "" if there is no global parameter
+func (g *generator) globalParametersLiteral() string {
+	keys := []string{}
+	for key := range g.doc.Parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	declared := map[string]bool{}
+	b := &bytes.Buffer{}
+	for _, key := range keys {
+		p := g.doc.Parameters[key]
+		location := "parameters." + key
+		if p == nil || p.In == "body" || parameterFields[p.In] == "" {
+			g.warn(location, "the global parameter is not generated, only the parameters in path, header, query and formData are supported")
+			continue
+		}
+		if declared[p.Name] {
+			g.warn(location, "the global parameter is not generated, the name %s is declared by another global parameter", p.Name)
+			continue
+		}
+		declared[p.Name] = true
+		if key != p.Name {
+			g.warn(location, "the global parameter is declared as %s, it is the name of the parameter", p.Name)
+		}
+		fmt.Fprintf(b, "%s: ehttp.Parameter{%s: &%s},\n", strconv.Quote(p.Name), parameterFields[p.In], g.valueInfoLiteral(location, p))
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "map[string]ehttp.Parameter{\n" + b.String() + "}"
+}
+
+// configLiteral the literal of the ehttp.Config from the document
+func (g *generator) configLiteral() string {
+	doc := g.doc
+	b := &bytes.Buffer{}
+	b.WriteString("&ehttp.Config{\n")
+	if len(doc.Schemes) > 0 {
+		fmt.Fprintf(b, "Schemes: %s,\n", g.schemesLiteral("schemes", doc.Schemes))
+	}
+	if doc.BasePath != "" && doc.BasePath != "/" {
+		fmt.Fprintf(b, "BasePath: %s,\n", strconv.Quote(doc.BasePath))
+	}
+	if doc.Info != nil {
+		info := doc.Info
+		fmt.Fprintf(b, "Version: %s,\n", strconv.Quote(info.Version))
+		fmt.Fprintf(b, "Title: %s,\n", strconv.Quote(info.Title))
+		if info.Description != "" {
+			fmt.Fprintf(b, "Description: %s,\n", strconv.Quote(info.Description))
+		}
+	}
+	if doc.Host != "" {
+		fmt.Fprintf(b, "DomainName: %s,\n", strconv.Quote(doc.Host))
+	}
+	if doc.Info != nil {
+		info := doc.Info
+		if info.TermsOfService != "" {
+			fmt.Fprintf(b, "TermsOfService: %s,\n", strconv.Quote(info.TermsOfService))
+		}
+		if c := info.Contact; c != nil {
+			fmt.Fprintf(b, "Contact: &ehttp.Contact{%s},\n", fieldsLiteral("Name", c.Name, "URL", c.URL, "Email", c.EMail))
+		}
+		if l := info.License; l != nil {
+			fmt.Fprintf(b, "License: &ehttp.License{%s},\n", fieldsLiteral("Name", l.Name, "URL", l.URL))
+		}
+	}
+	if len(doc.Tags) > 0 {
+		b.WriteString("Tags: []ehttp.Tag{\n")
+		for _, tag := range doc.Tags {
+			if tag == nil {
+				continue
+			}
+			fields := []string{"Name: " + strconv.Quote(tag.Name)}
+			if tag.Description != "" {
+				fields = append(fields, "Description: "+strconv.Quote(tag.Description))
+			}
+			if tag.ExternalDocs != nil {
+				fields = append(fields, "ExternalDocs: "+externalDocsLiteral(tag.ExternalDocs))
+			}
+			fmt.Fprintf(b, "ehttp.Tag{%s},\n", strings.Join(fields, ", "))
+		}
+		b.WriteString("},\n")
+	}
+	if doc.ExternalDocs != nil {
+		fmt.Fprintf(b, "ExternalDocs: %s,\n", externalDocsLiteral(doc.ExternalDocs))
+	}
+	b.WriteString("}")
+	if len(doc.SecurityDefinitions) > 0 || len(doc.Security) > 0 {
+		g.warn("securityDefinitions", "the security definitions and requirements are not generated")
+	}
+	return b.String()
+}
+
+// schemesLiteral the literal of []ehttp.Scheme, the schemes except http and https are lost
+func (g *generator) schemesLiteral(location string, schemes []string) string {
+	values := []string{}
+	for _, scheme := range schemes {
+		switch scheme {
+		case "http":
+			values = append(values, "ehttp.SchemeHTTP")
+		case "https":
+			values = append(values, "ehttp.SchemeHTTPS")
+		default:
+			g.warn(location, "the scheme %s is not generated", scheme)
+		}
+	}
+	return "[]ehttp.Scheme{" + strings.Join(values, ", ") + "}"
+}
+
+func externalDocsLiteral(docs *swagger.ExternalDocs) string {
+	return "&ehttp.ExternalDocs{" + fieldsLiteral("Description", docs.Description, "URL", docs.URL) + "}"
+}
+
+// fieldsLiteral the fields of a struct literal (field name, string value, ...), the empty values are omitted
+func fieldsLiteral(namesAndValues ...string) string {
+	fields := []string{}
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		if namesAndValues[i+1] != "" {
+			fields = append(fields, namesAndValues[i]+": "+strconv.Quote(namesAndValues[i+1]))
+		}
+	}
+	return strings.Join(fields, ", ")
+}
+
+func stringsLiteral(values []string) string {
+	literals := []string{}
+	for _, value := range values {
+		literals = append(literals, strconv.Quote(value))
+	}
+	return "[]string{" + strings.Join(literals, ", ") + "}"
+}
+
+// generateAPIs generate apis.go
+func (g *generator) generateAPIs() ([]byte, error) {
+	f := newFile(g.options.Package, "// Code generated by ehttp gen server. DO NOT EDIT.")
+	f.imports["github.com/enjoy-web/ehttp"] = true
+	globalParameters := g.globalParametersLiteral()
+	if globalParameters != "" {
+		f.printf("\n// GlobalParameters the global parameters of the document, they are referred by the GlobalParameterNames of the APIDocs\n")
+		f.printf("var GlobalParameters = %s\n", globalParameters)
+	}
+	for _, op := range g.operations {
+		if op.source.Sunset != "" && strings.Contains(op.doc, "time.Date(") {
+			f.imports["time"] = true
+		}
+		comment := op.method + " " + op.path
+		if op.source.Summary != "" {
+			comment += ", " + toComment(op.source.Summary)
+		}
+		f.printf("\n// Doc%s %s\n", op.name, comment)
+		f.printf("var Doc%s = %s\n", op.name, op.doc)
+	}
+	f.printf("\n// NewConfig the Config of the server from the document\n")
+	f.printf("func NewConfig() *ehttp.Config {\nreturn %s\n}\n", g.configLiteral())
+	f.printf("\n// %s register the APIs of the document, the handlers are in handlers.go\n", g.options.FuncName)
+	f.printf("func %s(router *ehttp.Engine) error {\n", g.options.FuncName)
+	for _, op := range g.operations {
+		f.printf("if err := router.%s(%s, Doc%s, Handle%s); err != nil {\nreturn err\n}\n", op.method, strconv.Quote(op.ginPath), op.name, op.name)
+	}
+	f.printf("return nil\n}\n")
+	f.printf("\n// NewEngine create the Engine with NewConfig, and register the APIs\n")
+	f.printf("func NewEngine() (*ehttp.Engine, error) {\n")
+	f.printf("router := ehttp.NewEngine(NewConfig())\n")
+	if globalParameters != "" {
+		f.printf("if err := router.SetGlobalParameters(GlobalParameters); err != nil {\nreturn nil, err\n}\n")
+	}
+	f.printf("if err := %s(router); err != nil {\nreturn nil, err\n}\n", g.options.FuncName)
+	f.printf("return router, nil\n}\n")
+	return f.bytes()
+}
+
+// generateHandlers generate handlers.go, the handlers respond 400 with the error of the parameters and 501 otherwise
+func (g *generator) generateHandlers() ([]byte, error) {
+	f := newFile(g.options.Package, "")
+	if len(g.operations) > 0 {
+		f.imports["net/http"] = true
+		f.imports["github.com/gin-gonic/gin"] = true
+	}
+	for _, op := range g.operations {
+		f.printf("\n// Handle%s %s %s\n", op.name, op.method, op.path)
+		f.printf("func Handle%s(c *gin.Context, err error) {\n", op.name)
+		f.printf("if err != nil {\nc.String(http.StatusBadRequest, err.Error())\nreturn\n}\n")
+		f.printf("c.String(http.StatusNotImplemented, \"not implemented\")\n}\n")
+	}
+	return f.bytes()
+}
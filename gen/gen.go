@@ -0,0 +1,306 @@
+// Package gen generate the Go source of an ehttp server from a swagger 2.0 document (contract-first):
+// the model structs with the tags of ehttp, the APIDocs of the operations, the registration function and the handler stubs.
+// The document registered by the generated code is equivalent to the source document,
+// except the parts which can't be declared by ehttp (like: the maps, the nested arrays and the formats without Go types),
+// they are reported as the warnings.
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const definitionsPrefix = "#/definitions/"
+
+// Options the options of the generated code
+// Fields:
+//   Package -- the package name of the generated files, default is "api"
+//   FuncName -- the name of the registration function (func(router *ehttp.Engine) error), default is "RegisterAPIs"
+type Options struct {
+	Package  string
+	FuncName string
+}
+
+// Files the generated Go source files
+// Fields:
+//   Models -- models.go, the structs of the definitions
+//   APIs -- apis.go, the APIDocs of the operations, the Config and the registration function
+//   Handlers -- handlers.go, the handler stubs, it is the file to be edited
+//   Warnings -- the parts of the document which can't be generated exactly
+type Files struct {
+	Models   []byte
+	APIs     []byte
+	Handlers []byte
+	Warnings []string
+}
+
+// Server generate the Go source of the server from the swagger document
+func Server(doc *swagger.Swagger, options *Options) (*Files, error) {
+	g := &generator{
+		doc:     doc,
+		models:  map[string]string{},
+		goNames: map[string]bool{},
+	}
+	if options != nil {
+		g.options = *options
+	}
+	if g.options.Package == "" {
+		g.options.Package = "api"
+	}
+	if g.options.FuncName == "" {
+		g.options.FuncName = "RegisterAPIs"
+	}
+	if !token.IsIdentifier(g.options.Package) {
+		return nil, errors.New("invalid package name " + g.options.Package)
+	}
+	if !token.IsIdentifier(g.options.FuncName) || !token.IsExported(g.options.FuncName) {
+		return nil, errors.New("invalid function name " + g.options.FuncName + ", it must be exported")
+	}
+	for _, name := range []string{g.options.FuncName, "GlobalParameters", "NewConfig", "NewEngine"} {
+		g.goNames[name] = true
+	}
+	g.collectModels()
+	g.collectOperations()
+	files := &Files{}
+	var err error
+	if files.APIs, err = g.generateAPIs(); err != nil {
+		return nil, err
+	}
+	if files.Models, err = g.generateModels(); err != nil {
+		return nil, err
+	}
+	if files.Handlers, err = g.generateHandlers(); err != nil {
+		return nil, err
+	}
+	files.Warnings = g.warnings
+	return files, nil
+}
+
+type generator struct {
+	doc        *swagger.Swagger
+	options    Options
+	warnings   []string
+	models     map[string]string // definition name -> struct name
+	structs    []*structType
+	operations []*operation
+	goNames    map[string]bool // the declared Go names
+}
+
+func (g *generator) warn(location string, format string, args ...interface{}) {
+	g.warnings = append(g.warnings, location+": "+fmt.Sprintf(format, args...))
+}
+
+// newGoName an unused exported Go name of the string
+func (g *generator) newGoName(s string) string {
+	name := toGoName(s)
+	for i := 2; g.goNames[name]; i++ {
+		name = toGoName(s) + strconv.Itoa(i)
+	}
+	g.goNames[name] = true
+	return name
+}
+
+// file the source of a generated file
+type file struct {
+	packageName string
+	header      string
+	imports     map[string]bool
+	body        bytes.Buffer
+}
+
+func newFile(packageName, header string) *file {
+	return &file{packageName: packageName, header: header, imports: map[string]bool{}}
+}
+
+func (f *file) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&f.body, format, args...)
+}
+
+func (f *file) bytes() ([]byte, error) {
+	b := &bytes.Buffer{}
+	if f.header != "" {
+		b.WriteString(f.header + "\n\n")
+	}
+	b.WriteString("package " + f.packageName + "\n")
+	if len(f.imports) > 0 {
+		paths := []string{}
+		for path := range f.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		b.WriteString("\nimport (\n")
+		for _, path := range paths {
+			if !strings.Contains(path, ".") {
+				b.WriteString(strconv.Quote(path) + "\n")
+			}
+		}
+		b.WriteString("\n")
+		for _, path := range paths {
+			if strings.Contains(path, ".") {
+				b.WriteString(strconv.Quote(path) + "\n")
+			}
+		}
+		b.WriteString(")\n")
+	}
+	b.Write(f.body.Bytes())
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, errors.New("format the generated source, " + err.Error())
+	}
+	return source, nil
+}
+
+var goInitialisms = map[string]string{
+	"api": "API", "html": "HTML", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON",
+	"uri": "URI", "url": "URL", "uuid": "UUID", "xml": "XML",
+}
+
+var notIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// toGoName the exported Go name of the string, like ehttp.Engine.GenerateGoClient.
+// examples:
+//   getBooksId -> GetBooksId
+//   page_size -> PageSize
+//   X-Request-Id -> XRequestID
+func toGoName(s string) string {
+	b := &bytes.Buffer{}
+	for _, word := range notIdentifierRegexp.Split(s, -1) {
+		if word == "" {
+			continue
+		}
+		if initialism, ok := goInitialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// goPrimitiveType the Go type of the swagger type and format, and the warning if the document generated by the type is different
+func goPrimitiveType(typ, format string) (goType string, warning string) {
+	switch typ {
+	case "integer":
+		switch format {
+		case "int32":
+			return "int32", ""
+		case "int64":
+			return "int64", ""
+		case "":
+			return "int", "the integer without format is declared as int (format int32)"
+		}
+		return "int64", "the format " + format + " is declared as int64"
+	case "number":
+		switch format {
+		case "float":
+			return "float32", ""
+		case "double":
+			return "float64", "the format double is declared as float64 (format float)"
+		case "":
+			return "float64", "the number without format is declared as float64 (format float)"
+		}
+		return "float64", "the format " + format + " is declared as float64 (format float)"
+	case "string":
+		if format != "" {
+			return "string", "the format " + format + " is lost, it is declared as string"
+		}
+		return "string", ""
+	case "boolean":
+		return "bool", ""
+	case "file":
+		return "file", ""
+	}
+	return "", "the type " + typ + " is not supported"
+}
+
+// formatValue format the JSON value for the tags and the ValueInfos (like: 1, 1.5, true, abc)
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// formatEnum the enum separated by spaces, false if a value has spaces or is not a string or an integer
+func formatEnum(enum []interface{}) (string, bool) {
+	values := []string{}
+	for _, value := range enum {
+		switch v := value.(type) {
+		case string:
+			if v == "" || strings.ContainsAny(v, " \t\r\n") {
+				return "", false
+			}
+		case float64:
+			if v != float64(int64(v)) {
+				return "", false
+			}
+		default:
+			return "", false
+		}
+		values = append(values, formatValue(value))
+	}
+	return strings.Join(values, " "), true
+}
+
+// jsonLiteral the Go string literal of the value encoded as JSON
+func jsonLiteral(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return stringLiteral(string(data)), nil
+}
+
+// stringLiteral a raw string literal if it is possible, it is more readable for the JSON and the tags
+func stringLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r") || (!strings.Contains(s, `"`) && !strings.Contains(s, `\`)) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// getExample the JSON example of the examples (MIME type -> example), application/json first
+func getExample(examples map[string]interface{}) (interface{}, bool) {
+	if example, ok := examples["application/json"]; ok {
+		return example, true
+	}
+	mimeTypes := []string{}
+	for mimeType := range examples {
+		mimeTypes = append(mimeTypes, mimeType)
+	}
+	sort.Strings(mimeTypes)
+	for _, mimeType := range mimeTypes {
+		if strings.Contains(mimeType, "json") {
+			return examples[mimeType], true
+		}
+	}
+	return nil, false
+}
+
+// toComment the line comment of the text
+func toComment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,215 @@
+package gen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "book store APIS", "version": "v1", "license": {"name": "MIT"}},
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": ["https"],
+  "produces": ["application/json"],
+  "tags": [{"name": "books", "description": "the books"}],
+  "parameters": {
+    "X-Request-Id": {"name": "X-Request-Id", "in": "header", "type": "string", "required": true}
+  },
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "tags": ["books"],
+        "summary": "Get a book",
+        "operationId": "getBook",
+        "parameters": [
+          {"$ref": "#/parameters/X-Request-Id"},
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all", "brief"]},
+          {"name": "limit", "in": "query", "type": "integer", "format": "int32", "minimum": 1, "default": 10}
+        ],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}, "headers": {"X-Rate": {"type": "integer", "format": "int64"}}},
+          "default": {"description": "error", "schema": {"$ref": "#/definitions/Error"}}
+        }
+      },
+      "delete": {
+        "deprecated": true,
+        "x-sunset": "Wed, 01 Jan 2031 00:00:00 GMT",
+        "parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+        "responses": {"204": {"description": "deleted"}}
+      },
+      "head": {"responses": {"200": {"description": "ok"}}}
+    },
+    "/books": {
+      "post": {
+        "parameters": [{
+          "name": "book", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Book"},
+          "x-examples": {"application/json": {"title": "Go", "required": ["not", "a", "list"]}}
+        }],
+        "responses": {"201": {"description": "created", "schema": {"type": "object", "properties": {"id": {"type": "string"}}}}}
+      }
+    },
+    "/files/{name}.json": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "description": "a book",
+      "required": ["title", "tags"],
+      "properties": {
+        "id": {"type": "string", "readOnly": true, "description": "the id"},
+        "title": {"type": "string", "minLength": 1, "example": "Go"},
+        "price": {"type": "number", "format": "double"},
+        "note": {"type": "string", "x-nullable": true},
+        "status": {"type": "string", "enum": ["on sale", "sold"]},
+        "tags": {"type": "array", "items": {"type": "string"}},
+        "author": {"$ref": "#/definitions/Author"},
+        "meta": {"type": "object", "additionalProperties": {"type": "string"}}
+      }
+    },
+    "Author": {"type": "object", "properties": {"name": {"type": "string"}}},
+    "Error": {"type": "object", "properties": {"message": {"type": "string"}}},
+    "Pet": {"type": "object", "discriminator": "kind", "properties": {"kind": {"type": "string"}}}
+  }
+}`
+
+func newTestFiles(t *testing.T) *Files {
+	doc, err := swagger.ParseDocument([]byte(testDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := Server(doc, &Options{Package: "bookapi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, source := range map[string][]byte{"models.go": files.Models, "apis.go": files.APIs, "handlers.go": files.Handlers} {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, source, 0); err != nil {
+			t.Error(name, err)
+		}
+	}
+	return files
+}
+
+func TestParseDocument(t *testing.T) {
+	doc, err := swagger.ParseDocument([]byte(testDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := doc.Definitions["Book"].Properties
+	if !properties["title"].Required || !properties["tags"].Required || properties["id"].Required {
+		t.Error("the required list should be moved to the properties")
+	}
+	example := doc.Paths["/books"].Post.Parameters[0].Examples["application/json"].(map[string]interface{})
+	if _, ok := example["required"]; !ok {
+		t.Error("the examples should not be changed")
+	}
+	if _, err := swagger.ParseDocument([]byte(`{"openapi": "3.0.0"}`)); err == nil {
+		t.Error("an OpenAPI 3 document should be an error")
+	}
+}
+
+func TestServer_Models(t *testing.T) {
+	models := string(newTestFiles(t).Models)
+	for _, expected := range []string{
+		"package bookapi",
+		"// Book a book\ntype Book struct {",
+		"Author *Author  `json:\"author\" nullable:\"false\"`",
+		"ID     string   `json:\"id\" desc:\"the id\" readonly:\"true\"`",
+		"Note   *string  `json:\"note\"`",
+		"Price  float64  `json:\"price\"`",
+		"Tags   []string `json:\"tags\" req:\"true\"`",
+		"Title  string   `json:\"title\" minlen:\"1\" req:\"true\" example:\"Go\"`",
+		`pkgPath + ".Book": "a book",`,
+		"type PostBooksResponse201 struct {",
+	} {
+		if !strings.Contains(models, expected) {
+			t.Error("models.go should contain " + expected)
+		}
+	}
+	for _, unexpected := range []string{"Meta", "type Pet struct", "enum:"} {
+		if strings.Contains(models, unexpected) {
+			t.Error("models.go should not contain " + unexpected)
+		}
+	}
+}
+
+func TestServer_APIs(t *testing.T) {
+	apis := string(newTestFiles(t).APIs)
+	for _, expected := range []string{
+		`"X-Request-Id": ehttp.Parameter{InHeader: &ehttp.ValueInfo{Type: "string", Required: true}},`,
+		"// DocGetBook GET /books/{id}, Get a book\nvar DocGetBook = &ehttp.APIDocCommon{",
+		`GlobalParameterNames: []string{"X-Request-Id"},`,
+		`"fields": ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "string", Enum: "all brief"}},`,
+		`"limit":  ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Min: "1", Default: "10"}},`,
+		`"X-Rate": ehttp.ValueInfo{Type: "int64"},`,
+		"-1: ehttp.Response{",
+		`OperationID: "getBook",`,
+		"Sunset:     time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC),",
+		"Model:   &Book{},\n\t\tExample: `{\"required\":[\"not\",\"a\",\"list\"],\"title\":\"Go\"}`,",
+		`Produces: []string{"application/json"},`,
+		`License:    &ehttp.License{Name: "MIT"},`,
+		`DomainName: "api.example.com",`,
+		`if err := router.GET("/books/:id", DocGetBook, HandleGetBook); err != nil {`,
+		`if err := router.DELETE("/books/:id", DocDeleteBooksID, HandleDeleteBooksID); err != nil {`,
+		"if err := router.SetGlobalParameters(GlobalParameters); err != nil {",
+		"func RegisterAPIs(router *ehttp.Engine) error {",
+	} {
+		if !strings.Contains(apis, expected) {
+			t.Error("apis.go should contain " + expected)
+		}
+	}
+	if strings.Contains(apis, "/files/") || strings.Contains(apis, "HEAD") {
+		t.Error("the operations which can't be registered should not be generated")
+	}
+}
+
+func TestServer_Handlers(t *testing.T) {
+	handlers := string(newTestFiles(t).Handlers)
+	if strings.Contains(handlers, "DO NOT EDIT") {
+		t.Error("handlers.go should be editable")
+	}
+	if !strings.Contains(handlers, "// HandleGetBook GET /books/{id}\nfunc HandleGetBook(c *gin.Context, err error) {") {
+		t.Error("handlers.go should contain HandleGetBook")
+	}
+}
+
+func TestServer_Warnings(t *testing.T) {
+	warnings := strings.Join(newTestFiles(t).Warnings, "\n")
+	for _, expected := range []string{
+		"definitions.Book.meta: the map is not generated",
+		"definitions.Book.price: the format double is declared as float64 (format float)",
+		"definitions.Book.status: the enum is lost",
+		"definitions.Book.tags: the required array or struct is validated",
+		"definitions.Pet: the polymorphic definition is not generated",
+		"HEAD /books/{id}: the operation is not generated",
+		"GET /files/{name}.json: the operation is not generated",
+		"POST /books response 201: the inline object is declared as the struct PostBooksResponse201",
+	} {
+		if !strings.Contains(warnings, expected) {
+			t.Error("the warnings should contain " + expected)
+		}
+	}
+	if _, err := Server(&swagger.Swagger{}, &Options{Package: "1api"}); err == nil {
+		t.Error("the package name 1api should be invalid")
+	}
+}
+
+func TestToGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"getBook":            "GetBook",
+		"delete /books/{id}": "DeleteBooksID",
+		"page_size":          "PageSize",
+		"2fa":                "X2fa",
+	} {
+		if goName := toGoName(name); goName != expected {
+			t.Error(name + " should be " + expected + ", got " + goName)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package gen
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// structType a generated struct
+type structType struct {
+	name        string
+	description string
+	fields      []*structField
+}
+
+// structField a field of the generated struct, the tags are ordered
+type structField struct {
+	name   string
+	goType string
+	tags   [][2]string
+}
+
+func (f *structField) addTag(key, value string) {
+	f.tags = append(f.tags, [2]string{key, value})
+}
+
+// tagLiteral the literal of the struct tag
+func (f *structField) tagLiteral() string {
+	tags := []string{}
+	for _, tag := range f.tags {
+		tags = append(tags, tag[0]+":"+strconv.Quote(tag[1]))
+	}
+	tag := strings.Join(tags, " ")
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// collectModels declare the structs of the definitions.
+// The names are declared first, so the definitions can refer to each other.
+func (g *generator) collectModels() {
+	names := []string{}
+	for name := range g.doc.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		location := "definitions." + name
+		if !g.isModel(location, g.doc.Definitions[name]) {
+			continue
+		}
+		g.models[name] = g.newGoName(name)
+		if g.models[name] != name {
+			g.warn(location, "the struct is %s, it is the definition name in the generated document", g.models[name])
+		}
+	}
+	for _, name := range names {
+		goName, ok := g.models[name]
+		if !ok {
+			continue
+		}
+		location := "definitions." + name
+		schema := g.doc.Definitions[name]
+		if len(schema.AllOf) > 0 {
+			g.warn(location, "the allOf is flattened, the properties of the schemas are declared in the struct %s", goName)
+		}
+		if schema.XML != nil {
+			g.warn(location, "the XML object is not generated")
+		}
+		g.addStruct(location, goName, schema.Description, g.getProperties(schema, map[string]bool{}))
+	}
+}
+
+// isModel check if the definition can be declared as a struct
+func (g *generator) isModel(location string, schema *swagger.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || schema.Discriminator != "" {
+		g.warn(location, "the polymorphic definition is not generated, declare the interface by ehttp.RegisterPolymorphic")
+		return false
+	}
+	if schema.Type != "" && schema.Type != "object" {
+		g.warn(location, "the %s definition is not generated, only the objects are declared as structs", schema.Type)
+		return false
+	}
+	return true
+}
+
+// getProperties the properties of the schema, the properties of the allOf schemas are merged
+func (g *generator) getProperties(schema *swagger.Schema, visiting map[string]bool) map[string]*swagger.Propertie {
+	properties := map[string]*swagger.Propertie{}
+	for _, s := range schema.AllOf {
+		if s == nil {
+			continue
+		}
+		if s.Ref != "" {
+			name := strings.TrimPrefix(s.Ref, definitionsPrefix)
+			if visiting[name] || g.doc.Definitions[name] == nil {
+				continue
+			}
+			visiting[name] = true
+			s = g.doc.Definitions[name]
+		}
+		for name, p := range g.getProperties(s, visiting) {
+			properties[name] = p
+		}
+	}
+	for name, p := range schema.Properties {
+		properties[name] = p
+	}
+	return properties
+}
+
+// addStruct declare the struct of the properties, the fields are sorted by the property names
+func (g *generator) addStruct(location, name, description string, properties map[string]*swagger.Propertie) {
+	s := &structType{name: name, description: description}
+	g.structs = append(g.structs, s)
+	propertyNames := []string{}
+	for propertyName := range properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	fieldNames := map[string]bool{}
+	for _, propertyName := range propertyNames {
+		if properties[propertyName] == nil {
+			continue
+		}
+		field := g.newStructField(location+"."+propertyName, name, propertyName, properties[propertyName])
+		if field == nil {
+			continue
+		}
+		fieldName := field.name
+		for i := 2; fieldNames[field.name]; i++ {
+			field.name = fieldName + strconv.Itoa(i)
+		}
+		fieldNames[field.name] = true
+		s.fields = append(s.fields, field)
+	}
+}
+
+// newStructField the field of the property, nil if the type can't be declared
+func (g *generator) newStructField(location, structName, name string, p *swagger.Propertie) *structField {
+	field := &structField{name: toGoName(name)}
+	field.goType = g.getFieldType(location, structName+field.name, p)
+	if field.goType == "" {
+		return nil
+	}
+	field.addTag("json", name)
+	if p.Description != "" {
+		field.addTag("desc", p.Description)
+	}
+	isArray := strings.HasPrefix(field.goType, "[]")
+	isStruct := strings.HasPrefix(field.goType, "*")
+	hasLimits := false
+	if isArray || isStruct {
+		items := p.Items
+		if isStruct || items == nil {
+			items = p
+		}
+		if len(items.Enum) > 0 || items.Minimum != nil || items.Maximum != nil || items.MinLength != nil || items.MaxLength != nil || items.Default != nil {
+			g.warn(location, "the enum, minimum, maximum, minLength, maxLength and default of an array or a struct are lost")
+		}
+	} else {
+		hasLimits = g.setLimitTags(location, field, p)
+	}
+	if p.Required {
+		field.addTag("req", "true")
+		if isArray || isStruct {
+			g.warn(location, "the required array or struct is validated, but it is not required in the generated document")
+		}
+	}
+	if p.ReadOnly {
+		field.addTag("readonly", "true")
+	}
+	if p.WriteOnly {
+		field.addTag("writeonly", "true")
+	}
+	switch {
+	case isStruct && !p.Nullable:
+		field.addTag("nullable", "false")
+	case !isStruct && p.Nullable && (isArray || hasLimits):
+		// the tags enum, min, max and default are only supported by the non-pointer fields
+		field.addTag("nullable", "true")
+		if hasLimits {
+			g.warn(location, "the nullable property is declared as %s, null is decoded as the zero value", field.goType)
+		}
+	case !isStruct && p.Nullable:
+		field.goType = "*" + field.goType
+	}
+	if p.Example != nil {
+		switch {
+		case isStruct:
+			g.warn(location, "the example of a struct is lost")
+		case isArray:
+			field.addTag("example", formatValue(p.Example))
+		default:
+			field.addTag("example", formatValue(p.Example))
+		}
+	}
+	if p.XML != nil || (p.Items != nil && p.Items.XML != nil) {
+		g.warn(location, "the XML object is not generated")
+	}
+	return field
+}
+
+// setLimitTags set the tags enum, min, max, minlen, maxlen and default of the primitive field, return true if one is set
+func (g *generator) setLimitTags(location string, field *structField, p *swagger.Propertie) bool {
+	length := len(field.tags)
+	if len(p.Enum) > 0 {
+		if enum, ok := formatEnum(p.Enum); ok && field.goType != "float32" && field.goType != "float64" && field.goType != "bool" {
+			field.addTag("enum", enum)
+		} else {
+			g.warn(location, "the enum is lost, only the strings without spaces and the integers are supported")
+		}
+	}
+	if p.Minimum != nil {
+		field.addTag("min", formatValue(*p.Minimum))
+	}
+	if p.Maximum != nil {
+		field.addTag("max", formatValue(*p.Maximum))
+	}
+	if p.MinLength != nil {
+		field.addTag("minlen", strconv.FormatInt(*p.MinLength, 10))
+	}
+	if p.MaxLength != nil {
+		field.addTag("maxlen", strconv.FormatInt(*p.MaxLength, 10))
+	}
+	if p.Default != nil {
+		field.addTag("default", formatValue(p.Default))
+	}
+	return len(field.tags) > length
+}
+
+// getFieldType the Go type of the property, "" if it can't be declared.
+// The inline objects are declared as the structs named inlineName.
+func (g *generator) getFieldType(location, inlineName string, p *swagger.Propertie) string {
+	switch {
+	case p.Ref != "":
+		return g.getRefType(location, p.Ref)
+	case len(p.AllOf) == 1 && p.AllOf[0] != nil && p.AllOf[0].Ref != "":
+		// a reference with the siblings is wrapped by allOf
+		return g.getRefType(location, p.AllOf[0].Ref)
+	case len(p.AllOf) > 0:
+		g.warn(location, "the property is not generated, the allOf of a property is not supported")
+		return ""
+	case p.Type == "array":
+		if p.Items == nil {
+			g.warn(location, "the array without items is not generated")
+			return ""
+		}
+		if p.Items.Type == "array" {
+			g.warn(location, "the nested array is not generated")
+			return ""
+		}
+		itemType := g.getFieldType(location+".items", inlineName+"Item", p.Items)
+		if itemType == "" {
+			return ""
+		}
+		return "[]" + itemType
+	case p.Type == "object" || (p.Type == "" && p.Properties != nil):
+		if p.AdditionalProperties != nil {
+			g.warn(location, "the map is not generated")
+			return ""
+		}
+		if len(p.Properties) == 0 {
+			g.warn(location, "the free-form object is not generated")
+			return ""
+		}
+		name := g.newGoName(inlineName)
+		g.warn(location, "the inline object is declared as the struct %s, it is a definition in the generated document", name)
+		g.addStruct(location, name, p.Description, p.Properties)
+		return "*" + name
+	}
+	typ, warning := goPrimitiveType(p.Type, p.Format)
+	if typ == "file" {
+		typ, warning = "", "the file property is not supported"
+	}
+	if warning != "" {
+		if typ == "" {
+			warning += ", the property is not generated"
+		}
+		g.warn(location, "%s", warning)
+	}
+	return typ
+}
+
+// getRefType the pointer type of the referenced definition, "" if the definition is not generated
+func (g *generator) getRefType(location, ref string) string {
+	name, ok := g.models[strings.TrimPrefix(ref, definitionsPrefix)]
+	if !ok || !strings.HasPrefix(ref, definitionsPrefix) {
+		g.warn(location, "the property is not generated, the %s is not declared as a struct", ref)
+		return ""
+	}
+	return "*" + name
+}
+
+// getModelType the model of a schema in the requests and the responses (like: &Book{}), "" if it can't be declared
+func (g *generator) getModelType(location, inlineName string, schema *swagger.Schema) string {
+	if schema.Ref != "" {
+		name, ok := g.models[strings.TrimPrefix(schema.Ref, definitionsPrefix)]
+		if !ok || !strings.HasPrefix(schema.Ref, definitionsPrefix) {
+			g.warn(location, "the model is not generated, the %s is not declared as a struct", schema.Ref)
+			return ""
+		}
+		return name
+	}
+	if (schema.Type == "object" || schema.Type == "") && len(schema.Properties) > 0 && len(schema.AllOf) == 0 {
+		name := g.newGoName(inlineName)
+		g.warn(location, "the inline object is declared as the struct %s, it is a definition in the generated document", name)
+		g.addStruct(location, name, schema.Description, schema.Properties)
+		return name
+	}
+	typ := schema.Type
+	if typ == "" {
+		typ = "schema"
+	}
+	g.warn(location, "the model is not generated, the %s model is not supported, the models are structs", typ)
+	return ""
+}
+
+// generateModels generate models.go
+func (g *generator) generateModels() ([]byte, error) {
+	f := newFile(g.options.Package, "// Code generated by ehttp gen server. DO NOT EDIT.")
+	descriptions := []*structType{}
+	for _, s := range g.structs {
+		f.printf("\n")
+		if s.description != "" {
+			descriptions = append(descriptions, s)
+			for i, line := range strings.Split(strings.TrimSpace(s.description), "\n") {
+				if i == 0 {
+					line = s.name + " " + line
+				}
+				f.printf("// %s\n", strings.TrimRight(line, " \t\r"))
+			}
+		}
+		f.printf("type %s struct {\n", s.name)
+		for _, field := range s.fields {
+			f.printf("%s %s %s\n", field.name, field.goType, field.tagLiteral())
+		}
+		f.printf("}\n")
+	}
+	if len(descriptions) > 0 {
+		// the descriptions of the structs are the registered doc comments
+		f.imports["reflect"] = true
+		f.imports["github.com/enjoy-web/ehttp"] = true
+		f.printf("\nfunc init() {\n")
+		f.printf("pkgPath := reflect.TypeOf(%s{}).PkgPath()\n", descriptions[0].name)
+		f.printf("ehttp.RegisterDocComments(ehttp.DocComments{\n")
+		for _, s := range descriptions {
+			f.printf("pkgPath + %s: %s,\n", strconv.Quote("."+s.name), strconv.Quote(s.description))
+		}
+		f.printf("})\n}\n")
+	}
+	return f.bytes()
+}
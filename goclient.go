@@ -0,0 +1,616 @@
+package ehttp
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateGoClient generate the source of a typed Go client package from the registered APIDocs.
+// Every operation is a method of the Client named from the operationId (like: getBooksId -> GetBooksId),
+// the parameters are the fields of <Method>Params (the optional parameters are pointers), and the request body
+// is the last argument. The request and response models are the Go types of the Models if their packages are
+// importable, the other types (like the types of a main package) are redeclared in the client package.
+// A response of which the status code is not 2xx is returned as *Error,
+// and Error.Model is the body decoded into the documented Model of the status code.
+func (e *Engine) GenerateGoClient(packageName string) ([]byte, error) {
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("invalid package name %q", packageName)
+	}
+	g := newGoClientGenerator()
+	apis := e.getSortedAPIs()
+	// reserve the names of the methods and the parameter types before the models are declared
+	names := map[*registeredAPI]string{}
+	for _, api := range apis {
+		name := g.getUniqueTypeName(toGoName(api.operation.OperationID))
+		names[api] = name
+		g.typeNames[name+"Params"] = true
+	}
+	operations := &bytes.Buffer{}
+	for _, api := range apis {
+		if err := g.writeOperation(operations, e, api, names[api]); err != nil {
+			return nil, &engineError{api.path, api.method, err}
+		}
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by ehttp. DO NOT EDIT.\n\n")
+	title := e.Conf.Title
+	if title == "" {
+		title = "the APIs"
+	}
+	fmt.Fprintf(b, "// Package %s the Go client of %s\npackage %s\n\n", packageName, title, packageName)
+	b.WriteString("import (\n")
+	for _, importPath := range []string{"bytes", "context", "encoding/json", "fmt", "io", "io/ioutil", "mime/multipart", "net/http", "net/url", "strings"} {
+		fmt.Fprintf(b, "%q\n", importPath)
+	}
+	b.WriteString("\n")
+	for _, importPath := range getSortedStringKeys(g.imports) {
+		alias := g.imports[importPath]
+		if alias == path.Base(importPath) && !strings.Contains(importPath, ".") {
+			fmt.Fprintf(b, "%q\n", importPath)
+		} else {
+			fmt.Fprintf(b, "%s %q\n", alias, importPath)
+		}
+	}
+	b.WriteString(")\n\n")
+	fmt.Fprintf(b, "// BasePath the base path of the APIs, the paths of the requests are Client.BaseURL + BasePath + the path of the API\nconst BasePath = %q\n", e.Conf.BasePath)
+	b.WriteString(goClientRuntime)
+	b.Write(g.declarations.Bytes())
+	b.Write(operations.Bytes())
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format the Go client: %s", err)
+	}
+	return source, nil
+}
+
+// getSortedAPIs the registered APIs sorted by the paths and the methods
+func (e *Engine) getSortedAPIs() []*registeredAPI {
+	apis := append([]*registeredAPI{}, e.apis...)
+	sort.SliceStable(apis, func(i, j int) bool {
+		if apis[i].path != apis[j].path {
+			return apis[i].path < apis[j].path
+		}
+		return apis[i].method < apis[j].method
+	})
+	return apis
+}
+
+// goClientRuntime the types and the functions shared by the operations of the client
+const goClientRuntime = `
+// Client the client of the APIs
+// Fields:
+//   BaseURL -- the scheme and the host of the server, like "https://api.example.com"
+//   HTTPClient -- the HTTP client which sends the requests, http.DefaultClient if it is nil
+//   Header -- the headers of all the requests (like: Authorization)
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Header     http.Header
+}
+
+// NewClient new a client of the server
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: http.DefaultClient, Header: http.Header{}}
+}
+
+// Error the response of which the status code is not 2xx
+// Fields:
+//   Model -- the body decoded into the documented Model of the status code, nil if the status code has no Model
+type Error struct {
+	StatusCode int
+	Body       []byte
+	Model      interface{}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("status code %d: %s", e.StatusCode, e.Body)
+}
+
+// File a file of the multipart/form-data body
+type File struct {
+	Name    string
+	Content io.Reader
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader, contentType string) (int, []byte, error) {
+	rawURL := c.BaseURL + BasePath + path
+	if len(query) > 0 {
+		rawURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	req = req.WithContext(ctx)
+	for _, h := range []http.Header{c.Header, header} {
+		for key, values := range h {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, data, err
+}
+
+func newJSONBody(v interface{}) (io.Reader, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func newMultipartBody(fields map[string]string, files map[string]*File) (io.Reader, string, error) {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	for key, value := range fields {
+		if err := w.WriteField(key, value); err != nil {
+			return nil, "", err
+		}
+	}
+	for key, file := range files {
+		if file == nil || file.Content == nil {
+			continue
+		}
+		part, err := w.CreateFormFile(key, file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return b, w.FormDataContentType(), nil
+}
+
+func decodeBody(data []byte, v interface{}) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+`
+
+// goClientReservedNames the names declared by goClientRuntime and the imports of the client
+var goClientReservedNames = []string{"BasePath", "Client", "NewClient", "Error", "File", "newJSONBody", "newMultipartBody", "decodeBody",
+	"bytes", "context", "json", "fmt", "io", "ioutil", "multipart", "http", "url", "strings"}
+
+type goClientGenerator struct {
+	imports      map[string]string // the package path -> the alias
+	aliases      map[string]bool
+	types        map[reflect.Type]string // the redeclared types -> the names
+	typeNames    map[string]bool
+	declarations *bytes.Buffer
+}
+
+func newGoClientGenerator() *goClientGenerator {
+	g := &goClientGenerator{
+		imports:      map[string]string{},
+		aliases:      map[string]bool{},
+		types:        map[reflect.Type]string{},
+		typeNames:    map[string]bool{},
+		declarations: &bytes.Buffer{},
+	}
+	for _, name := range goClientReservedNames {
+		g.aliases[name] = true
+		g.typeNames[name] = true
+	}
+	return g
+}
+
+// goClientParameter a field of the <Method>Params struct
+type goClientParameter struct {
+	field    string
+	name     string
+	in       string
+	typ      string
+	required bool
+	isFile   bool
+	desc     string
+}
+
+var swaggerPathParameterRegexp = regexp.MustCompile(`\{([^}]*)\}`)
+
+func (g *goClientGenerator) writeOperation(b *bytes.Buffer, e *Engine, api *registeredAPI, name string) error {
+	parameters, err := g.getParameters(e, api)
+	if err != nil {
+		return err
+	}
+	bodyType := ""
+	if request := api.doc.GetRequest(); request != nil && request.Model != nil {
+		bodyType = g.modelTypeString(request.Model)
+	}
+	resultType := ""
+	errorModels := map[int]string{}
+	responses := api.getResponses()
+	for _, code := range getSortedResponseCodes(responses) {
+		model := responses[code].Model
+		switch {
+		case model == nil:
+		case code >= 200 && code < 300:
+			if resultType == "" {
+				resultType = g.modelTypeString(model)
+			}
+		default:
+			errorModels[code] = g.modelTypeString(model)
+		}
+	}
+	paramsType := ""
+	if len(parameters) > 0 {
+		paramsType = name + "Params"
+	}
+	returnError := func(err string) string {
+		if resultType == "" {
+			return "return " + err
+		}
+		return "return result, " + err
+	}
+
+	// the parameters struct
+	if paramsType != "" {
+		fmt.Fprintf(b, "\n// %s the parameters of %s\ntype %s struct {\n", paramsType, name, paramsType)
+		for _, p := range parameters {
+			comment := p.in + " " + p.name
+			if p.desc != "" {
+				comment += ", " + p.desc
+			}
+			fmt.Fprintf(b, "%s %s // %s\n", p.field, p.typ, toLineComment(comment))
+		}
+		b.WriteString("}\n")
+	}
+
+	// the method
+	summary := api.operation.Summary
+	if summary == "" {
+		summary = api.method + " " + api.path
+	} else {
+		summary += " (" + api.method + " " + api.path + ")"
+	}
+	fmt.Fprintf(b, "\n// %s %s\n", name, toLineComment(summary))
+	if api.operation.Deprecated {
+		b.WriteString("//\n// Deprecated: the operation is deprecated.\n")
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(ctx context.Context", name)
+	if paramsType != "" {
+		fmt.Fprintf(b, ", params *%s", paramsType)
+	}
+	if bodyType != "" {
+		fmt.Fprintf(b, ", body %s", bodyType)
+	}
+	if resultType != "" {
+		fmt.Fprintf(b, ") (%s, error) {\nvar result %s\n", resultType, resultType)
+	} else {
+		b.WriteString(") error {\n")
+	}
+	if paramsType != "" {
+		fmt.Fprintf(b, "if params == nil {\nparams = &%s{}\n}\n", paramsType)
+	}
+
+	// the path
+	pathFields := map[string]string{}
+	byIn := map[string][]*goClientParameter{}
+	for _, p := range parameters {
+		if p.in == InPath {
+			pathFields[p.name] = p.field
+		}
+		byIn[p.in] = append(byIn[p.in], p)
+	}
+	pathExpression := []string{}
+	last := 0
+	for _, match := range swaggerPathParameterRegexp.FindAllStringSubmatchIndex(api.path, -1) {
+		if literal := api.path[last:match[0]]; literal != "" {
+			pathExpression = append(pathExpression, strconv.Quote(literal))
+		}
+		if field, ok := pathFields[api.path[match[2]:match[3]]]; ok {
+			pathExpression = append(pathExpression, "url.PathEscape(fmt.Sprint(params."+field+"))")
+		} else {
+			pathExpression = append(pathExpression, strconv.Quote(api.path[match[0]:match[1]]))
+		}
+		last = match[1]
+	}
+	if literal := api.path[last:]; literal != "" || len(pathExpression) == 0 {
+		pathExpression = append(pathExpression, strconv.Quote(literal))
+	}
+	fmt.Fprintf(b, "path := %s\n", strings.Join(pathExpression, " + "))
+
+	// the query and the headers
+	setValues := func(variable string, parameters []*goClientParameter) {
+		for _, p := range parameters {
+			if p.required {
+				fmt.Fprintf(b, "%s.Set(%q, fmt.Sprint(params.%s))\n", variable, p.name, p.field)
+			} else {
+				fmt.Fprintf(b, "if params.%s != nil {\n%s.Set(%q, fmt.Sprint(*params.%s))\n}\n", p.field, variable, p.name, p.field)
+			}
+		}
+	}
+	query, header := "nil", "nil"
+	if len(byIn[InQuery]) > 0 {
+		query = "query"
+		b.WriteString("query := url.Values{}\n")
+		setValues(query, byIn[InQuery])
+	}
+	if len(byIn[InHeader]) > 0 {
+		header = "header"
+		b.WriteString("header := http.Header{}\n")
+		setValues(header, byIn[InHeader])
+	}
+
+	// the body
+	body, contentType := "nil", `""`
+	hasFile := false
+	for _, p := range byIn[InFormData] {
+		hasFile = hasFile || p.isFile
+	}
+	switch {
+	case bodyType != "":
+		body, contentType = "reqBody", strconv.Quote(Application_Json)
+		fmt.Fprintf(b, "reqBody, err := newJSONBody(body)\nif err != nil {\n%s\n}\n", returnError("err"))
+	case hasFile:
+		body, contentType = "reqBody", "contentType"
+		b.WriteString("fields := map[string]string{}\nfiles := map[string]*File{}\n")
+		for _, p := range byIn[InFormData] {
+			switch {
+			case p.isFile:
+				fmt.Fprintf(b, "files[%q] = params.%s\n", p.name, p.field)
+			case p.required:
+				fmt.Fprintf(b, "fields[%q] = fmt.Sprint(params.%s)\n", p.name, p.field)
+			default:
+				fmt.Fprintf(b, "if params.%s != nil {\nfields[%q] = fmt.Sprint(*params.%s)\n}\n", p.field, p.name, p.field)
+			}
+		}
+		fmt.Fprintf(b, "reqBody, contentType, err := newMultipartBody(fields, files)\nif err != nil {\n%s\n}\n", returnError("err"))
+	case len(byIn[InFormData]) > 0:
+		body, contentType = "strings.NewReader(form.Encode())", `"application/x-www-form-urlencoded"`
+		b.WriteString("form := url.Values{}\n")
+		setValues("form", byIn[InFormData])
+	}
+
+	// the response
+	fmt.Fprintf(b, "status, data, err := c.do(ctx, %q, path, %s, %s, %s, %s)\nif err != nil {\n%s\n}\n",
+		api.method, query, header, body, contentType, returnError("err"))
+	b.WriteString("if status >= 200 && status < 300 {\n")
+	if resultType != "" {
+		b.WriteString("return result, decodeBody(data, &result)\n}\n")
+	} else {
+		b.WriteString("return nil\n}\n")
+	}
+	if len(errorModels) == 0 {
+		fmt.Fprintf(b, "%s\n}\n", returnError("&Error{StatusCode: status, Body: data}"))
+		return nil
+	}
+	b.WriteString("apiErr := &Error{StatusCode: status, Body: data}\nswitch status {\n")
+	codes := []int{}
+	for code := range errorModels {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(b, "case %d:\nvar model %s\nif json.Unmarshal(data, &model) == nil {\napiErr.Model = model\n}\n", code, errorModels[code])
+	}
+	fmt.Fprintf(b, "}\n%s\n}\n", returnError("apiErr"))
+	return nil
+}
+
+// getParameters the fields of the <Method>Params struct
+func (g *goClientGenerator) getParameters(e *Engine, api *registeredAPI) ([]*goClientParameter, error) {
+	apiParameters, err := e.getAPIParameters(api)
+	if err != nil {
+		return nil, err
+	}
+	parameters := []*goClientParameter{}
+	fields := map[string]bool{}
+	for _, apiParameter := range apiParameters {
+		valueInfo := apiParameter.valueInfo
+		p := &goClientParameter{
+			field:    toGoName(apiParameter.name),
+			name:     apiParameter.name,
+			in:       apiParameter.in,
+			typ:      valueInfo.Type,
+			required: apiParameter.isRequired(),
+			isFile:   valueInfo.Type == "file",
+			desc:     valueInfo.Desc,
+		}
+		if fields[p.field] {
+			p.field += toGoName(p.in)
+		}
+		fields[p.field] = true
+		switch {
+		case p.isFile:
+			p.typ = "*File"
+		case !p.required:
+			p.typ = "*" + p.typ
+		}
+		parameters = append(parameters, p)
+	}
+	return parameters, nil
+}
+
+// modelTypeString the type of a Model in the client, the structs are pointers (like: *models.Book)
+func (g *goClientGenerator) modelTypeString(model interface{}) string {
+	t := reflect.TypeOf(model)
+	if t.Kind() == reflect.Struct {
+		return "*" + g.typeString(t)
+	}
+	return g.typeString(t)
+}
+
+// typeString the type in the client, the interfaces are json.RawMessage
+func (g *goClientGenerator) typeString(t reflect.Type) string {
+	if t.Kind() == reflect.Interface {
+		return "json.RawMessage"
+	}
+	if t.Name() == "" || t.PkgPath() == "" {
+		return g.underlyingTypeString(t)
+	}
+	if isImportablePackage(t.PkgPath()) && token.IsExported(t.Name()) {
+		return g.importPackage(t.PkgPath()) + "." + t.Name()
+	}
+	return g.declareType(t)
+}
+
+func (g *goClientGenerator) underlyingTypeString(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeString(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeString(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeString(t.Elem()))
+	case reflect.Map:
+		return "map[" + g.typeString(t.Key()) + "]" + g.typeString(t.Elem())
+	case reflect.Interface:
+		return "json.RawMessage"
+	case reflect.Struct:
+		return g.structString(t)
+	}
+	return t.Kind().String()
+}
+
+// structString the struct with the exported fields and the json tags
+func (g *goClientGenerator) structString(t reflect.Type) string {
+	b := &bytes.Buffer{}
+	b.WriteString("struct {\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := ""
+		if value, ok := field.Tag.Lookup("json"); ok {
+			tag = " `json:" + strconv.Quote(value) + "`"
+		}
+		fmt.Fprintf(b, "%s %s%s\n", field.Name, g.typeString(field.Type), tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// declareType redeclare the type in the client, and return the name
+func (g *goClientGenerator) declareType(t reflect.Type) string {
+	if name, ok := g.types[t]; ok {
+		return name
+	}
+	name := g.getUniqueTypeName(toGoName(t.Name()))
+	g.types[t] = name
+	underlying := g.underlyingTypeString(t)
+	fmt.Fprintf(g.declarations, "\n// %s the model %s\ntype %s %s\n", name, t.String(), name, underlying)
+	return name
+}
+
+func (g *goClientGenerator) getUniqueTypeName(name string) string {
+	unique := name
+	for i := 2; g.typeNames[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.typeNames[unique] = true
+	return unique
+}
+
+// importPackage import the package, and return the alias
+func (g *goClientGenerator) importPackage(pkgPath string) string {
+	if alias, ok := g.imports[pkgPath]; ok {
+		return alias
+	}
+	name := notIdentifierRegexp.ReplaceAllString(path.Base(pkgPath), "_")
+	if name == "" || unicode.IsDigit([]rune(name)[0]) || token.Lookup(name).IsKeyword() {
+		name = "pkg" + name
+	}
+	alias := name
+	for i := 2; g.aliases[alias]; i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	g.aliases[alias] = true
+	g.imports[pkgPath] = alias
+	return alias
+}
+
+// isImportablePackage the main packages and the internal packages can't be imported by the client
+func isImportablePackage(pkgPath string) bool {
+	if pkgPath == "main" || strings.HasSuffix(pkgPath, "/main") {
+		return false
+	}
+	return pkgPath != "internal" && !strings.HasPrefix(pkgPath, "internal/") &&
+		!strings.Contains(pkgPath, "/internal/") && !strings.HasSuffix(pkgPath, "/internal")
+}
+
+var goInitialisms = map[string]string{
+	"api": "API", "html": "HTML", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON",
+	"uri": "URI", "url": "URL", "uuid": "UUID", "xml": "XML",
+}
+
+// toGoName the exported Go name of the string.
+// examples:
+//   getBooksId -> GetBooksId
+//   BookAPI_Get -> BookAPIGet
+//   page_size -> PageSize
+//   X-Request-Id -> XRequestID
+func toGoName(s string) string {
+	b := &bytes.Buffer{}
+	for _, word := range notIdentifierRegexp.Split(s, -1) {
+		if word == "" {
+			continue
+		}
+		if initialism, ok := goInitialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(upperFirstLetter(word))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// toLineComment the string in one line
+func toLineComment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func getSortedResponseCodes(responses map[int]Response) []int {
+	codes := []int{}
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+func getSortedStringKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
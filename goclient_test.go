@@ -0,0 +1,138 @@
+package ehttp
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testGoClientError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type testGoClientToken struct {
+	Token string             `json:"token"`
+	Error *testGoClientError `json:"error"`
+}
+
+func newTestGoClientEngine(t *testing.T) *Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{BasePath: "/v1", Title: "book store APIS"})
+	docGET := &APIDocCommon{
+		Summary:     "Get book info by id",
+		OperationID: "getBook",
+		Parameters: map[string]Parameter{
+			"id":           Parameter{InPath: &ValueInfo{Type: "string"}},
+			"X-Request-Id": Parameter{InHeader: &ValueInfo{Type: "string", Required: true}},
+			"page_size":    Parameter{InQuery: &ValueInfo{Type: "int32"}},
+		},
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation", Model: &testDocumentBook{}},
+			404: Response{Description: "not found", Model: &testGoClientError{}},
+		},
+	}
+	docLogin := &APIDocCommon{
+		OperationID: "login",
+		Parameters: map[string]Parameter{
+			"name":     Parameter{InFormData: &ValueInfo{Type: "string", Required: true}},
+			"remember": Parameter{InFormData: &ValueInfo{Type: "bool"}},
+		},
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation", Model: &testGoClientToken{}},
+		},
+	}
+	docDELETE := &APIDocCommon{
+		OperationID: "delete-book",
+		Deprecated:  true,
+		Parameters: map[string]Parameter{
+			"id": Parameter{InPath: &ValueInfo{Type: "int64"}},
+		},
+		Responses: map[int]Response{
+			204: Response{Description: "successful operation"},
+		},
+	}
+	handler := func(c *gin.Context, err error) {}
+	if err := router.GET("/books/:id", docGET, handler); err != nil {
+		testError(t, err)
+	}
+	if err := router.POST("/login", docLogin, handler); err != nil {
+		testError(t, err)
+	}
+	if err := router.DELETE("/books/:id", docDELETE, handler); err != nil {
+		testError(t, err)
+	}
+	return router
+}
+
+func TestEngine_GenerateGoClient(t *testing.T) {
+	source, err := newTestGoClientEngine(t).GenerateGoClient("client")
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "client.go", source, 0); err != nil {
+		testError(t, err)
+	}
+	for _, expected := range []string{
+		"package client",
+		`const BasePath = "/v1"`,
+		"func (c *Client) GetBook(ctx context.Context, params *GetBookParams) (*TestDocumentBook, error) {",
+		"XRequestID string",
+		"PageSize   *int32",
+		`header.Set("X-Request-Id", fmt.Sprint(params.XRequestID))`,
+		`path := "/books/" + url.PathEscape(fmt.Sprint(params.ID))`,
+		"case 404:\n\t\tvar model *TestGoClientError",
+		"func (c *Client) Login(ctx context.Context, params *LoginParams) (*TestGoClientToken, error) {",
+		`strings.NewReader(form.Encode()), "application/x-www-form-urlencoded"`,
+		"type TestGoClientToken struct {\n\tToken string             `json:\"token\"`\n\tError *TestGoClientError `json:\"error\"`\n}",
+		"// Deprecated: the operation is deprecated.\nfunc (c *Client) DeleteBook(ctx context.Context, params *DeleteBookParams) error {",
+	} {
+		if !strings.Contains(string(source), expected) {
+			testError(t, "the Go client should contain "+expected)
+		}
+	}
+	if _, err := newTestGoClientEngine(t).GenerateGoClient("1client"); err == nil {
+		testError(t, "the package name 1client should be invalid")
+	}
+}
+
+func TestGoClient_TypeString(t *testing.T) {
+	g := newGoClientGenerator()
+	for _, c := range []struct {
+		model    interface{}
+		expected string
+	}{
+		{"", "string"},
+		{[]string{}, "[]string"},
+		{map[string]int64{}, "map[string]int64"},
+		{&testDocumentBook{}, "*TestDocumentBook"},
+		{testDocumentBook{}, "*TestDocumentBook"},
+		{[]*testDocumentBook{}, "[]*TestDocumentBook"},
+		{&Config{}, "*ehttp.Config"},
+	} {
+		if typ := g.modelTypeString(c.model); typ != c.expected {
+			testError(t, "the type should be "+c.expected+", got "+typ)
+		}
+	}
+	if alias := g.imports["github.com/enjoy-web/ehttp"]; alias != "ehttp" {
+		testError(t, "the alias of github.com/enjoy-web/ehttp should be ehttp, got "+alias)
+	}
+}
+
+func TestToGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"getBooksId":   "GetBooksId",
+		"BookAPI_Get":  "BookAPIGet",
+		"page_size":    "PageSize",
+		"X-Request-Id": "XRequestID",
+		"2fa":          "X2fa",
+	} {
+		if goName := toGoName(name); goName != expected {
+			testError(t, name+" should be "+expected+", got "+goName)
+		}
+	}
+}
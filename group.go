@@ -0,0 +1,92 @@
+package ehttp
+
+import (
+	"path"
+	"strings"
+)
+
+// RouterGroup is a group of the APIs with a common path prefix and a group name.
+// The group name is the inferred tag of the APIs without tags, if the tag inference is opened (see Config.InferTags).
+// Create a RouterGroup by using Engine.Group(relativePath, name)
+type RouterGroup struct {
+	engine   *Engine
+	basePath string
+	name     string
+}
+
+// Group creates a new router group with the path prefix and the group name.
+func (e *Engine) Group(relativePath string, name string) *RouterGroup {
+	return &RouterGroup{engine: e, basePath: relativePath, name: name}
+}
+
+// Group creates a new router group in the group. If the name is empty, the name of the group is used.
+func (g *RouterGroup) Group(relativePath string, name string) *RouterGroup {
+	if name == "" {
+		name = g.name
+	}
+	return &RouterGroup{engine: g.engine, basePath: joinPaths(g.basePath, relativePath), name: name}
+}
+
+// GET is a shortcut for Engine.GET in the group.
+func (g *RouterGroup) GET(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
+	return g.engine.handleInGroup(GET, joinPaths(g.basePath, relativePath), g.name, doc, handlers)
+}
+
+// POST is a shortcut for Engine.POST in the group.
+func (g *RouterGroup) POST(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
+	return g.engine.handleInGroup(POST, joinPaths(g.basePath, relativePath), g.name, doc, handlers)
+}
+
+// PUT is a shortcut for Engine.PUT in the group.
+func (g *RouterGroup) PUT(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
+	return g.engine.handleInGroup(PUT, joinPaths(g.basePath, relativePath), g.name, doc, handlers)
+}
+
+// PATCH is a shortcut for Engine.PATCH in the group.
+func (g *RouterGroup) PATCH(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
+	return g.engine.handleInGroup(PATCH, joinPaths(g.basePath, relativePath), g.name, doc, handlers)
+}
+
+// DELETE is a shortcut for Engine.DELETE in the group.
+func (g *RouterGroup) DELETE(relativePath string, doc APIDoc, handlers ...HandlerFunc) error {
+	return g.engine.handleInGroup(DELETE, joinPaths(g.basePath, relativePath), g.name, doc, handlers)
+}
+
+// TagInferrer infers the tag of an API without tags.
+//   relativePath -- the path of the API (relative to the Config.BasePath)
+//   group -- the name of the RouterGroup, "" if the API is not in a group
+type TagInferrer func(relativePath string, group string) string
+
+// DefaultTagInferrer the tag is the group name, or the first path segment (like: /books/{id}, the tag is books)
+func DefaultTagInferrer(relativePath string, group string) string {
+	if group != "" {
+		return group
+	}
+	return getSwaggerTagFormPath(relativePath)
+}
+
+// inferTags set the inferred tag of the operation if the operation has no tags and the tag inference is opened.
+func (e *Engine) inferTags(relativePath string, group string, tags []string) []string {
+	if len(tags) > 0 || (!e.Conf.InferTags && e.Conf.TagInferrer == nil) {
+		return tags
+	}
+	inferrer := e.Conf.TagInferrer
+	if inferrer == nil {
+		inferrer = DefaultTagInferrer
+	}
+	if tag := inferrer(relativePath, group); tag != "" {
+		return []string{tag}
+	}
+	return tags
+}
+
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
@@ -0,0 +1,86 @@
+package ehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestJoinPaths(t *testing.T) {
+	tests := []struct {
+		Base     string
+		Relative string
+		Want     string
+	}{
+		{"/books", "", "/books"},
+		{"/books", "/:id", "/books/:id"},
+		{"/books/", "/{id}/", "/books/{id}/"},
+		{"", "/authors", "/authors"},
+	}
+	for index, test := range tests {
+		if p := joinPaths(test.Base, test.Relative); p != test.Want {
+			testError(t, index, p+" != "+test.Want)
+		}
+	}
+}
+
+func TestEngineInferTags(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{BasePath: "/v1", InferTags: true})
+	if err := router.GET("/books", &APIDocCommon{}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if err := router.POST("/books", &APIDocCommon{Tags: []string{"store"}}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	group := router.Group("/authors", "people")
+	if err := group.GET("/:id", newTestOperationDoc(""), testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if err := group.Group("/:id/books", "").GET("", newTestOperationDoc(""), testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	paths := router.Swagger.Paths
+	tags := [][]string{
+		paths["/books"].Get.Tags,
+		paths["/books"].Post.Tags,
+		paths["/authors/{id}"].Get.Tags,
+		paths["/authors/{id}/books"].Get.Tags,
+	}
+	expected := []string{"books", "store", "people", "people"}
+	for index := range expected {
+		if len(tags[index]) != 1 || tags[index][0] != expected[index] {
+			testError(t, index, tags[index], "should be", expected[index])
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(GET, "/v1/authors/1", nil)
+	router.GinEngine().ServeHTTP(w, req)
+	if w.Code != 200 {
+		testError(t, "the API in the group should be routed", w.Code)
+	}
+
+	// customizable inference function
+	router = NewEngine(&Config{TagInferrer: func(relativePath string, group string) string {
+		return strings.ToUpper(getSwaggerTagFormPath(relativePath))
+	}})
+	if err := router.GET("/books", &APIDocCommon{}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if tags := router.Swagger.Paths["/books"].Get.Tags; len(tags) != 1 || tags[0] != "BOOKS" {
+		testError(t, "the tag should be inferred by the TagInferrer", tags)
+	}
+
+	// the tag inference is closed
+	router = NewEngine(&Config{})
+	if err := router.GET("/books", &APIDocCommon{}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if tags := router.Swagger.Paths["/books"].Get.Tags; len(tags) != 0 {
+		testError(t, "the tag should not be inferred", tags)
+	}
+}
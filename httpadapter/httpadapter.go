@@ -0,0 +1,171 @@
+// Package httpadapter register the documented operations of an ehttp.Engine on a net/http *http.ServeMux,
+// with the Go 1.22 patterns (like: "GET /v1/books/{id}"), so the services not using gin get the documents and the validation too.
+// The requests are checked like gin: the parameters, the request body and CORS (see Config.AllowOrigin),
+// the error is passed to the handler. The mock mode and the validation of the responses are supported too (see ehttp.Route.Serve).
+//
+// example:
+//    router := ehttp.NewEngine(conf)
+//    mux := httpadapter.New(router, nil)
+//    mux.GET("/books/{id}", doc, func(w http.ResponseWriter, r *http.Request, err error) {
+//        if err != nil {
+//            http.Error(w, err.Error(), http.StatusBadRequest)
+//            return
+//        }
+//        fmt.Fprint(w, r.PathValue("id"))
+//    })
+//    http.ListenAndServe(":8000", mux)
+package httpadapter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/enjoy-web/ehttp"
+)
+
+// HandlerFunc the handler of a documented operation
+//   err -- the result of checking the request (if the request is valid, err == nil)
+type HandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+// ServeMux register the documented operations of the Engine on the *http.ServeMux
+// Fields:
+//   Engine -- the engine of the documents
+type ServeMux struct {
+	Engine     *ehttp.Engine
+	mux        *http.ServeMux
+	preflights map[string]bool
+}
+
+// New new a ServeMux of the engine, if mux is nil, a new *http.ServeMux is used.
+// The documents are served on the mux if the Config.OpenAPIDocumentURL is true (see ehttp.Engine.DocumentHandlers).
+func New(engine *ehttp.Engine, mux *http.ServeMux) *ServeMux {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+	m := &ServeMux{Engine: engine, mux: mux, preflights: map[string]bool{}}
+	if engine.Conf.OpenAPIDocumentURL {
+		for url, handler := range engine.DocumentHandlers() {
+			mux.HandleFunc(m.getPattern(ehttp.GET, url), handler)
+		}
+	}
+	return m
+}
+
+// ServeHTTP serve the requests by the *http.ServeMux
+func (m *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
+
+// GET register the documented operation GET on the path (like: /books/{id}, without the BasePath)
+func (m *ServeMux) GET(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return m.Handle(ehttp.GET, path, doc, handler)
+}
+
+// POST register the documented operation POST on the path
+func (m *ServeMux) POST(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return m.Handle(ehttp.POST, path, doc, handler)
+}
+
+// PUT register the documented operation PUT on the path
+func (m *ServeMux) PUT(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return m.Handle(ehttp.PUT, path, doc, handler)
+}
+
+// PATCH register the documented operation PATCH on the path
+func (m *ServeMux) PATCH(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return m.Handle(ehttp.PATCH, path, doc, handler)
+}
+
+// DELETE register the documented operation DELETE on the path
+func (m *ServeMux) DELETE(path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	return m.Handle(ehttp.DELETE, path, doc, handler)
+}
+
+// Handle register the documented operation on the path, the path is in the swagger style or the gin style (like: /books/{id}, /books/:id),
+// the values of the parameters in path are got by r.PathValue
+func (m *ServeMux) Handle(method string, path string, doc ehttp.APIDoc, handler HandlerFunc) error {
+	if handler == nil {
+		return errors.New(method + " " + path + " miss HandlerFunc")
+	}
+	// the operation is documented only if the *http.ServeMux accepts the pattern
+	route, err := m.Engine.RegisterRoute(method, path, doc, handler, func(route *ehttp.Route) error {
+		return m.handleFunc(m.getPattern(method, route.Path), func(w http.ResponseWriter, r *http.Request) {
+			route.Serve(NewContext(w, r, getPathValue), w, func(w http.ResponseWriter, err error) {
+				handler(w, r, err)
+			})
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if m.Engine.Conf.AllowOrigin && !m.preflights[route.Path] {
+		m.preflights[route.Path] = true
+		return m.handleFunc(m.getPattern(ehttp.OPTIONS, route.Path), PreflightHandler(m.Engine, route.Path, getPathValue))
+	}
+	return nil
+}
+
+// handleFunc register the handler on the *http.ServeMux, the conflicting or invalid patterns are errors (http.ServeMux panics)
+func (m *ServeMux) handleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	m.mux.HandleFunc(pattern, handler)
+	return nil
+}
+
+// getPattern the pattern of the *http.ServeMux (like: GET /v1/books/{id}),
+// the path ending with "/" matches the path only, like gin
+func (m *ServeMux) getPattern(method string, path string) string {
+	pattern := method + " " + strings.TrimRight(m.Engine.Conf.BasePath, "/") + path
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+	return pattern
+}
+
+// PreflightHandler the handler of the CORS preflight requests (OPTIONS) of the path (without the BasePath),
+// it is used by the adapters of the routers based on net/http (like: chi)
+func PreflightHandler(engine *ehttp.Engine, path string, param func(r *http.Request, name string) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := engine.CheckPreflight(NewContext(w, r, param), path); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte("{}"))
+	}
+}
+
+// NewContext new the ehttp.Context of net/http, the values of the parameters in path are got by param (like: chi.URLParam)
+func NewContext(w http.ResponseWriter, r *http.Request, param func(r *http.Request, name string) string) ehttp.Context {
+	return &context{w, r, param}
+}
+
+// getPathValue the value of the parameter in path of the *http.ServeMux
+func getPathValue(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+// context the ehttp.Context of net/http
+type context struct {
+	w     http.ResponseWriter
+	r     *http.Request
+	param func(r *http.Request, name string) string
+}
+
+func (c *context) Request() *http.Request {
+	return c.r
+}
+
+func (c *context) Param(name string) string {
+	return c.param(c.r, name)
+}
+
+func (c *context) ResponseHeader() http.Header {
+	return c.w.Header()
+}
@@ -0,0 +1,166 @@
+package httpadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp"
+)
+
+type book struct {
+	ID    string `json:"id" readonly:"true"`
+	Title string `json:"title"`
+}
+
+func newTestServeMux(t *testing.T, conf *ehttp.Config) *ServeMux {
+	mux := New(ehttp.NewEngine(conf), nil)
+	handler := func(w http.ResponseWriter, r *http.Request, err error) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(r.PathValue("id") + ":" + r.URL.Query().Get("limit")))
+	}
+	getBook := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{
+			"id":    ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}},
+			"limit": ehttp.Parameter{InQuery: &ehttp.ValueInfo{Type: "int32", Max: "10"}},
+		},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := mux.GET("/books/{id}", getBook, handler); err != nil {
+		t.Fatal(err)
+	}
+	createBook := &ehttp.APIDocCommon{
+		Request:   &ehttp.Request{Model: &book{}},
+		Responses: map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := mux.POST("/books", createBook, handler); err != nil {
+		t.Fatal(err)
+	}
+	return mux
+}
+
+func serve(mux *ServeMux, method string, url string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestServeMux(t *testing.T) {
+	mux := newTestServeMux(t, &ehttp.Config{BasePath: "/v1", OpenAPIDocumentURL: true})
+	nodes := []struct {
+		method string
+		url    string
+		body   string
+		code   int
+		resp   string
+	}{
+		{"GET", "/v1/books/1?limit=2", "", 200, "1:2"},
+		{"GET", "/v1/books/abc", "", 400, "invalid syntax"},
+		{"GET", "/v1/books/1?limit=11", "", 400, "greater than the maximum"},
+		{"POST", "/v1/books", `{"title":"Go"}`, 200, ":"},
+		{"POST", "/v1/books", `{"id":"1","title":"Go"}`, 400, "read only"},
+		{"GET", "/v1/authors", "", 404, ""},
+	}
+	for _, node := range nodes {
+		w := serve(mux, node.method, node.url, node.body, map[string]string{"Content-Type": "application/json"})
+		if w.Code != node.code || !strings.Contains(w.Body.String(), node.resp) {
+			t.Error(node.method, node.url, "unexpected response", w.Code, w.Body.String())
+		}
+	}
+	w := serve(mux, "GET", "/v1/docs/swagger.json", "", nil)
+	if w.Code != 200 || !strings.Contains(w.Body.String(), `"/books/{id}"`) || !strings.Contains(w.Body.String(), `"host": "example.com"`) {
+		t.Error("the document should be served", w.Code, w.Body.String())
+	}
+	if err := mux.GET("/books/{id}", nil, func(w http.ResponseWriter, r *http.Request, err error) {}); err == nil {
+		t.Error("the conflicting pattern should be an error")
+	}
+}
+
+func TestServeMux_RejectedPattern(t *testing.T) {
+	mux := newTestServeMux(t, &ehttp.Config{OpenAPIDocumentURL: true})
+	getBook := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"name": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "string"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok"}},
+	}
+	if err := mux.GET("/books/{name}", getBook, func(w http.ResponseWriter, r *http.Request, err error) {}); err == nil {
+		t.Error("the conflicting pattern should be an error")
+	}
+	if _, ok := mux.Engine.Swagger.Paths["/books/{name}"]; ok {
+		t.Error("the operation of the rejected pattern should not be documented")
+	}
+	w := serve(mux, "GET", "/docs/reference.md", "", nil)
+	if w.Code != 200 || strings.Contains(w.Body.String(), "/books/{name}") || !strings.Contains(w.Body.String(), "/books/{id}") {
+		t.Error("the reference should be served without the rejected operation", w.Code, w.Body.String())
+	}
+}
+
+func TestServeMux_CORS(t *testing.T) {
+	mux := newTestServeMux(t, &ehttp.Config{AllowOrigin: true, Origins: []string{"http://a.com"}})
+	w := serve(mux, "OPTIONS", "/books/1", "", map[string]string{"Origin": "http://a.com"})
+	if w.Code != 200 || w.Header().Get("Access-Control-Allow-Origin") != "http://a.com" || w.Header().Get("Access-Control-Allow-Methods") != "GET" {
+		t.Error("the preflight request should be allowed", w.Code, w.Header())
+	}
+	w = serve(mux, "GET", "/books/1", "", map[string]string{"Origin": "http://b.com"})
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "is not allow") {
+		t.Error("the origin should not be allowed", w.Code, w.Body.String())
+	}
+}
+
+func TestServeMux_MockMode(t *testing.T) {
+	mux := New(ehttp.NewEngine(&ehttp.Config{MockMode: true}), nil)
+	doc := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok", Model: &book{}}},
+	}
+	err := mux.GET("/books/{id}", doc, func(w http.ResponseWriter, r *http.Request, err error) {
+		t.Error("the handler should not be called in the mock mode")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := serve(mux, "GET", "/books/1", "", nil)
+	if w.Code != 200 || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") || !strings.Contains(w.Body.String(), `"title"`) {
+		t.Error("the mock response should be written", w.Code, w.Header(), w.Body.String())
+	}
+	if w := serve(mux, "GET", "/books/abc", "", nil); w.Code != 400 {
+		t.Error("the invalid parameter should be 400", w.Code, w.Body.String())
+	}
+}
+
+func TestServeMux_StrictResponses(t *testing.T) {
+	violations := []*ehttp.ResponseViolation{}
+	mux := New(ehttp.NewEngine(&ehttp.Config{
+		StrictResponses:       true,
+		ResponseViolationHook: func(v *ehttp.ResponseViolation) { violations = append(violations, v) },
+	}), nil)
+	doc := &ehttp.APIDocCommon{
+		Parameters: map[string]ehttp.Parameter{"id": ehttp.Parameter{InPath: &ehttp.ValueInfo{Type: "int64"}}},
+		Responses:  map[int]ehttp.Response{200: ehttp.Response{Description: "ok", Model: &book{}}},
+	}
+	err := mux.GET("/books/{id}", doc, func(w http.ResponseWriter, r *http.Request, err error) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("id") == "1" {
+			w.Write([]byte(`{"id":"1","title":"Go"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"2","title":2}`))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(mux, "GET", "/books/1", "", nil); w.Code != 200 || w.Body.String() != `{"id":"1","title":"Go"}` {
+		t.Error("the valid response should be written", w.Code, w.Body.String())
+	}
+	w := serve(mux, "GET", "/books/2", "", nil)
+	if w.Code != 500 || strings.Contains(w.Body.String(), `"title":2`) || len(violations) != 1 {
+		t.Error("the invalid response should be replaced by 500", w.Code, w.Body.String(), violations)
+	}
+}
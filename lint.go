@@ -0,0 +1,33 @@
+package ehttp
+
+import (
+	"fmt"
+
+	"github.com/enjoy-web/ehttp/lint"
+)
+
+// Lint check the swagger document with the rules of Config.LintRules (all the rules if it is empty)
+func (e *Engine) Lint() ([]*lint.Problem, error) {
+	return lint.Lint(e.Swagger, e.Conf.LintRules...)
+}
+
+// lintOnStartup log the problems as warnings, and panic in the strict mode
+func (e *Engine) lintOnStartup() {
+	if !e.Conf.Lint && !e.Conf.StrictLint {
+		return
+	}
+	problems, err := e.Lint()
+	if err != nil {
+		if e.Conf.StrictLint {
+			panic("ehttp: " + err.Error())
+		}
+		logWarning("Config " + err.Error())
+		return
+	}
+	for _, problem := range problems {
+		logWarning("lint " + problem.String())
+	}
+	if e.Conf.StrictLint && len(problems) > 0 {
+		panic(fmt.Sprintf("ehttp: %d lint problems in the strict mode", len(problems)))
+	}
+}
@@ -0,0 +1,147 @@
+// Package lint check the style of the swagger document, beyond the structural checks of the engine.
+package lint
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Problem a problem found by a rule
+// Fields:
+//   Rule -- the name of the rule
+//   Location -- like "GET /books/{id}" or "definition Book"
+//   Message -- the description of the problem
+type Problem struct {
+	Rule     string
+	Location string
+	Message  string
+}
+
+func (p *Problem) String() string {
+	return "[" + p.Rule + "] " + p.Location + ": " + p.Message
+}
+
+// Rule a lint rule
+type Rule struct {
+	Name        string
+	Description string
+	check       func(doc *swagger.Swagger) []*Problem
+}
+
+// Rules all the rules
+func Rules() []*Rule {
+	result := make([]*Rule, len(rules))
+	copy(result, rules)
+	return result
+}
+
+// RuleNames the names of all the rules
+func RuleNames() []string {
+	names := []string{}
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// Lint check the document with the enabled rules, all the rules are enabled if ruleNames is empty.
+// The problems are sorted by the order of the rules.
+func Lint(doc *swagger.Swagger, ruleNames ...string) ([]*Problem, error) {
+	if doc == nil {
+		return nil, errors.New("the swagger document is nil")
+	}
+	enabledRules, err := getRules(ruleNames)
+	if err != nil {
+		return nil, err
+	}
+	problems := []*Problem{}
+	for _, rule := range enabledRules {
+		problems = append(problems, rule.check(doc)...)
+	}
+	return problems, nil
+}
+
+func getRules(ruleNames []string) ([]*Rule, error) {
+	if len(ruleNames) == 0 {
+		return rules, nil
+	}
+	enabled := map[string]bool{}
+	for _, name := range ruleNames {
+		if getRule(name) == nil {
+			return nil, errors.New("unknown lint rule " + name + ", the rules are " + strings.Join(RuleNames(), ", "))
+		}
+		enabled[name] = true
+	}
+	result := []*Rule{}
+	for _, rule := range rules {
+		if enabled[rule.Name] {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func getRule(name string) *Rule {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+type operationInfo struct {
+	method    string
+	path      string
+	operation *swagger.Operation
+}
+
+func (o *operationInfo) location() string {
+	return o.method + " " + o.path
+}
+
+// getOperations the operations of the document, sorted by the path and the method
+func getOperations(doc *swagger.Swagger) []*operationInfo {
+	paths := []string{}
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	operations := []*operationInfo{}
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if item == nil {
+			continue
+		}
+		methods := []struct {
+			method    string
+			operation *swagger.Operation
+		}{
+			{"GET", item.Get},
+			{"PUT", item.Put},
+			{"POST", item.Post},
+			{"DELETE", item.Delete},
+			{"OPTIONS", item.Options},
+			{"HEAD", item.Head},
+			{"PATCH", item.Patch},
+		}
+		for _, m := range methods {
+			if m.operation != nil {
+				operations = append(operations, &operationInfo{method: m.method, path: path, operation: m.operation})
+			}
+		}
+	}
+	return operations
+}
+
+func getSortedDefinitionNames(doc *swagger.Swagger) []string {
+	names := []string{}
+	for name := range doc.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testDocument = `{
+  "swagger": "2.0",
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "summary": "Get a book",
+        "description": "Get a book by the id",
+        "operationId": "getBook",
+        "parameters": [
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all", "brief"]},
+          {"name": "pageSize", "in": "query", "type": "integer"},
+          {"name": "sortBy", "in": "query", "type": "string", "description": "sort by"}
+        ],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}},
+          "404": {"description": "not found"}
+        }
+      }
+    },
+    "/books": {
+      "get": {
+        "operationId": "getBook",
+        "parameters": [
+          {"name": "page_size", "in": "query", "type": "integer"}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "publishedAt": {"type": "string"},
+        "status": {"type": "string", "enum": ["on", "off"], "description": "the status"},
+        "author": {"$ref": "#/definitions/Author"}
+      }
+    },
+    "Author": {
+      "type": "object",
+      "properties": {
+        "first_name": {"type": "string"},
+        "lastName": {"type": "string"},
+        "gender": {"type": "string", "enum": ["male", "female"]}
+      }
+    },
+    "Store": {"type": "object"}
+  }
+}`
+
+func TestLint(t *testing.T) {
+	doc := &swagger.Swagger{}
+	if err := json.Unmarshal([]byte(testDocument), doc); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := Lint(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, problem := range problems {
+		got[problem.String()] = true
+		t.Log(problem)
+	}
+	expected := []string{
+		"[operation-summary] GET /books: the summary is missing",
+		"[operation-description] GET /books: the description is missing",
+		"[operation-4xx-response] GET /books: there is no 4xx response",
+		"[path-parameters] GET /books/{id}: the path parameter id is not documented",
+		"[query-parameter-naming] GET /books: the query parameter page_size is snake_case, but most of the query parameters are camelCase",
+		"[property-naming] definition Author: the property first_name is snake_case, but most of the properties are camelCase",
+		"[enum-description] GET /books/{id}: the query parameter fields has an enum without a description",
+		"[enum-description] definition Author: the property gender has an enum without a description",
+		"[operation-id-unique] GET /books/{id}: the operationId getBook is used by GET /books",
+		"[unused-definition] definition Store: the definition is not used",
+	}
+	for _, problem := range expected {
+		if !got[problem] {
+			t.Error("missing problem: " + problem)
+		}
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, got %d", len(expected), len(problems))
+	}
+
+	problems, err = Lint(doc, "unused-definition")
+	if err != nil || len(problems) != 1 {
+		t.Error("only the enabled rules should be checked", problems, err)
+	}
+	if _, err := Lint(doc, "unknown"); err == nil {
+		t.Error("the unknown rule should be an error")
+	}
+}
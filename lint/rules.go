@@ -0,0 +1,289 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+var rules = []*Rule{
+	&Rule{Name: "operation-summary", Description: "the operations should have a summary", check: checkOperationSummary},
+	&Rule{Name: "operation-description", Description: "the operations should have a description", check: checkOperationDescription},
+	&Rule{Name: "operation-4xx-response", Description: "the operations should have at least one 4xx response", check: checkOperation4xxResponse},
+	&Rule{Name: "path-parameters", Description: "the path parameters should be documented", check: checkPathParameters},
+	&Rule{Name: "query-parameter-naming", Description: "the query parameters should use the same naming style (snake_case, camelCase ...)", check: checkQueryParameterNaming},
+	&Rule{Name: "property-naming", Description: "the properties of the models should use the same naming style (snake_case, camelCase ...)", check: checkPropertyNaming},
+	&Rule{Name: "enum-description", Description: "the parameters and the properties with an enum should have a description", check: checkEnumDescription},
+	&Rule{Name: "operation-id-unique", Description: "the operationIds should be unique", check: checkOperationIDUnique},
+	&Rule{Name: "unused-definition", Description: "the definitions should be used by the operations", check: checkUnusedDefinition},
+}
+
+func checkOperationSummary(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	for _, o := range getOperations(doc) {
+		if strings.TrimSpace(o.operation.Summary) == "" {
+			problems = append(problems, &Problem{"operation-summary", o.location(), "the summary is missing"})
+		}
+	}
+	return problems
+}
+
+func checkOperationDescription(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	for _, o := range getOperations(doc) {
+		if strings.TrimSpace(o.operation.Description) == "" {
+			problems = append(problems, &Problem{"operation-description", o.location(), "the description is missing"})
+		}
+	}
+	return problems
+}
+
+func checkOperation4xxResponse(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	for _, o := range getOperations(doc) {
+		has4xx := false
+		for code := range o.operation.Responses {
+			if strings.HasPrefix(code, "4") {
+				has4xx = true
+			}
+		}
+		if !has4xx {
+			problems = append(problems, &Problem{"operation-4xx-response", o.location(), "there is no 4xx response"})
+		}
+	}
+	return problems
+}
+
+var pathParameterRegexp = regexp.MustCompile(`\{([^}]*)\}`)
+
+func checkPathParameters(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	for _, o := range getOperations(doc) {
+		documented := map[string]bool{}
+		for _, parameter := range getParameters(doc, o.operation) {
+			if parameter.In == "path" {
+				documented[parameter.Name] = true
+			}
+		}
+		for _, match := range pathParameterRegexp.FindAllStringSubmatch(o.path, -1) {
+			if !documented[match[1]] {
+				problems = append(problems, &Problem{"path-parameters", o.location(), "the path parameter " + match[1] + " is not documented"})
+			}
+			delete(documented, match[1])
+		}
+		for _, name := range getSortedKeys(documented) {
+			problems = append(problems, &Problem{"path-parameters", o.location(), "the path parameter " + name + " is not in the path"})
+		}
+	}
+	return problems
+}
+
+// getParameters the parameters of the operation, the $refs are resolved
+func getParameters(doc *swagger.Swagger, operation *swagger.Operation) []*swagger.Parameter {
+	parameters := []*swagger.Parameter{}
+	for _, parameter := range operation.Parameters {
+		if parameter != nil && parameter.Ref != "" {
+			parameter = doc.Parameters[strings.TrimPrefix(parameter.Ref, "#/parameters/")]
+		}
+		if parameter != nil {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+func checkQueryParameterNaming(doc *swagger.Swagger) []*Problem {
+	names := []*namedLocation{}
+	for _, o := range getOperations(doc) {
+		for _, parameter := range getParameters(doc, o.operation) {
+			if parameter.In == "query" {
+				names = append(names, &namedLocation{o.location(), parameter.Name, "the query parameter " + parameter.Name})
+			}
+		}
+	}
+	return checkNaming("query-parameter-naming", "query parameters", names)
+}
+
+func checkPropertyNaming(doc *swagger.Swagger) []*Problem {
+	names := []*namedLocation{}
+	for _, definitionName := range getSortedDefinitionNames(doc) {
+		location := "definition " + definitionName
+		walkProperties(getDefinitionProperties(doc, definitionName), "", func(path string, propertie *swagger.Propertie) {
+			name := path[strings.LastIndex(path, ".")+1:]
+			names = append(names, &namedLocation{location, name, "the property " + path})
+		})
+	}
+	return checkNaming("property-naming", "properties", names)
+}
+
+type namedLocation struct {
+	location string
+	name     string
+	subject  string
+}
+
+// naming styles, the order is the priority when the counts are same
+var namingStyles = []string{"camelCase", "snake_case", "kebab-case", "PascalCase"}
+
+// checkNaming the names of which the style is not the most used style are problems
+func checkNaming(ruleName string, kind string, names []*namedLocation) []*Problem {
+	counts := map[string]int{}
+	for _, n := range names {
+		counts[getNamingStyle(n.name)]++
+	}
+	mostUsed := ""
+	for _, style := range namingStyles {
+		if counts[style] > 0 && (mostUsed == "" || counts[style] > counts[mostUsed]) {
+			mostUsed = style
+		}
+	}
+	problems := []*Problem{}
+	for _, n := range names {
+		if style := getNamingStyle(n.name); style != "" && style != mostUsed {
+			message := fmt.Sprintf("%s is %s, but most of the %s are %s", n.subject, style, kind, mostUsed)
+			problems = append(problems, &Problem{ruleName, n.location, message})
+		}
+	}
+	return problems
+}
+
+// getNamingStyle the naming style, empty if the name is a lower case word (like "id")
+func getNamingStyle(name string) string {
+	hasUpper := false
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			hasUpper = true
+		}
+	}
+	switch {
+	case strings.Contains(name, "_"):
+		return "snake_case"
+	case strings.Contains(name, "-"):
+		return "kebab-case"
+	case name != "" && unicode.IsUpper([]rune(name)[0]):
+		return "PascalCase"
+	case hasUpper:
+		return "camelCase"
+	}
+	return ""
+}
+
+func checkEnumDescription(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	for _, o := range getOperations(doc) {
+		for _, parameter := range getParameters(doc, o.operation) {
+			if len(parameter.Enum) > 0 && strings.TrimSpace(parameter.Description) == "" {
+				message := "the " + parameter.In + " parameter " + parameter.Name + " has an enum without a description"
+				problems = append(problems, &Problem{"enum-description", o.location(), message})
+			}
+		}
+	}
+	for _, definitionName := range getSortedDefinitionNames(doc) {
+		location := "definition " + definitionName
+		walkProperties(getDefinitionProperties(doc, definitionName), "", func(path string, propertie *swagger.Propertie) {
+			if len(propertie.Enum) > 0 && strings.TrimSpace(propertie.Description) == "" {
+				problems = append(problems, &Problem{"enum-description", location, "the property " + path + " has an enum without a description"})
+			}
+		})
+	}
+	return problems
+}
+
+func getDefinitionProperties(doc *swagger.Swagger, name string) map[string]*swagger.Propertie {
+	if definition := doc.Definitions[name]; definition != nil {
+		return definition.Properties
+	}
+	return nil
+}
+
+// walkProperties walk the properties (sorted by the names) and the nested properties, the path is like "author.name"
+func walkProperties(properties map[string]*swagger.Propertie, prefix string, walk func(path string, propertie *swagger.Propertie)) {
+	names := []string{}
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propertie := properties[name]
+		if propertie == nil {
+			continue
+		}
+		path := prefix + name
+		walk(path, propertie)
+		walkProperties(propertie.Properties, path+".", walk)
+		if propertie.Items != nil {
+			walkProperties(propertie.Items.Properties, path+"[].", walk)
+		}
+	}
+}
+
+func checkOperationIDUnique(doc *swagger.Swagger) []*Problem {
+	problems := []*Problem{}
+	locations := map[string]string{}
+	for _, o := range getOperations(doc) {
+		id := o.operation.OperationID
+		if id == "" {
+			continue
+		}
+		if location, ok := locations[id]; ok {
+			problems = append(problems, &Problem{"operation-id-unique", o.location(), "the operationId " + id + " is used by " + location})
+			continue
+		}
+		locations[id] = o.location()
+	}
+	return problems
+}
+
+var definitionRefRegexp = regexp.MustCompile(`"#/definitions/([^"]+)"`)
+
+// checkUnusedDefinition the definitions which can't be reached from the operations are unused
+func checkUnusedDefinition(doc *swagger.Swagger) []*Problem {
+	used := map[string]bool{}
+	queue := getDefinitionRefs(doc.Paths)
+	queue = append(queue, getDefinitionRefs(doc.Parameters)...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		if definition, ok := doc.Definitions[name]; ok {
+			queue = append(queue, getDefinitionRefs(definition)...)
+		}
+	}
+	problems := []*Problem{}
+	for _, name := range getSortedDefinitionNames(doc) {
+		if !used[name] {
+			problems = append(problems, &Problem{"unused-definition", "definition " + name, "the definition is not used"})
+		}
+	}
+	return problems
+}
+
+// getDefinitionRefs the names of the definitions referred by $ref, allOf, x-oneOf, x-anyOf and x-discriminator-mapping
+func getDefinitionRefs(v interface{}) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, match := range definitionRefRegexp.FindAllStringSubmatch(string(data), -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func getSortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
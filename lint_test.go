@@ -0,0 +1,48 @@
+package ehttp
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEngine_Lint(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{StrictLint: true, LintRules: []string{"operation-summary", "operation-4xx-response"}})
+	doc := &APIDocCommon{
+		Summary: "Get the books",
+		Responses: map[int]Response{
+			200: Response{Description: "successful operation"},
+			400: Response{Description: "failed operation"},
+		},
+	}
+	if err := router.GET("/books", doc, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	problems, err := router.Lint()
+	if err != nil || len(problems) != 0 {
+		testError(t, "the document should have no problems", problems, err)
+	}
+	router.lintOnStartup()
+
+	if err := router.GET("/authors", &APIDocCommon{Responses: map[int]Response{200: Response{Description: "successful operation"}}}, testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	problems, err = router.Lint()
+	if err != nil || len(problems) != 2 || problems[0].Rule != "operation-summary" || problems[1].Rule != "operation-4xx-response" {
+		testError(t, "GET /authors should have no summary and no 4xx response", problems, err)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				testError(t, "the strict mode should panic if there are problems")
+			}
+		}()
+		router.lintOnStartup()
+	}()
+
+	router.Conf.LintRules = []string{"unknown"}
+	if _, err := router.Lint(); err == nil {
+		testError(t, "the unknown rule should be an error")
+	}
+}
@@ -0,0 +1,244 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/enjoy-web/ehttp/mock"
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// engineMocker the Mocker of the engine in the mock mode, it is created at the first request,
+// so the document is complete (all the operations and definitions are registered).
+type engineMocker struct {
+	once   sync.Once
+	mocker *mock.Mocker
+}
+
+func (e *Engine) getMocker() *mock.Mocker {
+	e.mocker.once.Do(func() {
+		e.mocker.mocker = mock.New(e.Swagger, e.Conf.MockSeed)
+	})
+	return e.mocker.mocker
+}
+
+// writeMockResponse write the response synthesized from the documented responses of the operation (see Config.MockMode).
+// If the parameters are invalid (err is not nil), it writes 400 with the error. The operations of the engine share one Mocker.
+func (e *Engine) writeMockResponse(w http.ResponseWriter, r *http.Request, operation *swagger.Operation, err error) {
+	if err != nil {
+		writeText(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	response, err := e.getMocker().Respond(operation, r)
+	if err != nil {
+		writeText(w, http.StatusNotImplemented, err.Error())
+		return
+	}
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	if response.Body == nil {
+		w.WriteHeader(response.StatusCode)
+		return
+	}
+	data, err := json.Marshal(response.Body)
+	if err != nil {
+		writeText(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(response.StatusCode)
+	w.Write(data)
+}
+
+// writeText write the plain text response, like gin.Context.String
+func writeText(w http.ResponseWriter, statusCode int, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	w.Write([]byte(text))
+}
+
+// HandleDocument register the operations of a swagger document with the handler, it serves an existing document
+// (like: the mock server of the document, see Config.MockMode and the command `ehttp mock`).
+// The parameters (not in body) are validated by the ValueInfos converted from the swagger parameters, the bodies are not validated.
+// The references of the global parameters are inlined, the basePath of the document is ignored (see Config.BasePath).
+// The operations HEAD and OPTIONS are not registered.
+func (e *Engine) HandleDocument(doc *swagger.Swagger, handler HandlerFunc) error {
+	paths := []string{}
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if item == nil {
+			continue
+		}
+		ginPath, err := swaggerPathToGinPath(path)
+		if err != nil {
+			return err
+		}
+		for _, o := range []struct {
+			method    string
+			operation *swagger.Operation
+		}{
+			{GET, item.Get},
+			{POST, item.Post},
+			{PUT, item.Put},
+			{PATCH, item.Patch},
+			{DELETE, item.Delete},
+		} {
+			if o.operation == nil {
+				continue
+			}
+			apiDoc, err := newDocumentAPIDoc(doc, o.operation)
+			if err != nil {
+				return &engineError{path, o.method, err}
+			}
+			if err := e.handle(o.method, ginPath, apiDoc, []HandlerFunc{handler}); err != nil {
+				return err
+			}
+		}
+		if item.Head != nil || item.Options != nil {
+			log.Printf("[ehttp-warning] the operations HEAD and OPTIONS of %s are not registered\n", path)
+		}
+	}
+	return nil
+}
+
+// documentAPIDoc the APIDoc of an operation of a swagger document
+type documentAPIDoc struct {
+	operation   *swagger.Operation
+	definitions map[string]*swagger.Schema
+	parameters  map[string]Parameter
+}
+
+func newDocumentAPIDoc(doc *swagger.Swagger, operation *swagger.Operation) (*documentAPIDoc, error) {
+	op := *operation
+	op.Parameters = []*swagger.Parameter{}
+	parameters := map[string]Parameter{}
+	for _, p := range operation.Parameters {
+		if p == nil {
+			continue
+		}
+		if p.Ref != "" {
+			name := strings.TrimPrefix(p.Ref, "#/parameters/")
+			if doc.Parameters[name] == nil {
+				return nil, errors.New("the ref " + p.Ref + " is not found")
+			}
+			p = doc.Parameters[name]
+		}
+		op.Parameters = append(op.Parameters, p)
+		valueInfo := toValueInfo(p)
+		if valueInfo == nil {
+			continue
+		}
+		parameter := parameters[p.Name]
+		switch p.In {
+		case InPath:
+			parameter.InPath = valueInfo
+		case InHeader:
+			parameter.InHeader = valueInfo
+		case InQuery:
+			parameter.InQuery = valueInfo
+		case InFormData:
+			parameter.InFormData = valueInfo
+		}
+		parameters[p.Name] = parameter
+	}
+	return &documentAPIDoc{operation: &op, definitions: doc.Definitions, parameters: parameters}, nil
+}
+
+// toValueInfo the ValueInfo validating the swagger parameter (not in body), nil for the body.
+// The arrays are validated as strings, the enums which can't be declared are not validated.
+func toValueInfo(p *swagger.Parameter) *ValueInfo {
+	if p.In == "body" {
+		return nil
+	}
+	v := &ValueInfo{Type: "string", Required: p.Required}
+	switch p.Type {
+	case "integer":
+		v.Type = "int64"
+		if p.Format == "int32" {
+			v.Type = "int32"
+		}
+	case "number":
+		v.Type = "float64"
+	case "boolean":
+		v.Type = "bool"
+	case "file":
+		v.Type = "file"
+	}
+	if v.Type == "string" && p.Type == "string" || strings.HasPrefix(v.Type, "int") {
+		enum := []string{}
+		for _, value := range p.Enum {
+			s := formatDocumentValue(value)
+			if s == "" || strings.ContainsAny(s, " \t\r\n") {
+				enum = nil
+				break
+			}
+			enum = append(enum, s)
+		}
+		v.Enum = strings.Join(enum, " ")
+	}
+	if v.Enum == "" && (strings.HasPrefix(v.Type, "int") || strings.HasPrefix(v.Type, "float")) {
+		if p.Minimum != nil {
+			v.Min = formatDocumentValue(*p.Minimum)
+		}
+		if p.Maximum != nil {
+			v.Max = formatDocumentValue(*p.Maximum)
+		}
+	}
+	if v.Type == "string" && p.Type == "string" {
+		if p.MinLength != nil {
+			v.MinLen = strconv.FormatInt(*p.MinLength, 10)
+		}
+		if p.MaxLength != nil {
+			v.MaxLen = strconv.FormatInt(*p.MaxLength, 10)
+		}
+	}
+	if v.check() != nil {
+		v.Enum, v.Min, v.Max = "", "", ""
+	}
+	return v
+}
+
+// formatDocumentValue format the JSON value of the document (like: 1, 1.5, abc)
+func formatDocumentValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(value)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	}
+	return ""
+}
+
+func (doc *documentAPIDoc) ToSwaggerOperation() (*swagger.Operation, error) {
+	operation := *doc.operation
+	return &operation, nil
+}
+
+func (doc *documentAPIDoc) ToSwaggerDefinitions() (map[string]*swagger.Schema, error) {
+	return doc.definitions, nil
+}
+
+func (doc *documentAPIDoc) GetParameters() map[string]Parameter {
+	return doc.parameters
+}
+
+func (doc *documentAPIDoc) GetRequest() *Request {
+	return nil
+}
+
+func (doc *documentAPIDoc) SetMethod(method string) {}
@@ -0,0 +1,417 @@
+// Package mock synthesize the responses of the operations of a swagger document, it is the mock server of the document.
+// The values are the examples, the default values, the random values of the enums, or the random values in the ranges
+// (minimum, maximum, minLength, maxLength). The random values are deterministic: the same seed and the same request
+// synthesize the same response.
+package mock
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const swaggerDefinitions = "#/definitions/"
+
+// PreferHeader the request header to select the status code and the seed of the response.
+// examples:
+//   Prefer: code=404
+//   Prefer: code=200, seed=7
+const PreferHeader = "Prefer"
+
+// Response a synthesized response
+// Fields:
+//   StatusCode -- the status code of the response
+//   Headers -- the documented headers of the response
+//   Body -- the JSON value of the body, nil if the response has no schema
+type Response struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       interface{}
+}
+
+// Mocker synthesize the responses of the operations of the document
+type Mocker struct {
+	doc  *swagger.Swagger
+	seed int64
+}
+
+// New a Mocker of the document, the random values are seeded by the seed
+func New(doc *swagger.Swagger, seed int64) *Mocker {
+	return &Mocker{doc: doc, seed: seed}
+}
+
+// Respond synthesize the response of the operation for the request.
+// The status code is the code of the header Prefer, or the lowest 2xx code of the responses (the lowest code if there is no 2xx code).
+// If the code is not documented, the response "default" is used.
+// The body is the JSON example of the response, or synthesized from the schema.
+func (m *Mocker) Respond(operation *swagger.Operation, r *http.Request) (*Response, error) {
+	prefer := getPrefer(r)
+	code, response, err := selectResponse(operation, prefer["code"])
+	if err != nil {
+		return nil, err
+	}
+	seed := m.seed
+	if s, ok := prefer["seed"]; ok {
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, errors.New("invalid seed " + s + " in the header " + PreferHeader)
+		}
+	}
+	g := m.newGenerator(seed, r.Method+" "+r.URL.RequestURI()+" "+strconv.Itoa(code))
+	result := &Response{StatusCode: code, Headers: map[string]string{}}
+	for _, name := range sortedKeys(response.Headers) {
+		if header := response.Headers[name]; header != nil {
+			result.Headers[name] = fmt.Sprint(g.getHeaderValue(header))
+		}
+	}
+	if example, ok := getJSONExample(response.Examples); ok {
+		result.Body = example
+	} else if response.Schema != nil {
+		result.Body = g.getSchemaValue(response.Schema)
+	}
+	return result, nil
+}
+
+// Schema synthesize a value of the schema, the writeOnly properties are ignored
+func (m *Mocker) Schema(schema *swagger.Schema) interface{} {
+	return m.newGenerator(m.seed, "").getSchemaValue(schema)
+}
+
+// newGenerator the generator seeded by the seed and the key (like: the request and the status code)
+func (m *Mocker) newGenerator(seed int64, key string) *generator {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return &generator{
+		doc:      m.doc,
+		rnd:      rand.New(rand.NewSource(seed ^ int64(h.Sum64()))),
+		visiting: map[string]bool{},
+	}
+}
+
+// getPrefer the preferences of the header Prefer (like: code=404, seed=7)
+func getPrefer(r *http.Request) map[string]string {
+	prefer := map[string]string{}
+	for _, value := range r.Header[PreferHeader] {
+		for _, item := range strings.Split(value, ",") {
+			kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+			if len(kv) == 2 {
+				prefer[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			}
+		}
+	}
+	return prefer
+}
+
+// selectResponse the status code and the documented response
+func selectResponse(operation *swagger.Operation, preferCode string) (int, *swagger.Response, error) {
+	if operation == nil || len(operation.Responses) == 0 {
+		return 0, nil, errors.New("the operation has no documented responses")
+	}
+	if preferCode != "" {
+		code, err := strconv.Atoi(preferCode)
+		if err != nil || code < 100 || code > 599 {
+			return 0, nil, errors.New("invalid code " + preferCode + " in the header " + PreferHeader)
+		}
+		if response := operation.Responses[preferCode]; response != nil {
+			return code, response, nil
+		}
+		if response := operation.Responses["default"]; response != nil {
+			return code, response, nil
+		}
+		return 0, nil, errors.New("the response " + preferCode + " is not documented")
+	}
+	codes := []int{}
+	for key, response := range operation.Responses {
+		if code, err := strconv.Atoi(key); err == nil && response != nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			return code, operation.Responses[strconv.Itoa(code)], nil
+		}
+	}
+	if len(codes) > 0 {
+		return codes[0], operation.Responses[strconv.Itoa(codes[0])], nil
+	}
+	if response := operation.Responses["default"]; response != nil {
+		return http.StatusOK, response, nil
+	}
+	return 0, nil, errors.New("the operation has no documented responses")
+}
+
+// getJSONExample the example of the first JSON MIME type
+func getJSONExample(examples map[string]interface{}) (interface{}, bool) {
+	for _, mimeType := range sortedKeys(examples) {
+		if strings.Contains(mimeType, "json") {
+			return examples[mimeType], true
+		}
+	}
+	return nil, false
+}
+
+// sortedKeys the sorted keys of the map, the random values are consumed in this order
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string]*swagger.Header:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Propertie:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]interface{}:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// limits the limits of a synthesized primitive value
+type limits struct {
+	typ       string
+	format    string
+	enum      []interface{}
+	minimum   *float64
+	maximum   *float64
+	minLength *int64
+	maxLength *int64
+}
+
+type generator struct {
+	doc      *swagger.Swagger
+	rnd      *rand.Rand
+	visiting map[string]bool
+}
+
+func (g *generator) getSchemaValue(schema *swagger.Schema) interface{} {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		if g.visiting[schema.Ref] {
+			return nil
+		}
+		g.visiting[schema.Ref] = true
+		defer delete(g.visiting, schema.Ref)
+		return g.getSchemaValue(g.doc.Definitions[strings.TrimPrefix(schema.Ref, swaggerDefinitions)])
+	}
+	if len(schema.OneOf) > 0 {
+		return g.getSchemaValue(schema.OneOf[0])
+	}
+	if len(schema.AnyOf) > 0 {
+		return g.getSchemaValue(schema.AnyOf[0])
+	}
+	if schema.Type == "array" {
+		values := []interface{}{}
+		for i := g.arrayLength(); i > 0; i-- {
+			if value := g.getSchemaValue(schema.Items); value != nil {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	if len(schema.Properties) == 0 && len(schema.AllOf) == 0 {
+		return g.getPrimitiveValue(&limits{typ: schema.Type, format: schema.Format, enum: schema.Enum})
+	}
+	value := map[string]interface{}{}
+	for _, item := range schema.AllOf {
+		if values, ok := g.getSchemaValue(item).(map[string]interface{}); ok {
+			for name, v := range values {
+				value[name] = v
+			}
+		}
+	}
+	for name, v := range g.getPropertiesValue(schema.Properties) {
+		value[name] = v
+	}
+	return value
+}
+
+func (g *generator) getPropertiesValue(properties map[string]*swagger.Propertie) map[string]interface{} {
+	value := map[string]interface{}{}
+	for _, name := range sortedKeys(properties) {
+		propertie := properties[name]
+		if propertie == nil || propertie.WriteOnly {
+			continue
+		}
+		if v := g.getPropertieValue(propertie); v != nil {
+			value[name] = v
+		}
+	}
+	return value
+}
+
+func (g *generator) getPropertieValue(propertie *swagger.Propertie) interface{} {
+	if propertie.Example != nil {
+		return propertie.Example
+	}
+	if propertie.Default != nil {
+		return propertie.Default
+	}
+	if propertie.Ref != "" {
+		return g.getSchemaValue(&swagger.Schema{Ref: propertie.Ref})
+	}
+	if len(propertie.AllOf) == 1 && propertie.AllOf[0] != nil {
+		return g.getPropertieValue(propertie.AllOf[0])
+	}
+	switch propertie.Type {
+	case "array":
+		values := []interface{}{}
+		if propertie.Items == nil {
+			return values
+		}
+		for i := g.arrayLength(); i > 0; i-- {
+			if value := g.getPropertieValue(propertie.Items); value != nil {
+				values = append(values, value)
+			}
+		}
+		return values
+	case "object":
+		value := g.getPropertiesValue(propertie.Properties)
+		if propertie.AdditionalProperties != nil {
+			if v := g.getPropertieValue(propertie.AdditionalProperties); v != nil {
+				value[g.randomWord(3, 8)] = v
+			}
+		}
+		return value
+	}
+	return g.getPrimitiveValue(&limits{
+		typ:       propertie.Type,
+		format:    propertie.Format,
+		enum:      propertie.Enum,
+		minimum:   propertie.Minimum,
+		maximum:   propertie.Maximum,
+		minLength: propertie.MinLength,
+		maxLength: propertie.MaxLength,
+	})
+}
+
+func (g *generator) getHeaderValue(header *swagger.Header) interface{} {
+	if header.Example != nil {
+		return header.Example
+	}
+	if header.Default != nil {
+		return header.Default
+	}
+	return g.getPrimitiveValue(&limits{
+		typ:       header.Type,
+		format:    header.Format,
+		enum:      header.Enum,
+		minimum:   header.Minimum,
+		maximum:   header.Maximum,
+		minLength: header.MinLength,
+		maxLength: header.MaxLength,
+	})
+}
+
+// arrayLength the random length of a synthesized array, 1 to 3
+func (g *generator) arrayLength() int {
+	return 1 + g.rnd.Intn(3)
+}
+
+// getPrimitiveValue a random value of the enum, or a random value in the limits
+func (g *generator) getPrimitiveValue(l *limits) interface{} {
+	if len(l.enum) > 0 {
+		return l.enum[g.rnd.Intn(len(l.enum))]
+	}
+	switch l.typ {
+	case "integer":
+		low, high := getRange(l.minimum, l.maximum, 1, 100)
+		low, high = math.Ceil(low), math.Floor(high)
+		if high <= low {
+			return int64(low)
+		}
+		return int64(low) + g.rnd.Int63n(int64(high-low)+1)
+	case "number":
+		low, high := getRange(l.minimum, l.maximum, 0, 100)
+		value := math.Round((low+g.rnd.Float64()*(high-low))*100) / 100
+		return math.Max(low, math.Min(high, value))
+	case "boolean":
+		return g.rnd.Intn(2) == 1
+	case "string":
+		return g.getStringValue(l)
+	case "object":
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// getRange the range of the minimum and the maximum, the default range is [low, high]
+func getRange(minimum, maximum *float64, low, high float64) (float64, float64) {
+	switch {
+	case minimum != nil && maximum != nil:
+		return *minimum, *maximum
+	case minimum != nil:
+		return *minimum, *minimum + high - low
+	case maximum != nil:
+		return *maximum - high + low, *maximum
+	}
+	return low, high
+}
+
+func (g *generator) getStringValue(l *limits) string {
+	switch l.format {
+	case "date-time":
+		return g.randomTime().Format(time.RFC3339)
+	case "date":
+		return g.randomTime().Format("2006-01-02")
+	case "uuid":
+		b := make([]byte, 16)
+		g.rnd.Read(b)
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case "email":
+		return g.randomWord(4, 8) + "@example.com"
+	case "uri", "url":
+		return "https://example.com/" + g.randomWord(4, 8)
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(g.randomWord(4, 8)))
+	}
+	minLength, maxLength := int64(6), int64(10)
+	if l.minLength != nil {
+		minLength = *l.minLength
+		if maxLength < minLength {
+			maxLength = minLength
+		}
+	}
+	if l.maxLength != nil {
+		maxLength = *l.maxLength
+		if minLength > maxLength {
+			minLength = maxLength
+		}
+	}
+	return g.randomWord(int(minLength), int(maxLength))
+}
+
+// randomTime a random time (UTC, in seconds) in 2000 to 2029
+func (g *generator) randomTime() time.Time {
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(g.rnd.Int63n(30*365*24*3600)) * time.Second)
+}
+
+// randomWord a random word of the lowercase letters, the length is in [minLength, maxLength]
+func (g *generator) randomWord(minLength, maxLength int) string {
+	length := minLength
+	if maxLength > minLength {
+		length += g.rnd.Intn(maxLength - minLength + 1)
+	}
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = byte('a' + g.rnd.Intn(26))
+	}
+	return string(b)
+}
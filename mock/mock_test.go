@@ -0,0 +1,130 @@
+package mock
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+func newTestDocument() *swagger.Swagger {
+	minimum, maximum := 1.0, 5.0
+	minLength := int64(12)
+	return &swagger.Swagger{
+		Definitions: map[string]*swagger.Schema{
+			"Node": &swagger.Schema{
+				Type: "object",
+				Properties: map[string]*swagger.Propertie{
+					"id":       &swagger.Propertie{Type: "string", Format: "uuid"},
+					"created":  &swagger.Propertie{Type: "string", Format: "date-time"},
+					"level":    &swagger.Propertie{Type: "integer", Minimum: &minimum, Maximum: &maximum},
+					"code":     &swagger.Propertie{Type: "string", MinLength: &minLength},
+					"color":    &swagger.Propertie{Type: "string", Enum: []interface{}{"red", "blue"}},
+					"name":     &swagger.Propertie{Type: "string", Default: "node"},
+					"password": &swagger.Propertie{Type: "string", WriteOnly: true},
+					"children": &swagger.Propertie{Type: "array", Items: &swagger.Propertie{Ref: "#/definitions/Node"}},
+				},
+			},
+		},
+	}
+}
+
+func newTestOperation() *swagger.Operation {
+	return &swagger.Operation{
+		Responses: map[string]*swagger.Response{
+			"201": &swagger.Response{Description: "created", Schema: &swagger.Schema{Ref: "#/definitions/Node"}},
+			"400": &swagger.Response{Description: "bad request", Examples: map[string]interface{}{"application/json": "bad"}},
+			"default": &swagger.Response{
+				Description: "error",
+				Headers:     map[string]*swagger.Header{"X-Code": &swagger.Header{Type: "integer", Enum: []interface{}{7}}},
+			},
+		},
+	}
+}
+
+func TestRespond(t *testing.T) {
+	mocker := New(newTestDocument(), 42)
+	operation := newTestOperation()
+	response, err := mocker.Respond(operation, httptest.NewRequest("POST", "/nodes", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != 201 {
+		t.Error("the status code should be the lowest 2xx code, got", response.StatusCode)
+	}
+	node := response.Body.(map[string]interface{})
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`).MatchString(node["id"].(string)) {
+		t.Error("invalid uuid", node["id"])
+	}
+	if !regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`).MatchString(node["created"].(string)) {
+		t.Error("invalid date-time", node["created"])
+	}
+	if level := node["level"].(int64); level < 1 || level > 5 {
+		t.Error("the level should be in [1, 5], got", level)
+	}
+	if len(node["code"].(string)) < 12 {
+		t.Error("the length of the code should be >= 12", node["code"])
+	}
+	if color := node["color"]; color != "red" && color != "blue" {
+		t.Error("the color should be in the enum, got", color)
+	}
+	if node["name"] != "node" {
+		t.Error("the name should be the default value, got", node["name"])
+	}
+	if _, ok := node["password"]; ok {
+		t.Error("the writeOnly property should not be in the response")
+	}
+	if len(node["children"].([]interface{})) != 0 {
+		t.Error("the recursive model should be synthesized once")
+	}
+
+	// deterministic
+	again, _ := New(newTestDocument(), 42).Respond(operation, httptest.NewRequest("POST", "/nodes", nil))
+	if !reflect.DeepEqual(response, again) {
+		t.Error("the same seed and the same request should synthesize the same response")
+	}
+	r := httptest.NewRequest("POST", "/nodes", nil)
+	r.Header.Set(PreferHeader, "seed=43")
+	if other, _ := mocker.Respond(operation, r); reflect.DeepEqual(response, other) {
+		t.Error("the seed of the header Prefer should synthesize another response")
+	}
+}
+
+func TestRespond_Prefer(t *testing.T) {
+	mocker := New(newTestDocument(), 0)
+	operation := newTestOperation()
+	nodes := []struct {
+		prefer string
+		code   int
+		body   interface{}
+		header string
+	}{
+		{"code=400", 400, "bad", ""},
+		{"code=503, seed=1", 503, nil, "7"},
+	}
+	for _, node := range nodes {
+		r := httptest.NewRequest("POST", "/nodes", nil)
+		r.Header.Set(PreferHeader, node.prefer)
+		response, err := mocker.Respond(operation, r)
+		if err != nil {
+			t.Error(node.prefer, err)
+			continue
+		}
+		if response.StatusCode != node.code || response.Body != node.body || response.Headers["X-Code"] != node.header {
+			t.Error(node.prefer, "unexpected response", response)
+		}
+	}
+	delete(operation.Responses, "default")
+	for _, prefer := range []string{"code=503", "code=abc", "seed=abc"} {
+		r := httptest.NewRequest("POST", "/nodes", nil)
+		r.Header.Set(PreferHeader, prefer)
+		if _, err := mocker.Respond(operation, r); err == nil {
+			t.Error(prefer + " should be an error")
+		}
+	}
+	if _, err := mocker.Respond(&swagger.Operation{}, httptest.NewRequest("GET", "/", nil)); err == nil {
+		t.Error("the operation without responses should be an error")
+	}
+}
@@ -0,0 +1,178 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/gin-gonic/gin"
+)
+
+type testMockBook struct {
+	ID     string   `json:"id" example:"b1"`
+	Title  string   `json:"title" minlen:"2" maxlen:"8"`
+	Status string   `json:"status" enum:"draft published"`
+	Pages  int      `json:"pages" min:"10" max:"20"`
+	Tags   []string `json:"tags"`
+}
+
+type testMockError struct {
+	Message string `json:"message"`
+}
+
+func newTestMockEngine(seed int64) (*Engine, error) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{MockMode: true, MockSeed: seed})
+	doc := &APIDocCommon{
+		Parameters: map[string]Parameter{
+			"id":    Parameter{InPath: &ValueInfo{Type: "string"}},
+			"limit": Parameter{InQuery: &ValueInfo{Type: "int32", Min: "1"}},
+		},
+		Responses: map[int]Response{
+			200: Response{Model: &testMockBook{}, Headers: map[string]ValueInfo{"X-Total": ValueInfo{Type: "int64", Example: "3"}}},
+			404: Response{Model: &testMockError{}},
+		},
+	}
+	handler := func(c *gin.Context, err error) {
+		c.String(http.StatusTeapot, "the handler should not be called in the mock mode")
+	}
+	return router, router.GET("/books/:id", doc, handler)
+}
+
+func doTestMockRequest(router *Engine, prefer string, url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(GET, url, nil)
+	if prefer != "" {
+		r.Header.Set("Prefer", prefer)
+	}
+	router.GinEngine().ServeHTTP(w, r)
+	return w
+}
+
+func TestMockMode(t *testing.T) {
+	router, err := newTestMockEngine(1)
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	w := doTestMockRequest(router, "", "/books/1")
+	if w.Code != http.StatusOK {
+		testError(t, w.Code, w.Body.String())
+		return
+	}
+	if w.Header().Get("X-Total") != "3" {
+		testError(t, "the header X-Total should be the example 3, got "+w.Header().Get("X-Total"))
+	}
+	book := &testMockBook{}
+	if err := json.Unmarshal(w.Body.Bytes(), book); err != nil {
+		testError(t, err)
+	}
+	if book.ID != "b1" || len(book.Title) < 2 || len(book.Title) > 8 || book.Pages < 10 || book.Pages > 20 ||
+		(book.Status != "draft" && book.Status != "published") || len(book.Tags) == 0 {
+		testError(t, "the book should be synthesized from the limits, got "+w.Body.String())
+	}
+	// deterministic
+	if body := doTestMockRequest(router, "", "/books/1").Body.String(); body != w.Body.String() {
+		testError(t, "the same request should respond the same body", body, w.Body.String())
+	}
+	other, _ := newTestMockEngine(1)
+	if body := doTestMockRequest(other, "", "/books/1").Body.String(); body != w.Body.String() {
+		testError(t, "the same seed should respond the same body", body, w.Body.String())
+	}
+	// the status code is selected by the header Prefer
+	if w := doTestMockRequest(router, "code=404", "/books/1"); w.Code != http.StatusNotFound {
+		testError(t, "the code should be 404, got", w.Code)
+	}
+	if w := doTestMockRequest(router, "code=500", "/books/1"); w.Code != http.StatusNotImplemented {
+		testError(t, "the undocumented code should be 501, got", w.Code)
+	}
+	// the parameters are validated
+	if w := doTestMockRequest(router, "", "/books/1?limit=0"); w.Code != http.StatusBadRequest {
+		testError(t, "the invalid parameter should be 400, got", w.Code)
+	}
+}
+
+func TestMockMode_SharedMocker(t *testing.T) {
+	router, err := newTestMockEngine(1)
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	doc := &APIDocCommon{
+		Parameters: map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "string"}}},
+		Responses:  map[int]Response{200: Response{Model: &testMockBook{}}},
+	}
+	if err := router.GET("/drafts/:id", doc, func(c *gin.Context, err error) {}); err != nil {
+		testError(t, err)
+		return
+	}
+	if router.mocker == nil || router.mocker.mocker != nil {
+		testError(t, "the mocker should be created at the first request")
+		return
+	}
+	book := doTestMockRequest(router, "", "/books/1").Body.String()
+	mocker := router.getMocker()
+	draft := doTestMockRequest(router, "", "/drafts/1").Body.String()
+	if router.getMocker() != mocker {
+		testError(t, "the operations should share the mocker of the engine")
+	}
+	if book == draft {
+		testError(t, "the operations with the same model should respond different values", book)
+	}
+}
+
+func TestEngineHandleDocument(t *testing.T) {
+	doc, err := swagger.ParseDocument([]byte(`{
+	  "swagger": "2.0",
+	  "parameters": {"X-Request-Id": {"name": "X-Request-Id", "in": "header", "type": "string", "required": true}},
+	  "paths": {
+	    "/pets/{id}": {
+	      "get": {
+	        "parameters": [
+	          {"$ref": "#/parameters/X-Request-Id"},
+	          {"name": "id", "in": "path", "required": true, "type": "integer", "format": "int64"},
+	          {"name": "kind", "in": "query", "type": "string", "enum": ["cat", "dog"]}
+	        ],
+	        "responses": {"200": {"description": "ok", "schema": {"$ref": "#/definitions/Pet"}}}
+	      }
+	    }
+	  },
+	  "definitions": {"Pet": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string", "example": "Tom"}}}}
+	}`))
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{MockMode: true})
+	if err := router.HandleDocument(doc, func(c *gin.Context, err error) {}); err != nil {
+		testError(t, err)
+		return
+	}
+	nodes := []struct {
+		url       string
+		requestID string
+		code      int
+	}{
+		{"/pets/1?kind=cat", "r1", http.StatusOK},
+		{"/pets/abc", "r1", http.StatusBadRequest},
+		{"/pets/1?kind=bird", "r1", http.StatusBadRequest},
+	}
+	for index, node := range nodes {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(GET, node.url, nil)
+		r.Header.Set("X-Request-Id", node.requestID)
+		router.GinEngine().ServeHTTP(w, r)
+		if w.Code != node.code {
+			testError(t, index, node.url, w.Code, w.Body.String())
+		}
+		if node.code == http.StatusOK && w.Body.String() != `{"name":"Tom"}` {
+			testError(t, index, "the body should be the example, got "+w.Body.String())
+		}
+	}
+	if router.Swagger.Paths["/pets/{id}"].Get.Parameters[0].Name != "X-Request-Id" {
+		testError(t, "the reference of the global parameter should be inlined")
+	}
+}
@@ -0,0 +1,379 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// modelDirection where the model value is sent
+type modelDirection int
+
+const (
+	// modelInRequest the fields with the tag readonly:"true" must not be sent
+	modelInRequest modelDirection = iota
+	// modelInResponse the fields with the tag writeonly:"true" must not be sent
+	modelInResponse
+)
+
+// modelValidator check if a value (decoded from JSON) matches the documents of a model
+// Fields:
+//   StructUUID -- the UUID of the model
+//   StructDocs -- the documents of the model and all structs in it
+//   Direction -- modelInRequest or modelInResponse
+type modelValidator struct {
+	StructUUID string
+	StructDocs map[string]*StructDoc
+	Direction  modelDirection
+}
+
+// newModelValidator the model must be a struct object, or a nil pointer to a registered interface
+func newModelValidator(model interface{}, direction modelDirection) (*modelValidator, error) {
+	creater := StructDocCreater{}
+	structDocs, err := creater.GetStructDocMap(model)
+	if err != nil {
+		return nil, err
+	}
+	structType, err := getStructReflectType(model)
+	if err != nil {
+		return nil, err
+	}
+	structUUID, err := getStructUUID(structType)
+	if err != nil {
+		return nil, err
+	}
+	return &modelValidator{StructUUID: structUUID, StructDocs: structDocs, Direction: direction}, nil
+}
+
+// Check check the value decoded from JSON (map[string]interface{}, []interface{}, string, float64, bool or nil)
+func (m modelValidator) Check(value interface{}) error {
+	if list, ok := value.([]interface{}); ok {
+		for i, item := range list {
+			if err := m.checkStruct(m.StructUUID, item, fmt.Sprintf("[%d]", i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return m.checkStruct(m.StructUUID, value, "")
+}
+
+// CheckJSON check the JSON data
+func (m modelValidator) CheckJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return m.Check(value)
+}
+
+func (m modelValidator) checkStruct(structUUID string, value interface{}, path string) error {
+	doc, ok := m.StructDocs[structUUID]
+	if !ok {
+		return errors.New("the document of " + structUUID + " is not found")
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return newModelValueError(path, "should be an object")
+	}
+	if doc.IsPolymorphic() {
+		return m.checkPolymorphic(doc, object, path)
+	}
+	fields := map[string]*StructField{}
+	for _, field := range doc.StructFields {
+		fields[field.Name] = field
+		fieldValue, ok := object[field.Name]
+		if !ok {
+			if field.Required && !m.isHidden(field) {
+				return newModelValueError(joinModelPath(path, field.Name), "is required")
+			}
+			continue
+		}
+		if m.isHidden(field) {
+			if m.Direction == modelInRequest {
+				return newModelValueError(joinModelPath(path, field.Name), "is read only")
+			}
+			return newModelValueError(joinModelPath(path, field.Name), "is write only")
+		}
+		if err := m.checkField(field, fieldValue, joinModelPath(path, field.Name)); err != nil {
+			return err
+		}
+	}
+	for name := range object {
+		if _, ok := fields[name]; !ok {
+			return newModelValueError(joinModelPath(path, name), "is not defined in "+doc.StructName)
+		}
+	}
+	return nil
+}
+
+func (m modelValidator) checkPolymorphic(doc *StructDoc, object map[string]interface{}, path string) error {
+	if doc.Discriminator != "" {
+		discriminator, _ := object[doc.Discriminator].(string)
+		if discriminator == "" {
+			return newModelValueError(joinModelPath(path, doc.Discriminator), "is required")
+		}
+		structUUID, ok := doc.DiscriminatorMapping[discriminator]
+		if !ok {
+			return newModelValueError(joinModelPath(path, doc.Discriminator), "invalid discriminator ("+discriminator+")")
+		}
+		return m.checkStruct(structUUID, object, path)
+	}
+	structUUIDs := doc.OneOf
+	if len(doc.AnyOf) > 0 {
+		structUUIDs = doc.AnyOf
+	}
+	matched := 0
+	for _, structUUID := range structUUIDs {
+		if err := m.checkStruct(structUUID, object, path); err == nil {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return newModelValueError(path, "does not match any schema of "+doc.StructName)
+	}
+	if matched > 1 && len(doc.OneOf) > 0 {
+		return newModelValueError(path, "matches more than one schema of "+doc.StructName)
+	}
+	return nil
+}
+
+// Strip remove the fields which must not be sent in the direction (like: the write only fields of a response)
+// from the value decoded from JSON, it returns true if any field is removed
+func (m modelValidator) Strip(value interface{}) bool {
+	if list, ok := value.([]interface{}); ok {
+		stripped := false
+		for _, item := range list {
+			if m.stripStruct(m.StructUUID, item) {
+				stripped = true
+			}
+		}
+		return stripped
+	}
+	return m.stripStruct(m.StructUUID, value)
+}
+
+// HasHidden check if the model has the fields which must not be sent in the direction
+func (m modelValidator) HasHidden() bool {
+	for _, doc := range m.StructDocs {
+		for _, field := range doc.StructFields {
+			if m.isHidden(field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m modelValidator) stripStruct(structUUID string, value interface{}) bool {
+	doc, ok := m.StructDocs[structUUID]
+	object, isObject := value.(map[string]interface{})
+	if !ok || !isObject {
+		return false
+	}
+	if doc.IsPolymorphic() {
+		// the schema of the discriminator, or all the schemas which the value may match
+		structUUIDs := append(append([]string{}, doc.OneOf...), doc.AnyOf...)
+		if doc.Discriminator != "" {
+			discriminator, _ := object[doc.Discriminator].(string)
+			structUUIDs = []string{doc.DiscriminatorMapping[discriminator]}
+		}
+		stripped := false
+		for _, structUUID := range structUUIDs {
+			if m.stripStruct(structUUID, object) {
+				stripped = true
+			}
+		}
+		return stripped
+	}
+	stripped := false
+	for _, field := range doc.StructFields {
+		fieldValue, ok := object[field.Name]
+		if !ok {
+			continue
+		}
+		if m.isHidden(field) {
+			delete(object, field.Name)
+			stripped = true
+			continue
+		}
+		if !field.IsStruct {
+			continue
+		}
+		items, ok := fieldValue.([]interface{})
+		if !ok || !field.IsArray {
+			items = []interface{}{fieldValue}
+		}
+		for _, item := range items {
+			if m.stripStruct(field.RefStructUUID, item) {
+				stripped = true
+			}
+		}
+	}
+	return stripped
+}
+
+// isHidden check if the field must not be sent in the direction
+func (m modelValidator) isHidden(field *StructField) bool {
+	if m.Direction == modelInRequest {
+		return field.ReadOnly
+	}
+	return field.WriteOnly
+}
+
+func (m modelValidator) checkField(field *StructField, value interface{}, path string) error {
+	if value == nil {
+		// a nil slice is encoded as null
+		if field.Nullable || field.IsArray {
+			return nil
+		}
+		return newModelValueError(path, "should not be null")
+	}
+	if field.IsArray {
+		list, ok := value.([]interface{})
+		if !ok {
+			return newModelValueError(path, "should be an array")
+		}
+		for i, item := range list {
+			if err := m.checkItem(field, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return m.checkItem(field, value, path)
+}
+
+func (m modelValidator) checkItem(field *StructField, value interface{}, path string) error {
+	if field.IsStruct {
+		if value == nil {
+			return nil
+		}
+		return m.checkStruct(field.RefStructUUID, value, path)
+	}
+	return checkStructFieldValue(field, value, path)
+}
+
+// checkStructFieldValue check the value (decoded from JSON) of a field which is not a struct
+func checkStructFieldValue(field *StructField, value interface{}, path string) error {
+	switch {
+	case isValueTypeString(field.ValueType):
+		str, ok := value.(string)
+		if !ok {
+			return newModelValueError(path, "should be a string")
+		}
+		length := int64(utf8.RuneCountInString(str))
+		if field.MinLen != nil && length < *field.MinLen {
+			return newModelValueError(path, fmt.Sprintf("length should be greater than or equal to %d", *field.MinLen))
+		}
+		if field.MaxLen != nil && length > *field.MaxLen {
+			return newModelValueError(path, fmt.Sprintf("length should be less than or equal to %d", *field.MaxLen))
+		}
+	case isValueTypeBool(field.ValueType):
+		if _, ok := value.(bool); !ok {
+			return newModelValueError(path, "should be a boolean")
+		}
+	case isValueTypeNumber(field.ValueType):
+		num, ok := value.(float64)
+		if !ok {
+			return newModelValueError(path, "should be a number")
+		}
+		if !isValueTypeFloat(field.ValueType) && num != math.Trunc(num) {
+			return newModelValueError(path, "should be an integer")
+		}
+		if isValueTypeUint(field.ValueType) && num < 0 {
+			return newModelValueError(path, "should be an unsigned integer")
+		}
+		if field.Min != nil && num < *field.Min {
+			return newModelValueError(path, fmt.Sprintf("should be greater than or equal to %v", *field.Min))
+		}
+		if field.Max != nil && num > *field.Max {
+			return newModelValueError(path, fmt.Sprintf("should be less than or equal to %v", *field.Max))
+		}
+	}
+	if len(field.Enum) > 0 && !isInEnum(field.Enum, value) {
+		return newModelValueError(path, fmt.Sprintf("should be one of %v", field.Enum))
+	}
+	return nil
+}
+
+// isInEnum the value is decoded from JSON, and the enum is from the tag enum
+func isInEnum(enum []interface{}, value interface{}) bool {
+	for _, v := range enum {
+		switch e := v.(type) {
+		case string:
+			if str, ok := value.(string); ok && str == e {
+				return true
+			}
+		case int64:
+			if num, ok := value.(float64); ok && num == float64(e) {
+				return true
+			}
+		case uint64:
+			if num, ok := value.(float64); ok && num == float64(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toJSONValue convert the object to the value decoded from JSON.
+// A string, []byte or json.RawMessage is treated as JSON data.
+func toJSONValue(obj interface{}) (interface{}, error) {
+	var data []byte
+	switch v := obj.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		return marshalToJSONValue(obj)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// marshalToJSONValue convert the object to the value decoded from JSON, by encoding it to JSON.
+func marshalToJSONValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func joinModelPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// modelValueError describes a value which does not match the model
+type modelValueError struct {
+	Path    string
+	Message string
+}
+
+func newModelValueError(path, message string) error {
+	return &modelValueError{path, message}
+}
+
+func (e modelValueError) Error() string {
+	if e.Path == "" {
+		return "the value " + e.Message
+	}
+	return "the field " + e.Path + " " + e.Message
+}
@@ -0,0 +1,125 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testValidatorAuthor struct {
+	Name string `json:"name" req:"true" minlen:"1" maxlen:"10"`
+}
+
+type testValidatorBook struct {
+	ID       string                 `json:"id" readonly:"true"`
+	Title    string                 `json:"title" req:"true"`
+	Type     string                 `json:"type" enum:"novel poetry"`
+	Pages    int                    `json:"pages" min:"1" max:"1000"`
+	Price    float64                `json:"price"`
+	Count    uint                   `json:"count"`
+	OnSale   bool                   `json:"on_sale"`
+	Note     *string                `json:"note"`
+	Password string                 `json:"password" writeonly:"true"`
+	Tags     []string               `json:"tags"`
+	Authors  []*testValidatorAuthor `json:"authors"`
+	Editor   *testValidatorAuthor   `json:"editor"`
+}
+
+func TestModelValidator(t *testing.T) {
+	requestValidator, err := newModelValidator(&testValidatorBook{}, modelInRequest)
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	responseValidator, err := newModelValidator(&testValidatorBook{}, modelInResponse)
+	if err != nil {
+		testError(t, err)
+		return
+	}
+
+	validRequests := []string{
+		`{"title":"a"}`,
+		`{"title":"a","type":"novel","pages":10,"price":9.9,"count":1,"on_sale":true,"note":null,"password":"x"}`,
+		`{"title":"a","tags":null,"authors":[{"name":"John"}],"editor":null}`,
+		`[{"title":"a"},{"title":"b"}]`,
+	}
+	for _, data := range validRequests {
+		if err := requestValidator.CheckJSON([]byte(data)); err != nil {
+			testError(t, data, err)
+		}
+	}
+
+	invalidRequests := []string{
+		`{}`,
+		`{"title":1}`,
+		`{"title":"a","id":"1"}`,
+		`{"title":"a","type":"comic"}`,
+		`{"title":"a","pages":0}`,
+		`{"title":"a","pages":1.5}`,
+		`{"title":"a","count":-1}`,
+		`{"title":"a","on_sale":"true"}`,
+		`{"title":"a","price":null}`,
+		`{"title":"a","tags":[1]}`,
+		`{"title":"a","authors":[{}]}`,
+		`{"title":"a","authors":[{"name":"12345678901"}]}`,
+		`{"title":"a","unknown":1}`,
+		`"abc"`,
+	}
+	for _, data := range invalidRequests {
+		if err := requestValidator.CheckJSON([]byte(data)); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, data+" should be invalid")
+		}
+	}
+
+	if err := responseValidator.CheckJSON([]byte(`{"id":"1","title":"a"}`)); err != nil {
+		testError(t, err)
+	}
+	if err := responseValidator.CheckJSON([]byte(`{"title":"a","password":"x"}`)); err != nil {
+		testLog(t, err)
+	} else {
+		testError(t, "password is write only")
+	}
+}
+
+type testStripAccount struct {
+	Name  string `json:"name"`
+	Token string `json:"token" writeonly:"true"`
+}
+
+type testStripUser struct {
+	Name     string              `json:"name"`
+	Password string              `json:"password" writeonly:"true"`
+	Accounts []*testStripAccount `json:"accounts"`
+	Main     *testStripAccount   `json:"main"`
+}
+
+func TestModelValidator_Strip(t *testing.T) {
+	validator, err := newModelValidator(&testStripUser{}, modelInResponse)
+	if err != nil || !validator.HasHidden() {
+		testError(t, "the model should have the write only fields", err)
+		return
+	}
+	nodes := []struct {
+		data     string
+		stripped bool
+		want     string
+	}{
+		{`{"name":"a"}`, false, `{"name":"a"}`},
+		{`{"name":"a","password":"x","main":{"name":"b","token":"y"}}`, true, `{"main":{"name":"b"},"name":"a"}`},
+		{`[{"name":"a","accounts":[{"name":"b","token":"y"},null]}]`, true, `[{"accounts":[{"name":"b"},null],"name":"a"}]`},
+	}
+	for _, node := range nodes {
+		var value interface{}
+		json.Unmarshal([]byte(node.data), &value)
+		stripped := validator.Strip(value)
+		data, _ := json.Marshal(value)
+		if stripped != node.stripped || string(data) != node.want {
+			testError(t, node.data, "unexpected stripped value", stripped, string(data))
+		}
+	}
+	requestValidator, _ := newModelValidator(&testStripUser{}, modelInRequest)
+	if requestValidator.HasHidden() {
+		testError(t, "the model has no read only field")
+	}
+}
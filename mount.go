@@ -0,0 +1,210 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// handledRoute a route handled by the engine, the path is the relative path of the handling (like: /books/:id)
+type handledRoute struct {
+	method       string
+	relativePath string
+	group        string
+	doc          APIDoc
+	handlers     []HandlerFunc
+}
+
+// Mount mount the APIs of the other engine on the path prefix (like: /books), the documents are merged into one.
+// The global parameters, the security definitions, the declared tags and the definitions of the other engine are merged by the names,
+// the conflicts are errors (like: a definition with the same name and a different schema, an API with the same method and path).
+// The conflicts (the global parameters, the definitions, the methods, the paths and the operationIds of the APIs)
+// are checked before the engine is changed, so the engine is unchanged if there is a conflict.
+// The APIs are handled by the engine again, so the Config of the engine is used (like: BasePath, AllowOrigin, MockMode),
+// the Config of the other engine is not. Mount it after the APIs of the other engine are registered.
+func (e *Engine) Mount(prefix string, other *Engine) error {
+	if other == nil || other == e {
+		return errors.New("the engine can't be mounted on itself")
+	}
+	if err := e.checkMountedGlobalParameters(other); err != nil {
+		return err
+	}
+	if err := e.checkMountedDefinitions(other); err != nil {
+		return err
+	}
+	if err := e.checkMountedRoutes(prefix, other); err != nil {
+		return err
+	}
+
+	e.mountGlobalParameters(other)
+	e.mountDefinitions(other)
+	for _, tag := range other.Swagger.Tags {
+		if e.getSwaggerTag(tag.Name) == nil {
+			e.Swagger.Tags = append(e.Swagger.Tags, tag)
+		}
+	}
+	for _, route := range other.routes {
+		relativePath := joinPaths(prefix, route.relativePath)
+		if err := e.handleInGroup(route.method, relativePath, route.group, route.doc, route.handlers); err != nil {
+			return err
+		}
+		// the operations of the other engine require the security of the other document
+		if route.doc != nil && len(other.Swagger.Security) > 0 {
+			if operation := e.apis[len(e.apis)-1].operation; operation.Security == nil {
+				operation.Security = other.Swagger.Security
+			}
+		}
+	}
+	return nil
+}
+
+// checkMountedGlobalParameters check the global parameters of the other engine, the parameters with the same name must be the same
+func (e *Engine) checkMountedGlobalParameters(other *Engine) error {
+	names := []string{}
+	for name := range other.globalParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := e.globalParameters[name]; ok && !isSameDocumentValue(e.Swagger.Parameters[name], other.Swagger.Parameters[name]) {
+			return errors.New("the global parameter " + name + " conflicts with the mounted engine")
+		}
+	}
+	return nil
+}
+
+// checkMountedDefinitions check the definitions and the security definitions of the other engine,
+// the definitions with the same name must be the same
+func (e *Engine) checkMountedDefinitions(other *Engine) error {
+	names := []string{}
+	for name := range other.Swagger.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if old, ok := e.Swagger.Definitions[name]; ok && !isSameDocumentValue(old, other.Swagger.Definitions[name]) {
+			return errors.New("the definition " + name + " conflicts with the mounted engine")
+		}
+	}
+	names = []string{}
+	for name := range other.Swagger.SecurityDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if old, ok := e.Swagger.SecurityDefinitions[name]; ok && !isSameDocumentValue(old, other.Swagger.SecurityDefinitions[name]) {
+			return errors.New("the security definition " + name + " conflicts with the mounted engine")
+		}
+	}
+	return nil
+}
+
+// checkMountedRoutes check the routes of the other engine on the path prefix, the method and the path
+// and the operationId of a route must not be registered by the engine or by another mounted route
+func (e *Engine) checkMountedRoutes(prefix string, other *Engine) error {
+	operationIDs := map[string]string{}
+	for operationID, owner := range e.operationIDs {
+		operationIDs[operationID] = owner
+	}
+	registered := map[string]bool{}
+	for _, route := range e.routes {
+		if swaggerPath, err := ginPathToSwaggerPath(route.relativePath); err == nil {
+			registered[route.method+" "+swaggerPath] = true
+		}
+	}
+	for _, route := range other.routes {
+		relativePath := joinPaths(prefix, route.relativePath)
+		swaggerPath, err := ginPathToSwaggerPath(relativePath)
+		if err != nil {
+			return &engineError{relativePath, route.method, err}
+		}
+		if _, err := swaggerPathToGinPath(swaggerPath); err != nil {
+			return &engineError{relativePath, route.method, err}
+		}
+		key := route.method + " " + swaggerPath
+		if registered[key] || (route.doc != nil && e.hasSwaggerOperation(swaggerPath, route.method)) {
+			return &engineError{relativePath, route.method, errors.New("the API is already registered")}
+		}
+		registered[key] = true
+		if route.doc == nil {
+			continue
+		}
+		operation, err := route.doc.ToSwaggerOperation()
+		if err != nil {
+			return &engineError{relativePath, route.method, err}
+		}
+		if _, err := claimOperationID(operationIDs, swaggerPath, route.method, operation.OperationID, nameOfFunction(route.handlers[0])); err != nil {
+			return &engineError{relativePath, route.method, err}
+		}
+	}
+	return nil
+}
+
+// mountGlobalParameters merge the global parameters of the other engine (see checkMountedGlobalParameters)
+func (e *Engine) mountGlobalParameters(other *Engine) {
+	for name, parameter := range other.globalParameters {
+		if _, ok := e.globalParameters[name]; ok {
+			continue
+		}
+		if e.globalParameters == nil {
+			e.globalParameters = map[string]Parameter{}
+		}
+		if e.Swagger.Parameters == nil {
+			e.Swagger.Parameters = map[string]*swagger.Parameter{}
+		}
+		e.globalParameters[name] = parameter
+		e.Swagger.Parameters[name] = other.Swagger.Parameters[name]
+	}
+}
+
+// mountDefinitions merge the definitions and the security definitions of the other engine (see checkMountedDefinitions),
+// the definitions not used by the routes (like: the definitions added to the document) are merged too
+func (e *Engine) mountDefinitions(other *Engine) {
+	for name, schema := range other.Swagger.Definitions {
+		if _, ok := e.Swagger.Definitions[name]; ok {
+			continue
+		}
+		if e.Swagger.Definitions == nil {
+			e.Swagger.Definitions = map[string]*swagger.Schema{}
+		}
+		e.Swagger.Definitions[name] = schema
+	}
+	for name, security := range other.Swagger.SecurityDefinitions {
+		if _, ok := e.Swagger.SecurityDefinitions[name]; ok {
+			continue
+		}
+		if e.Swagger.SecurityDefinitions == nil {
+			e.Swagger.SecurityDefinitions = map[string]*swagger.Security{}
+		}
+		e.Swagger.SecurityDefinitions[name] = security
+	}
+}
+
+func (e *Engine) hasSwaggerOperation(swaggerPath string, method string) bool {
+	item, ok := e.Swagger.Paths[swaggerPath]
+	if !ok {
+		return false
+	}
+	switch method {
+	case GET:
+		return item.Get != nil
+	case POST:
+		return item.Post != nil
+	case PUT:
+		return item.Put != nil
+	case PATCH:
+		return item.Patch != nil
+	case DELETE:
+		return item.Delete != nil
+	}
+	return false
+}
+
+// isSameDocumentValue the values of the documents are the same if their JSON are the same
+func isSameDocumentValue(a interface{}, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
@@ -0,0 +1,161 @@
+package ehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+	"github.com/gin-gonic/gin"
+)
+
+type testMountBook struct {
+	ID string `json:"id"`
+}
+
+func newTestMountEngine(t *testing.T, conf *Config) *Engine {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(conf)
+	if err := router.SetGlobalParameters(map[string]Parameter{
+		"X-Request-Id": Parameter{InHeader: &ValueInfo{Type: "string", Required: true}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestEngineMount(t *testing.T) {
+	books := newTestMountEngine(t, &Config{BasePath: "/ignored", Tags: []Tag{{Name: "books"}}})
+	books.Swagger.SecurityDefinitions = map[string]*swagger.Security{"token": &swagger.Security{Type: "apiKey", Name: "Authorization", In: "header"}}
+	books.Swagger.Security = []map[string][]string{{"token": {}}}
+	doc := &APIDocCommon{
+		Tags:                 []string{"books"},
+		GlobalParameterNames: []string{"X-Request-Id"},
+		Parameters:           map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "int64"}}},
+		Responses:            map[int]Response{200: Response{Model: &testMountBook{}}},
+	}
+	err := books.Group("/books", "").GET("/:id", doc, func(c *gin.Context, err error) {
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, &testMountBook{ID: c.Param("id")})
+	})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+
+	books.Swagger.Definitions["testMountExtra"] = &swagger.Schema{Type: "string"}
+
+	portal := newTestMountEngine(t, &Config{BasePath: "/v1"})
+	if err := portal.Mount("/store", books); err != nil {
+		testError(t, err)
+		return
+	}
+	operation := portal.Swagger.Paths["/store/books/{id}"].Get
+	if operation == nil || len(operation.Security) != 1 || operation.Tags[0] != "books" {
+		testError(t, "the API should be mounted on the prefix with the security of the document", portal.Swagger.Paths)
+		return
+	}
+	if portal.Swagger.Definitions["testMountBook"] == nil || portal.Swagger.SecurityDefinitions["token"] == nil || portal.getSwaggerTag("books") == nil {
+		testError(t, "the definitions and the tags should be merged")
+	}
+	if portal.Swagger.Definitions["testMountExtra"] == nil {
+		testError(t, "the definitions not used by the APIs should be merged")
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/v1/store/books/1", nil)
+	r.Header.Set("X-Request-Id", "1")
+	portal.GinEngine().ServeHTTP(w, r)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"id":"1"`) {
+		testError(t, "the mounted API should be served", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	portal.GinEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/store/books/abc", nil))
+	if w.Code != http.StatusBadRequest {
+		testError(t, "the parameters of the mounted API should be checked", w.Code)
+	}
+
+	if err := portal.Mount("/store", books); err == nil {
+		testError(t, "the API which is already registered should be an error")
+	}
+}
+
+func TestEngineMount_Conflicts(t *testing.T) {
+	other := newTestMountEngine(t, &Config{})
+	other.Swagger.Definitions = map[string]*swagger.Schema{"testMountBook": &swagger.Schema{Type: "string"}}
+	router := newTestMountEngine(t, &Config{})
+	router.Swagger.Definitions = map[string]*swagger.Schema{"testMountBook": &swagger.Schema{Type: "object"}}
+	if err := router.Mount("/other", other); err == nil || !strings.Contains(err.Error(), "definition testMountBook") {
+		testError(t, "the conflicting definition should be an error", err)
+	}
+
+	other = newTestMountEngine(t, &Config{})
+	if err := other.SetGlobalParameters(map[string]Parameter{
+		"X-Request-Id": Parameter{InQuery: &ValueInfo{Type: "string"}},
+	}); err != nil {
+		testError(t, err)
+		return
+	}
+	router = newTestMountEngine(t, &Config{})
+	if err := router.Mount("/other", other); err == nil || !strings.Contains(err.Error(), "global parameter X-Request-Id") {
+		testError(t, "the conflicting global parameter should be an error", err)
+	}
+	if err := router.Mount("/self", router); err == nil {
+		testError(t, "the engine can't be mounted on itself")
+	}
+}
+
+func TestEngineMount_Atomic(t *testing.T) {
+	handler := func(c *gin.Context, err error) {}
+	other := newTestMountEngine(t, &Config{})
+	if err := other.SetGlobalParameters(map[string]Parameter{
+		"X-Trace": Parameter{InHeader: &ValueInfo{Type: "string"}},
+	}); err != nil {
+		testError(t, err)
+		return
+	}
+	other.Swagger.SecurityDefinitions = map[string]*swagger.Security{"token": &swagger.Security{Type: "apiKey", Name: "Authorization", In: "header"}}
+	doc := &APIDocCommon{Responses: map[int]Response{200: Response{Model: &testMountBook{}}}}
+	for _, path := range []string{"/authors", "/books"} {
+		if err := other.GET(path, doc, handler); err != nil {
+			testError(t, err)
+			return
+		}
+	}
+
+	router := newTestMountEngine(t, &Config{})
+	if err := router.GET("/other/books", nil, handler); err != nil {
+		testError(t, err)
+		return
+	}
+	if err := router.Mount("/other", other); err == nil || !strings.Contains(err.Error(), "already registered") {
+		testError(t, "the conflicting API should be an error", err)
+	}
+	if len(router.Swagger.Paths) != 0 || len(router.routes) != 1 || router.Swagger.Definitions["testMountBook"] != nil ||
+		router.Swagger.Parameters["X-Trace"] != nil || router.Swagger.SecurityDefinitions["token"] != nil {
+		testError(t, "the engine should be unchanged if the mounting fails", router.Swagger.Paths, router.Swagger.Parameters)
+	}
+
+	other = NewEngine(&Config{})
+	router = NewEngine(&Config{})
+	for _, node := range []struct {
+		router      *Engine
+		path        string
+		operationID string
+	}{{other, "/a", ""}, {other, "/b", "list"}, {router, "/x", "list"}} {
+		if err := node.router.GET(node.path, &APIDocCommon{OperationID: node.operationID}, handler); err != nil {
+			testError(t, err)
+			return
+		}
+	}
+	if err := router.Mount("/m", other); err == nil || !strings.Contains(err.Error(), "the operationId list is already used") {
+		testError(t, "the conflicting operationId should be an error", err)
+	}
+	if len(router.Swagger.Paths) != 1 || len(router.routes) != 1 {
+		testError(t, "the engine should be unchanged if the operationId conflicts", router.Swagger.Paths)
+	}
+}
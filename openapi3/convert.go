@@ -0,0 +1,471 @@
+package openapi3
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const (
+	swaggerDefinitions   = "#/definitions/"
+	swaggerParameters    = "#/parameters/"
+	componentsSchemas    = "#/components/schemas/"
+	componentsParameters = "#/components/parameters/"
+)
+
+const (
+	mimeTypeJSON           = "application/json"
+	mimeTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	mimeTypeMultipartForm  = "multipart/form-data"
+)
+
+// FromSwagger convert the Swagger 2.0 document to the OpenAPI 3 document.
+// The vendor extensions of ehttp are converted to the OpenAPI 3 keywords:
+//   x-nullable -> nullable, x-writeOnly -> writeOnly, x-oneOf -> oneOf, x-anyOf -> anyOf,
+//   x-discriminator-mapping -> discriminator.mapping, x-example and x-examples -> example.
+func FromSwagger(doc *swagger.Swagger) (*OpenAPI, error) {
+	if doc == nil {
+		return nil, errors.New("the swagger document is nil")
+	}
+	c := &converter{doc: doc}
+	openAPI := &OpenAPI{
+		OpenAPI:      Version,
+		Info:         doc.Info,
+		Servers:      c.getServers(doc.Schemes),
+		Paths:        map[string]*PathItem{},
+		Security:     doc.Security,
+		Tags:         doc.Tags,
+		ExternalDocs: doc.ExternalDocs,
+	}
+	if openAPI.Info == nil {
+		openAPI.Info = &swagger.Info{}
+	}
+	for path, item := range doc.Paths {
+		pathItem, err := c.convertPathItem(item)
+		if err != nil {
+			return nil, errors.New(path + " " + err.Error())
+		}
+		openAPI.Paths[path] = pathItem
+	}
+	components, err := c.getComponents()
+	if err != nil {
+		return nil, err
+	}
+	openAPI.Components = components
+	return openAPI, nil
+}
+
+type converter struct {
+	doc *swagger.Swagger
+}
+
+// getServers the servers from the schemes, the host and the basePath.
+// If the host is empty, the server url is relative (the basePath).
+func (c *converter) getServers(schemes []string) []*Server {
+	if c.doc.Host == "" {
+		if c.doc.BasePath == "" {
+			return nil
+		}
+		return []*Server{&Server{URL: c.doc.BasePath}}
+	}
+	if len(schemes) == 0 {
+		return []*Server{&Server{URL: "//" + c.doc.Host + c.doc.BasePath}}
+	}
+	servers := []*Server{}
+	for _, scheme := range schemes {
+		servers = append(servers, &Server{URL: scheme + "://" + c.doc.Host + c.doc.BasePath})
+	}
+	return servers
+}
+
+func (c *converter) getComponents() (*Components, error) {
+	components := &Components{}
+	if len(c.doc.Definitions) > 0 {
+		components.Schemas = map[string]*Schema{}
+		for name, schema := range c.doc.Definitions {
+			components.Schemas[name] = convertSchema(schema)
+		}
+	}
+	for name, param := range c.doc.Parameters {
+		if param.In == "body" || param.In == "formData" {
+			continue
+		}
+		if components.Parameters == nil {
+			components.Parameters = map[string]*Parameter{}
+		}
+		components.Parameters[name] = convertParameter(param)
+	}
+	for name, security := range c.doc.SecurityDefinitions {
+		scheme, err := convertSecurity(security)
+		if err != nil {
+			return nil, errors.New("securityDefinitions " + name + " " + err.Error())
+		}
+		if components.SecuritySchemes == nil {
+			components.SecuritySchemes = map[string]*SecurityScheme{}
+		}
+		components.SecuritySchemes[name] = scheme
+	}
+	if components.Schemas == nil && components.Parameters == nil && components.SecuritySchemes == nil {
+		return nil, nil
+	}
+	return components, nil
+}
+
+func (c *converter) convertPathItem(item *swagger.Item) (*PathItem, error) {
+	pathItem := &PathItem{Ref: item.Ref}
+	operations := []struct {
+		src *swagger.Operation
+		dst **Operation
+	}{
+		{item.Get, &pathItem.Get},
+		{item.Put, &pathItem.Put},
+		{item.Post, &pathItem.Post},
+		{item.Delete, &pathItem.Delete},
+		{item.Options, &pathItem.Options},
+		{item.Head, &pathItem.Head},
+		{item.Patch, &pathItem.Patch},
+	}
+	for _, operation := range operations {
+		if operation.src == nil {
+			continue
+		}
+		dst, err := c.convertOperation(operation.src)
+		if err != nil {
+			return nil, err
+		}
+		*operation.dst = dst
+	}
+	return pathItem, nil
+}
+
+func (c *converter) convertOperation(src *swagger.Operation) (*Operation, error) {
+	operation := &Operation{
+		Tags:         src.Tags,
+		Summary:      src.Summary,
+		Description:  src.Description,
+		ExternalDocs: src.ExternalDocs,
+		OperationID:  src.OperationID,
+		Responses:    map[string]*Response{},
+		Deprecated:   src.Deprecated,
+		Security:     src.Security,
+		Sunset:       src.Sunset,
+	}
+	if len(src.Schemes) > 0 && c.doc.Host != "" {
+		operation.Servers = c.getServers(src.Schemes)
+	}
+	consumes := getMIMETypes(src.Consumes, c.doc.Consumes)
+	formData := []*swagger.Parameter{}
+	for _, param := range src.Parameters {
+		if param.Ref != "" && strings.HasPrefix(param.Ref, swaggerParameters) {
+			global, ok := c.doc.Parameters[param.Ref[len(swaggerParameters):]]
+			if !ok {
+				return nil, errors.New("the ref " + param.Ref + " is not found")
+			}
+			if global.In == "body" || global.In == "formData" {
+				param = global
+			}
+		}
+		switch param.In {
+		case "body":
+			operation.RequestBody = convertBodyParameter(param, consumes)
+		case "formData":
+			formData = append(formData, param)
+		default:
+			operation.Parameters = append(operation.Parameters, convertParameter(param))
+		}
+	}
+	if len(formData) > 0 {
+		operation.RequestBody = convertFormDataParameters(formData, consumes)
+	}
+	produces := getMIMETypes(src.Produces, c.doc.Produces)
+	for code, response := range src.Responses {
+		operation.Responses[code] = convertResponse(response, produces)
+	}
+	return operation, nil
+}
+
+// getMIMETypes the MIME types of the operation, or the MIME types of the document, the default is application/json
+func getMIMETypes(operationMIMETypes []string, documentMIMETypes []string) []string {
+	if len(operationMIMETypes) > 0 {
+		return operationMIMETypes
+	}
+	if len(documentMIMETypes) > 0 {
+		return documentMIMETypes
+	}
+	return []string{mimeTypeJSON}
+}
+
+func convertParameter(src *swagger.Parameter) *Parameter {
+	if src.Ref != "" {
+		return &Parameter{Ref: convertRef(src.Ref)}
+	}
+	return &Parameter{
+		Name:        src.Name,
+		In:          src.In,
+		Description: src.Description,
+		Required:    src.Required || src.In == "path",
+		Schema:      getParameterSchema(src),
+		Example:     src.Example,
+	}
+}
+
+func getParameterSchema(src *swagger.Parameter) *Schema {
+	schema := &Schema{
+		Type:      src.Type,
+		Format:    src.Format,
+		Items:     convertItems(src.Items),
+		Enum:      src.Enum,
+		Default:   src.Default,
+		Minimum:   src.Minimum,
+		Maximum:   src.Maximum,
+		MinLength: src.MinLength,
+		MaxLength: src.MaxLength,
+	}
+	if schema.Type == "file" {
+		schema.Type, schema.Format = "string", "binary"
+	}
+	return schema
+}
+
+func convertItems(items *swagger.Items) *Schema {
+	if items == nil {
+		return nil
+	}
+	schema := &Schema{Type: items.Type, Format: items.Format}
+	if items.Default != "" {
+		schema.Default = items.Default
+	}
+	if len(items.Items) > 0 {
+		schema.Items = convertItems(items.Items[0])
+	}
+	return schema
+}
+
+func convertBodyParameter(src *swagger.Parameter, consumes []string) *RequestBody {
+	body := &RequestBody{
+		Description: src.Description,
+		Required:    src.Required,
+		Content:     map[string]*MediaType{},
+	}
+	schema := convertSchema(src.Schema)
+	for _, mimeType := range consumes {
+		body.Content[mimeType] = &MediaType{Schema: schema, Example: src.Examples[mimeType]}
+	}
+	return body
+}
+
+// convertFormDataParameters the parameters in formData are the properties of the request body,
+// the media type is multipart/form-data if a parameter is a file.
+func convertFormDataParameters(params []*swagger.Parameter, consumes []string) *RequestBody {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	hasFile := false
+	for _, param := range params {
+		property := getParameterSchema(param)
+		property.Description = param.Description
+		schema.Properties[param.Name] = property
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+		if param.Type == "file" {
+			hasFile = true
+		}
+	}
+	sort.Strings(schema.Required)
+	mimeTypes := []string{}
+	for _, mimeType := range consumes {
+		if mimeType == mimeTypeMultipartForm || (mimeType == mimeTypeFormURLEncoded && !hasFile) {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+	if len(mimeTypes) == 0 {
+		mimeTypes = []string{mimeTypeFormURLEncoded}
+		if hasFile {
+			mimeTypes = []string{mimeTypeMultipartForm}
+		}
+	}
+	body := &RequestBody{Content: map[string]*MediaType{}, Required: len(schema.Required) > 0}
+	for _, mimeType := range mimeTypes {
+		body.Content[mimeType] = &MediaType{Schema: schema}
+	}
+	return body
+}
+
+func convertResponse(src *swagger.Response, produces []string) *Response {
+	if src.Ref != "" {
+		return &Response{Ref: convertRef(src.Ref)}
+	}
+	response := &Response{Description: src.Description}
+	for name, header := range src.Headers {
+		if response.Headers == nil {
+			response.Headers = map[string]*Header{}
+		}
+		response.Headers[name] = convertHeader(header)
+	}
+	if src.Schema == nil && len(src.Examples) == 0 {
+		return response
+	}
+	schema := convertSchema(src.Schema)
+	response.Content = map[string]*MediaType{}
+	for _, mimeType := range produces {
+		response.Content[mimeType] = &MediaType{Schema: schema, Example: src.Examples[mimeType]}
+	}
+	return response
+}
+
+func convertHeader(src *swagger.Header) *Header {
+	return &Header{
+		Description: src.Description,
+		Schema: &Schema{
+			Type:      src.Type,
+			Format:    src.Format,
+			Items:     convertItems(src.Items),
+			Enum:      src.Enum,
+			Default:   src.Default,
+			Minimum:   src.Minimum,
+			Maximum:   src.Maximum,
+			MinLength: src.MinLength,
+			MaxLength: src.MaxLength,
+		},
+		Example: src.Example,
+	}
+}
+
+func convertSchema(src *swagger.Schema) *Schema {
+	if src == nil {
+		return nil
+	}
+	schema := &Schema{
+		Ref:         convertRef(src.Ref),
+		Title:       src.Title,
+		Description: src.Description,
+		Type:        src.Type,
+		Format:      src.Format,
+		Items:       convertSchema(src.Items),
+		Enum:        src.Enum,
+		XML:         src.XML,
+		AllOf:       convertSchemas(src.AllOf),
+		OneOf:       convertSchemas(src.OneOf),
+		AnyOf:       convertSchemas(src.AnyOf),
+	}
+	if schema.Type == "file" {
+		schema.Type, schema.Format = "string", "binary"
+	}
+	schema.Properties, schema.Required = convertProperties(src.Properties)
+	if src.Discriminator != "" {
+		schema.Discriminator = &Discriminator{PropertyName: src.Discriminator}
+		for value, ref := range src.DiscriminatorMapping {
+			if schema.Discriminator.Mapping == nil {
+				schema.Discriminator.Mapping = map[string]string{}
+			}
+			schema.Discriminator.Mapping[value] = convertRef(ref)
+		}
+	}
+	return schema
+}
+
+func convertSchemas(src []*swagger.Schema) []*Schema {
+	if len(src) == 0 {
+		return nil
+	}
+	result := []*Schema{}
+	for _, schema := range src {
+		result = append(result, convertSchema(schema))
+	}
+	return result
+}
+
+// convertProperties the properties, and the names of the required properties sort by name
+func convertProperties(src map[string]*swagger.Propertie) (map[string]*Schema, []string) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	properties := map[string]*Schema{}
+	required := []string{}
+	for name, propertie := range src {
+		properties[name] = convertPropertie(propertie)
+		if propertie.Required {
+			required = append(required, name)
+		}
+	}
+	if len(required) == 0 {
+		return properties, nil
+	}
+	sort.Strings(required)
+	return properties, required
+}
+
+func convertPropertie(src *swagger.Propertie) *Schema {
+	if src == nil {
+		return nil
+	}
+	schema := &Schema{
+		Ref:                  convertRef(src.Ref),
+		Title:                src.Title,
+		Description:          src.Description,
+		Type:                 src.Type,
+		Format:               src.Format,
+		Items:                convertPropertie(src.Items),
+		AdditionalProperties: convertPropertie(src.AdditionalProperties),
+		Enum:                 src.Enum,
+		Default:              src.Default,
+		Example:              src.Example,
+		Minimum:              src.Minimum,
+		Maximum:              src.Maximum,
+		MinLength:            src.MinLength,
+		MaxLength:            src.MaxLength,
+		Nullable:             src.Nullable,
+		ReadOnly:             src.ReadOnly,
+		WriteOnly:            src.WriteOnly,
+		XML:                  src.XML,
+	}
+	schema.Properties, schema.Required = convertProperties(src.Properties)
+	for _, propertie := range src.AllOf {
+		schema.AllOf = append(schema.AllOf, convertPropertie(propertie))
+	}
+	return schema
+}
+
+func convertSecurity(src *swagger.Security) (*SecurityScheme, error) {
+	scheme := &SecurityScheme{Description: src.Description}
+	switch src.Type {
+	case "basic":
+		scheme.Type, scheme.Scheme = "http", "basic"
+	case "apiKey":
+		scheme.Type, scheme.Name, scheme.In = "apiKey", src.Name, src.In
+	case "oauth2":
+		scheme.Type = "oauth2"
+		scopes := src.Scopes
+		if scopes == nil {
+			scopes = map[string]string{}
+		}
+		flow := &OAuthFlow{AuthorizationURL: src.AuthorizationURL, TokenURL: src.TokenURL, Scopes: scopes}
+		switch src.Flow {
+		case "implicit":
+			scheme.Flows = &OAuthFlows{Implicit: flow}
+		case "password":
+			scheme.Flows = &OAuthFlows{Password: flow}
+		case "application":
+			scheme.Flows = &OAuthFlows{ClientCredentials: flow}
+		case "accessCode":
+			scheme.Flows = &OAuthFlows{AuthorizationCode: flow}
+		default:
+			return nil, errors.New("invalid oauth2 flow " + src.Flow)
+		}
+	default:
+		return nil, errors.New("invalid type " + src.Type)
+	}
+	return scheme, nil
+}
+
+// convertRef #/definitions/X -> #/components/schemas/X, #/parameters/X -> #/components/parameters/X
+func convertRef(ref string) string {
+	if strings.HasPrefix(ref, swaggerDefinitions) {
+		return componentsSchemas + ref[len(swaggerDefinitions):]
+	}
+	if strings.HasPrefix(ref, swaggerParameters) {
+		return componentsParameters + ref[len(swaggerParameters):]
+	}
+	return ref
+}
@@ -0,0 +1,159 @@
+// Package openapi3 the OpenAPI 3 document, and the conversion from the Swagger 2.0 document.
+package openapi3
+
+import "github.com/enjoy-web/ehttp/swagger"
+
+// Version the version of the OpenAPI Specification
+const Version = "3.0.3"
+
+// OpenAPI https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md
+// The Info, Tag, ExternalDocs and XML objects are same as the Swagger 2.0 objects.
+type OpenAPI struct {
+	OpenAPI      string                `json:"openapi" yaml:"openapi"`
+	Info         *swagger.Info         `json:"info" yaml:"info"`
+	Servers      []*Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths        map[string]*PathItem  `json:"paths" yaml:"paths"`
+	Components   *Components           `json:"components,omitempty" yaml:"components,omitempty"`
+	Security     []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Tags         []*swagger.Tag        `json:"tags,omitempty" yaml:"tags,omitempty"`
+	ExternalDocs *swagger.ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+}
+
+// Server An object representing a Server.
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PathItem Describes the operations available on a single path.
+type PathItem struct {
+	Ref     string     `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Get     *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty" yaml:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+}
+
+// Operation Describes a single API operation on a path.
+type Operation struct {
+	Tags         []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary      string                `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description  string                `json:"description,omitempty" yaml:"description,omitempty"`
+	ExternalDocs *swagger.ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	OperationID  string                `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters   []*Parameter          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody  *RequestBody          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses    map[string]*Response  `json:"responses" yaml:"responses"`
+	Deprecated   bool                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Security     []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Servers      []*Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Sunset       string                `json:"x-sunset,omitempty" yaml:"x-sunset,omitempty"` // the HTTP-date when the deprecated operation will become unresponsive
+}
+
+// Parameter Describes a single operation parameter (in path, query, header or cookie).
+type Parameter struct {
+	Ref         string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name        string      `json:"name,omitempty" yaml:"name,omitempty"`
+	In          string      `json:"in,omitempty" yaml:"in,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool        `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// RequestBody Describes a single request body.
+type RequestBody struct {
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content" yaml:"content"`
+	Required    bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// MediaType provides schema and examples for the media type.
+type MediaType struct {
+	Schema  *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Response Describes a single response from an API Operation.
+type Response struct {
+	Ref         string                `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                `json:"description" yaml:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Header describes the type of the header.
+type Header struct {
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+}
+
+// Components Holds a set of reusable objects for different aspects of the OAS.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+// Schema The Schema Object allows the definition of input and output data types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty" yaml:"title,omitempty"`
+	Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
+	Example              interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	Discriminator        *Discriminator     `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	XML                  *swagger.XML       `json:"xml,omitempty" yaml:"xml,omitempty"`
+}
+
+// Discriminator the name of the property to differentiate the schemas, and the mapping from the values to the schemas.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName" yaml:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+}
+
+// SecurityScheme Defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	Type        string      `json:"type" yaml:"type"` // Valid values are "apiKey", "http", "oauth2", "openIdConnect".
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Name        string      `json:"name,omitempty" yaml:"name,omitempty"`
+	In          string      `json:"in,omitempty" yaml:"in,omitempty"`
+	Scheme      string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	Flows       *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+}
+
+// OAuthFlows Allows configuration of the supported OAuth Flows.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow Configuration details for a supported OAuth Flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes" yaml:"scopes"`
+}
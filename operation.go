@@ -0,0 +1,109 @@
+package ehttp
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+var anonymousFunctionRegexp = regexp.MustCompile(`(^|\.)func\d+(\.|$)`)
+var genericFunctionRegexp = regexp.MustCompile(`\[.*\]`)
+var notIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// getOperationIDFromHandlerName the operationId derived from the name of the handler function.
+// examples:
+//   main.HandleGETBook -> HandleGETBook
+//   github.com/enjoy-web/demo/api.(*BookAPI).Get-fm -> BookAPI_Get
+//   main.main.func1 -> "" (an anonymous function has no name)
+func getOperationIDFromHandlerName(handlerName string) string {
+	name := handlerName[strings.LastIndex(handlerName, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	name = genericFunctionRegexp.ReplaceAllString(name, "")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" || anonymousFunctionRegexp.MatchString(name) {
+		return ""
+	}
+	return strings.NewReplacer("(", "", ")", "", "*", "", ".", "_").Replace(name)
+}
+
+// getOperationIDFromPath the operationId derived from the method and the swagger path.
+// examples:
+//   GET /books/{id} -> getBooksId
+//   POST /book-shelves -> postBookShelves
+func getOperationIDFromPath(method string, swaggerPath string) string {
+	operationID := strings.ToLower(method)
+	for _, word := range notIdentifierRegexp.Split(swaggerPath, -1) {
+		operationID += upperFirstLetter(word)
+	}
+	return operationID
+}
+
+// setOperationID set the operationId of the operation if it is empty, and check if the operationId is unique in the engine.
+//   The auto operationId is derived from the name of the handler function,
+//   if the handler is an anonymous function or the name is already used, it is derived from the method and the path.
+//   The operationId is claimed when the operation is documented (see Engine.claimOperationID).
+func (e *Engine) setOperationID(swaggerPath string, method string, operation *swagger.Operation, handlerName string) error {
+	operationID, err := getOperationID(e.operationIDs, swaggerPath, method, operation.OperationID, handlerName)
+	if err != nil {
+		return err
+	}
+	operation.OperationID = operationID
+	return nil
+}
+
+// claimOperationID claim the operationId of the documented operation in the engine (see Engine.setOperationID)
+func (e *Engine) claimOperationID(swaggerPath string, method string, operation *swagger.Operation) {
+	if e.operationIDs == nil {
+		e.operationIDs = map[string]string{}
+	}
+	e.operationIDs[operation.OperationID] = method + " " + swaggerPath
+}
+
+// claimOperationID claim the operationId (the auto operationId if it is empty) for the operation in operationIDs
+// (the operationId -> the method and the path of the operation), see Engine.setOperationID
+func claimOperationID(operationIDs map[string]string, swaggerPath string, method string, operationID string, handlerName string) (string, error) {
+	operationID, err := getOperationID(operationIDs, swaggerPath, method, operationID, handlerName)
+	if err != nil {
+		return "", err
+	}
+	operationIDs[operationID] = method + " " + swaggerPath
+	return operationID, nil
+}
+
+// getOperationID the operationId (the auto operationId if it is empty) of the operation,
+// it must not be used by the other operations in operationIDs
+func getOperationID(operationIDs map[string]string, swaggerPath string, method string, operationID string, handlerName string) (string, error) {
+	key := method + " " + swaggerPath
+	if operationID == "" {
+		operationID = getOperationIDFromHandlerName(handlerName)
+		if owner, ok := operationIDs[operationID]; operationID == "" || (ok && owner != key) {
+			operationID = getOperationIDFromPath(method, swaggerPath)
+		}
+	}
+	if owner, ok := operationIDs[operationID]; ok && owner != key {
+		return "", errors.New("the operationId " + operationID + " is already used by " + owner)
+	}
+	return operationID, nil
+}
+
+// getDeprecationHeaders the headers of the responses of a deprecated operation, return nil if the operation is not deprecated.
+func getDeprecationHeaders(operation *swagger.Operation) map[string]string {
+	if operation == nil || !operation.Deprecated {
+		return nil
+	}
+	headers := map[string]string{"Deprecation": "true"}
+	if operation.Sunset != "" {
+		headers["Sunset"] = operation.Sunset
+	}
+	return headers
+}
+
+func setResponseHeaders(c Context, headers map[string]string) {
+	for key, value := range headers {
+		c.ResponseHeader().Set(key, value)
+	}
+}
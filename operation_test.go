@@ -0,0 +1,146 @@
+package ehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testOperationAPI struct{}
+
+func (api *testOperationAPI) Get(c *gin.Context, err error) {}
+
+func testHandleOperation(c *gin.Context, err error) {}
+
+func TestGetOperationIDFromHandlerName(t *testing.T) {
+	api := &testOperationAPI{}
+	nodes := []struct {
+		handler     HandlerFunc
+		operationID string
+	}{
+		{testHandleOperation, "testHandleOperation"},
+		{api.Get, "testOperationAPI_Get"},
+		{func(c *gin.Context, err error) {}, ""},
+	}
+	for index, node := range nodes {
+		operationID := getOperationIDFromHandlerName(nameOfFunction(node.handler))
+		if operationID != node.operationID {
+			testError(t, index, operationID+" should be equal to "+node.operationID)
+		}
+	}
+}
+
+func TestGetOperationIDFromPath(t *testing.T) {
+	nodes := []struct {
+		method      string
+		path        string
+		operationID string
+	}{
+		{GET, "/books/{id}", "getBooksId"},
+		{POST, "/book-shelves", "postBookShelves"},
+		{DELETE, "/", "delete"},
+	}
+	for index, node := range nodes {
+		if operationID := getOperationIDFromPath(node.method, node.path); operationID != node.operationID {
+			testError(t, index, operationID+" should be equal to "+node.operationID)
+		}
+	}
+}
+
+func newTestOperationDoc(operationID string) *APIDocCommon {
+	return &APIDocCommon{
+		OperationID: operationID,
+		Parameters: map[string]Parameter{
+			"id": Parameter{InPath: &ValueInfo{Type: "string"}},
+		},
+	}
+}
+
+func TestEngineOperationID(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{})
+	anonymous := func(c *gin.Context, err error) {}
+	if err := router.GET("/books/:id", newTestOperationDoc(""), testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	// the name of the handler is already used
+	if err := router.DELETE("/books/:id", newTestOperationDoc(""), testHandleOperation); err != nil {
+		testError(t, err)
+	}
+	if err := router.POST("/books", &APIDocCommon{}, anonymous); err != nil {
+		testError(t, err)
+	}
+	if err := router.PUT("/books/:id", newTestOperationDoc("updateBook"), anonymous); err != nil {
+		testError(t, err)
+	}
+	if err := router.PATCH("/books/:id", newTestOperationDoc("updateBook"), anonymous); err == nil {
+		testError(t, "err should not be nil, the operationId updateBook is already used")
+	} else {
+		testLog(t, err)
+	}
+	paths := router.Swagger.Paths
+	operationIDs := []string{
+		paths["/books/{id}"].Get.OperationID,
+		paths["/books/{id}"].Delete.OperationID,
+		paths["/books"].Post.OperationID,
+		paths["/books/{id}"].Put.OperationID,
+	}
+	expected := []string{"testHandleOperation", "deleteBooksId", "postBooks", "updateBook"}
+	for index := range expected {
+		if operationIDs[index] != expected[index] {
+			testError(t, index, operationIDs[index]+" should be equal to "+expected[index])
+		}
+	}
+}
+
+func TestEngineDeprecatedOperation(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{})
+	sunset := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := &APIDocCommon{
+		Deprecated:   true,
+		Sunset:       sunset,
+		ExternalDocs: &ExternalDocs{Description: "the new API", URL: "https://example.com/docs/books"},
+		Schemes:      []Scheme{SchemeHTTPS},
+	}
+	if err := router.GET("/books", doc, testHandleOperation); err != nil {
+		testError(t, err)
+		return
+	}
+	operation := router.Swagger.Paths["/books"].Get
+	if !operation.Deprecated || operation.Sunset != "Wed, 02 Jan 2030 03:04:05 GMT" {
+		testError(t, "the operation should be deprecated with the sunset", operation.Sunset)
+	}
+	if operation.ExternalDocs == nil || operation.ExternalDocs.URL != "https://example.com/docs/books" {
+		testError(t, "the externalDocs should be set", operation.ExternalDocs)
+	}
+	if len(operation.Schemes) != 1 || operation.Schemes[0] != "https" {
+		testError(t, "the schemes should be [https]", operation.Schemes)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(GET, "/books", nil)
+	router.GinEngine().ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "true" {
+		testError(t, "the response should have the header Deprecation")
+	}
+	if w.Header().Get("Sunset") != operation.Sunset {
+		testError(t, "the response should have the header Sunset")
+	}
+
+	invalidDocs := []*APIDocCommon{
+		&APIDocCommon{Sunset: sunset},
+		&APIDocCommon{ExternalDocs: &ExternalDocs{Description: "no url"}},
+		&APIDocCommon{Schemes: []Scheme{"ftp"}},
+	}
+	for index, doc := range invalidDocs {
+		if _, err := doc.ToSwaggerOperation(); err == nil {
+			testError(t, index, "err should not be nil")
+		} else {
+			testLog(t, index, err)
+		}
+	}
+}
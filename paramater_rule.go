@@ -4,13 +4,12 @@ import (
 	"errors"
 	"strconv"
 	"strings"
-
-	"github.com/gin-gonic/gin"
+	"unicode/utf8"
 )
 
 // parameterRule the rule of the parameter, check if parameter is valid
 type parameterRule interface {
-	Check(*gin.Context) error
+	Check(Context) error
 }
 
 // parameterRuleBase the base class of parameterRule
@@ -21,17 +20,19 @@ type parameterRuleBase struct {
 }
 
 // GetValue get parameter value from the http request
-func (p parameterRuleBase) GetValue(c *gin.Context) (string, error) {
+func (p parameterRuleBase) GetValue(c Context) (string, error) {
 	var value string
 	switch p.In {
 	case "header":
-		value = c.GetHeader(p.Name)
+		value = c.Request().Header.Get(p.Name)
 	case "path":
 		value = c.Param(p.Name)
 	case "query":
-		value = c.Query(p.Name)
+		value = c.Request().URL.Query().Get(p.Name)
 	case "formData":
-		value = c.PostForm(p.Name)
+		value = getPostForm(c.Request(), p.Name)
+	case inResponseHeader:
+		value = c.ResponseHeader().Get(p.Name)
 	default:
 		return "", errors.New("parameter in " + p.In + " is not supported")
 	}
@@ -55,7 +56,7 @@ type parameterRuleInt struct {
 }
 
 // Check if parameter is valid
-func (p parameterRuleInt) Check(c *gin.Context) error {
+func (p parameterRuleInt) Check(c Context) error {
 	value, err := p.GetValue(c)
 	if err != nil {
 		return err
@@ -97,7 +98,7 @@ type parameterRuleUint struct {
 }
 
 // Check if parameter is valid
-func (p parameterRuleUint) Check(c *gin.Context) error {
+func (p parameterRuleUint) Check(c Context) error {
 	value, err := p.GetValue(c)
 	if err != nil {
 		return err
@@ -138,7 +139,7 @@ type parameterRuleFloat struct {
 }
 
 // Check if parameter is valid
-func (p parameterRuleFloat) Check(c *gin.Context) error {
+func (p parameterRuleFloat) Check(c Context) error {
 	value, err := p.GetValue(c)
 	if err != nil {
 		return err
@@ -166,11 +167,13 @@ func (p parameterRuleFloat) Check(c *gin.Context) error {
 // parameterRuleString the rule of the parameter(type is string), check if parameter is valid
 type parameterRuleString struct {
 	parameterRuleBase
-	Enum map[string]bool
+	Enum   map[string]bool
+	MinLen *int64
+	MaxLen *int64
 }
 
 // Check if parameter is valid
-func (p parameterRuleString) Check(c *gin.Context) error {
+func (p parameterRuleString) Check(c Context) error {
 	value, err := p.GetValue(c)
 	if err != nil {
 		return err
@@ -183,6 +186,13 @@ func (p parameterRuleString) Check(c *gin.Context) error {
 			return errors.New("invalid enum type (" + value + ")")
 		}
 	}
+	length := int64(utf8.RuneCountInString(value))
+	if p.MinLen != nil && length < *p.MinLen {
+		return errors.New(p.Name + " is shorter than the minimum length " + strconv.FormatInt(*p.MinLen, 10))
+	}
+	if p.MaxLen != nil && length > *p.MaxLen {
+		return errors.New(p.Name + " is longer than the maximum length " + strconv.FormatInt(*p.MaxLen, 10))
+	}
 	return nil
 }
 
@@ -192,7 +202,7 @@ type parameterRuleBool struct {
 }
 
 // Check if parameter is valid
-func (p parameterRuleBool) Check(c *gin.Context) error {
+func (p parameterRuleBool) Check(c Context) error {
 	value, err := p.GetValue(c)
 	if err != nil {
 		return err
@@ -252,8 +262,9 @@ func toParameterRules(name string, param *Parameter) ([]parameterRule, error) {
 	return rules, nil
 }
 
+// hasParameterRule the files are only documented, they are not checked (like: a required file)
 func hasParameterRule(valueInfo *ValueInfo) bool {
-	if valueInfo == nil {
+	if valueInfo == nil || valueInfo.Type == "file" {
 		return false
 	}
 	if valueInfo.hasEnum() {
@@ -268,6 +279,9 @@ func hasParameterRule(valueInfo *ValueInfo) bool {
 	if valueInfo.isBool() {
 		return true
 	}
+	if valueInfo.isString() && (valueInfo.MinLen != "" || valueInfo.MaxLen != "") {
+		return true
+	}
 	if valueInfo.Required {
 		return true
 	}
@@ -312,6 +326,13 @@ func newParameterRuleString(name string, in string, valueInfo *ValueInfo) (param
 			rule.Enum[enumType] = true
 		}
 	}
+	var err error
+	if rule.MinLen, err = valueInfo.getMinLen(); err != nil {
+		return nil, err
+	}
+	if rule.MaxLen, err = valueInfo.getMaxLen(); err != nil {
+		return nil, err
+	}
 	return rule, nil
 }
 
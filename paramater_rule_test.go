@@ -2,6 +2,7 @@ package ehttp
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -107,6 +108,11 @@ func TestParameterRuleCheck(t *testing.T) {
 		{"role", Parameter{InQuery: &ValueInfo{Type: "string", Required: true}}, "http://127.0.0.1:/dev/xx", true},
 		// err: role xxxxx is not a valid enum
 		{"role", Parameter{InQuery: &ValueInfo{Type: "string", Enum: "admin normal"}}, "http://127.0.0.1:/dev/xx?role=xxx", true},
+		{"name", Parameter{InQuery: &ValueInfo{Type: "string", MinLen: "2", MaxLen: "4"}}, "http://127.0.0.1:/dev/xx?name=abcd", false},
+		// err: name is shorter than the minimum length 2
+		{"name", Parameter{InQuery: &ValueInfo{Type: "string", MinLen: "2", MaxLen: "4"}}, "http://127.0.0.1:/dev/xx?name=a", true},
+		// err: name is longer than the maximum length 4
+		{"name", Parameter{InQuery: &ValueInfo{Type: "string", MinLen: "2", MaxLen: "4"}}, "http://127.0.0.1:/dev/xx?name=abcde", true},
 	}
 
 	for index, test := range tests {
@@ -120,7 +126,7 @@ func TestParameterRuleCheck(t *testing.T) {
 			testError(t, "tests[", index, "] error:", err)
 		}
 		for _, rule := range rules {
-			err := rule.Check(c)
+			err := rule.Check(ginContext{c})
 			if test.WantHasError {
 				if err == nil {
 					testError(t, "tests[", index, "] error:", err, ",WantHasError:", test.WantHasError)
@@ -135,3 +141,34 @@ func TestParameterRuleCheck(t *testing.T) {
 		}
 	}
 }
+
+func TestParameterRuleFile(t *testing.T) {
+	// the files are only documented
+	rules, err := toParameterRules("cover", &Parameter{InFormData: &ValueInfo{Type: "file", Required: true}})
+	if err != nil || len(rules) != 0 {
+		testError(t, "the required file should not have a rule", err, rules)
+	}
+}
+
+func TestParameterRuleString_Length(t *testing.T) {
+	rules, err := toParameterRules("name", &Parameter{InQuery: &ValueInfo{Type: "string", MinLen: "2", MaxLen: "4"}})
+	if err != nil || len(rules) != 1 {
+		testError(t, "the string with the lengths should have a rule", err)
+		return
+	}
+	tests := map[string]string{
+		"a":     "name is shorter than the minimum length 2",
+		"abcde": "name is longer than the maximum length 4",
+		// the length is the number of characters
+		"书名": "",
+	}
+	for value, expected := range tests {
+		req, _ := http.NewRequest("GET", "http://127.0.0.1/dev/xx?name="+url.QueryEscape(value), nil)
+		err := rules[0].Check(ginContext{&gin.Context{Request: req}})
+		if expected == "" && err != nil {
+			testError(t, value, err)
+		} else if expected != "" && (err == nil || err.Error() != expected) {
+			testError(t, value, "the error should be "+expected, err)
+		}
+	}
+}
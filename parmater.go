@@ -85,6 +85,21 @@ func (p Parameter) toSwaggerParameters(name string) ([]*swagger.Parameter, error
 	return parameters, nil
 }
 
+// getIn the first location of the parameter (path, header, query, formData), like the first of toSwaggerParameters
+func (p Parameter) getIn() string {
+	switch {
+	case p.InPath != nil:
+		return InPath
+	case p.InHeader != nil:
+		return InHeader
+	case p.InQuery != nil:
+		return InQuery
+	case p.InFormData != nil:
+		return InFormData
+	}
+	return ""
+}
+
 type parameterError struct {
 	Name string
 	Err  error
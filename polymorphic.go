@@ -0,0 +1,276 @@
+package ehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Polymorphic declares that an interface type is one of a set of struct types.
+// Fields:
+//   Discriminator -- the json name of the property which tells the struct types apart (like: "type").
+//                    Every struct type must have a string field with this name.
+//   Mapping -- the value of the discriminator property -> the struct object (like: "created": &EventCreated{})
+//   AnyOf -- the value may match more than one of the struct types (anyOf), default is oneOf
+type Polymorphic struct {
+	Discriminator string
+	Mapping       map[string]interface{}
+	AnyOf         bool
+}
+
+// RegisterPolymorphic register the struct types of an interface type.
+// The argument iface must be a nil pointer to the interface (like: (*Event)(nil)).
+// After registration, the interface can be used as the type of a struct field, or as the Model of a Request or a Response.
+//
+// example:
+//    type Event interface{ EventType() string }
+//    err := ehttp.RegisterPolymorphic((*Event)(nil), ehttp.Polymorphic{
+//    	Discriminator: "type",
+//    	Mapping: map[string]interface{}{
+//    		"created": &EventCreated{},
+//    		"deleted": &EventDeleted{},
+//    	},
+//    })
+func RegisterPolymorphic(iface interface{}, p Polymorphic) error {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return errors.New("RegisterPolymorphic: the argument iface must be a nil pointer to an interface, like (*Event)(nil)")
+	}
+	polymorphic, err := newPolymorphicType(t.Elem(), p)
+	if err != nil {
+		return errors.New("RegisterPolymorphic: " + t.Elem().String() + ", " + err.Error())
+	}
+	polymorphicTypes.add(polymorphic)
+	return nil
+}
+
+// DecodePolymorphicJSON decode the JSON data into the struct type selected by the discriminator.
+// The argument v must be a pointer to a registered interface (like: var event Event; DecodePolymorphicJSON(data, &event)).
+func DecodePolymorphicJSON(data []byte, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Interface {
+		return errors.New("DecodePolymorphicJSON: the argument v must be a pointer to an interface")
+	}
+	polymorphic, ok := polymorphicTypes.get(value.Elem().Type())
+	if !ok {
+		return errors.New("DecodePolymorphicJSON: " + value.Elem().Type().String() + " is not registered")
+	}
+	obj, err := polymorphic.decode(data)
+	if err != nil {
+		return err
+	}
+	value.Elem().Set(reflect.ValueOf(obj))
+	return nil
+}
+
+// polymorphicType the registered Polymorphic of an interface type
+type polymorphicType struct {
+	Polymorphic
+	ifaceType   reflect.Type
+	structTypes map[string]reflect.Type
+}
+
+func newPolymorphicType(ifaceType reflect.Type, p Polymorphic) (*polymorphicType, error) {
+	if len(p.Mapping) == 0 {
+		return nil, errors.New("Mapping should not be empty")
+	}
+	if p.Discriminator != "" {
+		if err := checkNameFormat(p.Discriminator); err != nil {
+			return nil, err
+		}
+	}
+	polymorphic := &polymorphicType{
+		Polymorphic: p,
+		ifaceType:   ifaceType,
+		structTypes: map[string]reflect.Type{},
+	}
+	for value, obj := range p.Mapping {
+		structType, err := getStructReflectType(obj)
+		if err != nil {
+			return nil, err
+		}
+		if !structType.Implements(ifaceType) && !reflect.PtrTo(structType).Implements(ifaceType) {
+			return nil, errors.New(structType.String() + " does not implement " + ifaceType.String())
+		}
+		if p.Discriminator != "" {
+			if err := checkDiscriminatorField(structType, p.Discriminator); err != nil {
+				return nil, err
+			}
+		}
+		polymorphic.structTypes[value] = structType
+	}
+	return polymorphic, nil
+}
+
+// checkDiscriminatorField check if the struct has a string field named discriminator
+func checkDiscriminatorField(structType reflect.Type, discriminator string) error {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, err := getStructFieldName(field)
+		if err != nil {
+			return err
+		}
+		if name == discriminator {
+			if field.Type.Kind() != reflect.String {
+				return errors.New("the discriminator " + discriminator + " in " + structType.String() + " must be a string")
+			}
+			return nil
+		}
+	}
+	return errors.New(structType.String() + " has no discriminator field " + discriminator)
+}
+
+// getDiscriminatorValues get the values of the discriminator, sort by value
+func (p *polymorphicType) getDiscriminatorValues() []string {
+	values := []string{}
+	for value := range p.structTypes {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// getDiscriminatorValue get the value of the discriminator by the struct type
+func (p *polymorphicType) getDiscriminatorValue(structType reflect.Type) (string, bool) {
+	for value, t := range p.structTypes {
+		if t == structType {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// decode the JSON data into a new struct object selected by the discriminator, return a pointer to the struct
+func (p *polymorphicType) decode(data []byte) (interface{}, error) {
+	if p.Discriminator == "" {
+		return nil, errors.New("the interface " + p.ifaceType.String() + " has no discriminator")
+	}
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	raw, ok := values[p.Discriminator]
+	if !ok {
+		return nil, errors.New("miss the discriminator " + p.Discriminator)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, errors.New("the discriminator " + p.Discriminator + " must be a string")
+	}
+	structType, ok := p.structTypes[value]
+	if !ok {
+		return nil, errors.New("invalid discriminator " + p.Discriminator + " (" + value + ")")
+	}
+	obj := reflect.New(structType)
+	if err := json.Unmarshal(data, obj.Interface()); err != nil {
+		return nil, err
+	}
+	if obj.Type().Implements(p.ifaceType) {
+		return obj.Interface(), nil
+	}
+	return obj.Elem().Interface(), nil
+}
+
+var polymorphicTypes = &polymorphicRegistry{}
+
+type polymorphicRegistry struct {
+	lock sync.RWMutex
+	m    map[reflect.Type]*polymorphicType
+}
+
+func (r *polymorphicRegistry) add(p *polymorphicType) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.m == nil {
+		r.m = map[reflect.Type]*polymorphicType{}
+	}
+	r.m[p.ifaceType] = p
+}
+
+func (r *polymorphicRegistry) get(ifaceType reflect.Type) (*polymorphicType, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	p, ok := r.m[ifaceType]
+	return p, ok
+}
+
+// getByStructType get the registered interface types with a discriminator, which contain the struct type
+func (r *polymorphicRegistry) getByStructType(structType reflect.Type) []*polymorphicType {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	list := []*polymorphicType{}
+	for _, p := range r.m {
+		if p.Discriminator == "" {
+			continue
+		}
+		if _, ok := p.getDiscriminatorValue(structType); ok {
+			list = append(list, p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ifaceType.String() < list[j].ifaceType.String()
+	})
+	return list
+}
+
+// isPolymorphicType check if the type is a registered interface
+func isPolymorphicType(t reflect.Type) bool {
+	if t.Kind() != reflect.Interface {
+		return false
+	}
+	_, ok := polymorphicTypes.get(t)
+	return ok
+}
+
+// getStructDocFromPolymorphicType get the StructDoc of a registered interface
+func getStructDocFromPolymorphicType(t reflect.Type, commentsFromSource bool) (*StructDoc, error) {
+	polymorphic, ok := polymorphicTypes.get(t)
+	if !ok {
+		return nil, &invalidStructError{t}
+	}
+	structName, err := getStructName(t)
+	if err != nil {
+		return nil, err
+	}
+	doc := &StructDoc{
+		UUID:          getStructUUIDFromPkgPathAndName(t.PkgPath(), structName),
+		StructName:    structName,
+		GoPkgPath:     t.PkgPath(),
+		StructFields:  []*StructField{},
+		Description:   docComments.get(t.PkgPath(), getDocCommentKey(t), commentsFromSource),
+		Discriminator: polymorphic.Discriminator,
+	}
+	if polymorphic.Discriminator != "" {
+		doc.DiscriminatorMapping = map[string]string{}
+	}
+	for _, value := range polymorphic.getDiscriminatorValues() {
+		structUUID, err := getStructUUID(polymorphic.structTypes[value])
+		if err != nil {
+			return nil, err
+		}
+		if polymorphic.AnyOf {
+			doc.AnyOf = append(doc.AnyOf, structUUID)
+		} else {
+			doc.OneOf = append(doc.OneOf, structUUID)
+		}
+		if polymorphic.Discriminator != "" {
+			doc.DiscriminatorMapping[value] = structUUID
+		}
+	}
+	return doc, nil
+}
+
+// getPolymorphicStructTypes get the struct types of a registered interface, sort by the value of the discriminator
+func getPolymorphicStructTypes(t reflect.Type) []reflect.Type {
+	polymorphic, ok := polymorphicTypes.get(t)
+	if !ok {
+		return nil
+	}
+	structTypes := []reflect.Type{}
+	for _, value := range polymorphic.getDiscriminatorValues() {
+		structTypes = append(structTypes, polymorphic.structTypes[value])
+	}
+	return structTypes
+}
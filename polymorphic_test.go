@@ -0,0 +1,199 @@
+package ehttp
+
+import (
+	"testing"
+)
+
+type testEvent interface {
+	EventType() string
+}
+
+type testEventCreated struct {
+	Type  string `json:"type"`
+	ID    string `json:"id" readonly:"true"`
+	Title string `json:"title"`
+}
+
+func (e testEventCreated) EventType() string { return e.Type }
+
+type testEventDeleted struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+func (e *testEventDeleted) EventType() string { return e.Type }
+
+type testEventList struct {
+	Total  int64       `json:"total"`
+	Events []testEvent `json:"events"`
+}
+
+type testNotEvent struct {
+	Type string `json:"type"`
+}
+
+type testEventNoType struct {
+	Title string `json:"title"`
+}
+
+func (e testEventNoType) EventType() string { return "" }
+
+func registerTestEvent(t *testing.T) {
+	err := RegisterPolymorphic((*testEvent)(nil), Polymorphic{
+		Discriminator: "type",
+		Mapping: map[string]interface{}{
+			"created": &testEventCreated{},
+			"deleted": &testEventDeleted{},
+		},
+	})
+	if err != nil {
+		testError(t, err)
+	}
+}
+
+func TestRegisterPolymorphic(t *testing.T) {
+	registerTestEvent(t)
+
+	invalidList := []struct {
+		iface interface{}
+		p     Polymorphic
+	}{
+		// err: not a pointer to an interface
+		{testEventCreated{}, Polymorphic{Discriminator: "type", Mapping: map[string]interface{}{"created": &testEventCreated{}}}},
+		// err: empty mapping
+		{(*testEvent)(nil), Polymorphic{Discriminator: "type"}},
+		// err: testNotEvent does not implement testEvent
+		{(*testEvent)(nil), Polymorphic{Discriminator: "type", Mapping: map[string]interface{}{"created": &testNotEvent{}}}},
+		// err: testEventNoType has no discriminator field
+		{(*testEvent)(nil), Polymorphic{Discriminator: "type", Mapping: map[string]interface{}{"created": &testEventNoType{}}}},
+		// err: the mapping value is not a struct
+		{(*testEvent)(nil), Polymorphic{Discriminator: "type", Mapping: map[string]interface{}{"created": "abc"}}},
+	}
+	for index, node := range invalidList {
+		if err := RegisterPolymorphic(node.iface, node.p); err != nil {
+			testLog(t, index, err)
+		} else {
+			testError(t, index, "err should not be nil")
+		}
+	}
+}
+
+func TestDecodePolymorphicJSON(t *testing.T) {
+	registerTestEvent(t)
+
+	var event testEvent
+	if err := DecodePolymorphicJSON([]byte(`{"type":"created","title":"book"}`), &event); err != nil {
+		testError(t, err)
+	} else if created, ok := event.(*testEventCreated); !ok || created.Title != "book" {
+		testError(t, "event should be *testEventCreated")
+	}
+	if err := DecodePolymorphicJSON([]byte(`{"type":"deleted","reason":"expired"}`), &event); err != nil {
+		testError(t, err)
+	} else if deleted, ok := event.(*testEventDeleted); !ok || deleted.Reason != "expired" {
+		testError(t, "event should be *testEventDeleted")
+	}
+
+	invalidList := []string{
+		`{"title":"book"}`,
+		`{"type":"updated"}`,
+		`{"type":1}`,
+		`[]`,
+	}
+	for _, data := range invalidList {
+		if err := DecodePolymorphicJSON([]byte(data), &event); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, data+" should be invalid")
+		}
+	}
+	var notRegistered interface{ Foo() }
+	if err := DecodePolymorphicJSON([]byte(`{"type":"created"}`), &notRegistered); err == nil {
+		testError(t, "err should not be nil")
+	}
+}
+
+func TestPolymorphicDefinitions(t *testing.T) {
+	registerTestEvent(t)
+
+	creater := StructDocCreater{}
+	docs, err := creater.GetStructDocMap(&testEventList{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	definitions := getDefinitionsFromStructDocMap(docs)
+	event, ok := definitions["testEvent"]
+	if !ok {
+		testError(t, "definition testEvent should be exist")
+		return
+	}
+	if event.Discriminator != "type" {
+		testError(t, "the discriminator should be type")
+	}
+	if len(event.OneOf) != 2 || event.OneOf[0].Ref != _definitions+"testEventCreated" || event.OneOf[1].Ref != _definitions+"testEventDeleted" {
+		testError(t, "x-oneOf should refer to testEventCreated and testEventDeleted")
+	}
+	if event.DiscriminatorMapping["deleted"] != _definitions+"testEventDeleted" {
+		testError(t, "x-discriminator-mapping should map deleted to testEventDeleted")
+	}
+	if !event.Properties["type"].Required {
+		testError(t, "the discriminator property should be required")
+	}
+	created, ok := definitions["testEventCreated"]
+	if !ok {
+		testError(t, "definition testEventCreated should be exist")
+		return
+	}
+	if len(created.AllOf) != 1 || created.AllOf[0].Ref != _definitions+"testEvent" || created.DiscriminatorValue != "created" {
+		testError(t, "testEventCreated should inherit testEvent")
+	}
+	if definitions["testEventList"].Properties["events"].Items.Ref != _definitions+"testEvent" {
+		testError(t, "events should refer to testEvent")
+	}
+
+	// a member used directly also brings its interface
+	docs, err = (&StructDocCreater{}).GetStructDocMap(&testEventDeleted{})
+	if err != nil {
+		testError(t, err)
+	} else if _, ok := getDefinitionsFromStructDocMap(docs)["testEvent"]; !ok {
+		testError(t, "definition testEvent should be exist")
+	}
+
+	// the interface as the model of a response
+	resp := Response{Description: "an event", Model: (*testEvent)(nil)}
+	swaggerResponse, err := resp.ToSwaggerResponse()
+	if err != nil {
+		testError(t, err)
+	} else if swaggerResponse.Schema.Ref != _definitions+"testEvent" {
+		testError(t, "the response should refer to testEvent")
+	}
+}
+
+func TestRequestBodyRuleWithPolymorphic(t *testing.T) {
+	registerTestEvent(t)
+
+	rule, err := newRequestBodyRule(&Request{Model: (*testEvent)(nil)})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if rule == nil {
+		testError(t, "rule should not be nil")
+		return
+	}
+	if err := rule.Check(ginContext{newTestBodyContext(Application_Json, `{"type":"created","title":"book"}`)}); err != nil {
+		testError(t, err)
+	}
+	invalidBodys := []string{
+		`{"title":"book"}`,
+		`{"type":"updated"}`,
+		`{"type":"created","id":"1"}`,
+	}
+	for _, body := range invalidBodys {
+		if err := rule.Check(ginContext{newTestBodyContext(Application_Json, body)}); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, body+" should be invalid")
+		}
+	}
+}
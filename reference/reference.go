@@ -0,0 +1,404 @@
+// Package reference render the swagger document to an offline API reference (Markdown or a self-contained HTML file).
+package reference
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/example"
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const swaggerDefinitions = "#/definitions/"
+
+// Reference the data of the templates
+// Fields:
+//   Title, Description, Version -- the info of the document
+//   BaseURL -- like "https://api.example.com/v1", empty if the document has no host and no basePath
+//   Tags -- the operations grouped by the tags, in the order of the declaration of the tags,
+//           the operations without tags are in the group "default"
+//   Models -- the definitions sorted by the names
+type Reference struct {
+	Title       string
+	Description string
+	Version     string
+	BaseURL     string
+	Tags        []*TagGroup
+	Models      []*Model
+}
+
+// TagGroup the operations of a tag
+type TagGroup struct {
+	Name        string
+	Description string
+	Operations  []*Operation
+}
+
+// Operation an operation of the reference
+// Fields:
+//   RequestExample -- the example payload of the body (indented JSON), empty if the operation has no body
+type Operation struct {
+	Method         string
+	Path           string
+	OperationID    string
+	Summary        string
+	Description    string
+	Deprecated     bool
+	Parameters     []*Parameter
+	RequestType    string
+	RequestExample string
+	Responses      []*Response
+}
+
+// Parameter a parameter of the operation, the body is not a parameter (see Operation.RequestType)
+type Parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Constraints string
+}
+
+// Response a response of the operation
+// Fields:
+//   Example -- the example payload (indented JSON), empty if the response has no body
+type Response struct {
+	Code        string
+	Description string
+	Type        string
+	Example     string
+}
+
+// Model a definition of the document
+type Model struct {
+	Name        string
+	Description string
+	Fields      []*Field
+	Example     string
+}
+
+// Field a property of the model, the nested properties are named like "author.name"
+type Field struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	Constraints string
+}
+
+// NewReference build the data of the templates from the swagger document
+func NewReference(doc *swagger.Swagger) *Reference {
+	b := &builder{doc: doc}
+	ref := &Reference{BaseURL: getBaseURL(doc)}
+	if doc.Info != nil {
+		ref.Title, ref.Description, ref.Version = doc.Info.Title, doc.Info.Description, doc.Info.Version
+	}
+	ref.Tags = b.getTagGroups()
+	for _, name := range getSortedKeys(doc.Definitions) {
+		ref.Models = append(ref.Models, b.getModel(name, doc.Definitions[name]))
+	}
+	return ref
+}
+
+func getBaseURL(doc *swagger.Swagger) string {
+	if doc.Host == "" {
+		return doc.BasePath
+	}
+	scheme := "http"
+	if len(doc.Schemes) > 0 {
+		scheme = doc.Schemes[0]
+	}
+	return scheme + "://" + doc.Host + doc.BasePath
+}
+
+type builder struct {
+	doc *swagger.Swagger
+}
+
+// getTagGroups the declared tags are first, then the other tags sorted by the names, and then "default"
+func (b *builder) getTagGroups() []*TagGroup {
+	groups := []*TagGroup{}
+	groupsByName := map[string]*TagGroup{}
+	getGroup := func(name string) *TagGroup {
+		if group, ok := groupsByName[name]; ok {
+			return group
+		}
+		group := &TagGroup{Name: name}
+		groupsByName[name] = group
+		groups = append(groups, group)
+		return group
+	}
+	for _, tag := range b.doc.Tags {
+		if tag != nil {
+			getGroup(tag.Name).Description = tag.Description
+		}
+	}
+	operations := b.getOperations()
+	undeclared := []string{}
+	for _, operation := range operations {
+		for _, tag := range operation.Tags {
+			if _, ok := groupsByName[tag]; !ok {
+				undeclared = append(undeclared, tag)
+			}
+		}
+	}
+	sort.Strings(undeclared)
+	for _, tag := range undeclared {
+		getGroup(tag)
+	}
+	for _, operation := range operations {
+		tags := operation.Tags
+		if len(tags) == 0 {
+			tags = []string{"default"}
+		}
+		for _, tag := range tags {
+			group := getGroup(tag)
+			group.Operations = append(group.Operations, b.getOperation(operation))
+		}
+	}
+	result := []*TagGroup{}
+	for _, group := range groups {
+		if len(group.Operations) > 0 {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+type operationInfo struct {
+	method string
+	path   string
+	*swagger.Operation
+}
+
+// getOperations the operations sorted by the paths and the methods
+func (b *builder) getOperations() []*operationInfo {
+	paths := []string{}
+	for path := range b.doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	operations := []*operationInfo{}
+	for _, path := range paths {
+		item := b.doc.Paths[path]
+		if item == nil {
+			continue
+		}
+		for _, o := range []*operationInfo{
+			{"GET", path, item.Get},
+			{"POST", path, item.Post},
+			{"PUT", path, item.Put},
+			{"PATCH", path, item.Patch},
+			{"DELETE", path, item.Delete},
+			{"HEAD", path, item.Head},
+			{"OPTIONS", path, item.Options},
+		} {
+			if o.Operation != nil {
+				operations = append(operations, o)
+			}
+		}
+	}
+	return operations
+}
+
+func (b *builder) getOperation(o *operationInfo) *Operation {
+	operation := &Operation{
+		Method:      o.method,
+		Path:        o.path,
+		OperationID: o.OperationID,
+		Summary:     o.Summary,
+		Description: o.Description,
+		Deprecated:  o.Deprecated,
+	}
+	for _, parameter := range o.Parameters {
+		if parameter != nil && strings.HasPrefix(parameter.Ref, "#/parameters/") {
+			parameter = b.doc.Parameters[strings.TrimPrefix(parameter.Ref, "#/parameters/")]
+		}
+		if parameter == nil {
+			continue
+		}
+		if parameter.In == "body" {
+			operation.RequestType = b.getSchemaType(parameter.Schema)
+			operation.RequestExample = toJSON(example.RequestBody(b.doc, parameter))
+			continue
+		}
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        parameter.Name,
+			In:          parameter.In,
+			Type:        getParameterType(parameter),
+			Required:    parameter.Required,
+			Description: parameter.Description,
+			Constraints: getConstraints(parameter.Enum, parameter.Default, parameter.Minimum, parameter.Maximum, parameter.MinLength, parameter.MaxLength),
+		})
+	}
+	for _, code := range getSortedKeys(o.Responses) {
+		response := o.Responses[code]
+		if response == nil {
+			continue
+		}
+		operation.Responses = append(operation.Responses, &Response{
+			Code:        code,
+			Description: response.Description,
+			Type:        b.getSchemaType(response.Schema),
+			Example:     toJSON(example.ResponseBody(b.doc, response)),
+		})
+	}
+	return operation
+}
+
+func getParameterType(parameter *swagger.Parameter) string {
+	if parameter.Type == "array" && parameter.Items != nil {
+		return "[]" + getTypeName(parameter.Items.Type, parameter.Items.Format)
+	}
+	return getTypeName(parameter.Type, parameter.Format)
+}
+
+func getTypeName(typ, format string) string {
+	if format == "" {
+		return typ
+	}
+	return typ + "(" + format + ")"
+}
+
+// getSchemaType like "Book", "[]Book" or "string"
+func (b *builder) getSchemaType(schema *swagger.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Ref != "" {
+		return strings.TrimPrefix(schema.Ref, swaggerDefinitions)
+	}
+	if schema.Type == "array" {
+		return "[]" + b.getSchemaType(schema.Items)
+	}
+	return getTypeName(schema.Type, schema.Format)
+}
+
+func (b *builder) getPropertieType(propertie *swagger.Propertie) string {
+	if propertie == nil {
+		return ""
+	}
+	if propertie.Ref != "" {
+		return strings.TrimPrefix(propertie.Ref, swaggerDefinitions)
+	}
+	if len(propertie.AllOf) == 1 {
+		return b.getPropertieType(propertie.AllOf[0])
+	}
+	if propertie.Type == "array" {
+		return "[]" + b.getPropertieType(propertie.Items)
+	}
+	if propertie.Type == "object" && propertie.AdditionalProperties != nil {
+		return "map[string]" + b.getPropertieType(propertie.AdditionalProperties)
+	}
+	return getTypeName(propertie.Type, propertie.Format)
+}
+
+func (b *builder) getModel(name string, definition *swagger.Schema) *Model {
+	model := &Model{Name: name}
+	if definition == nil {
+		return model
+	}
+	model.Description = definition.Description
+	b.addFields(model, "", definition.Properties)
+	model.Example = toJSON(example.Schema(b.doc, &swagger.Schema{Ref: swaggerDefinitions + name}, example.InResponse))
+	return model
+}
+
+func (b *builder) addFields(model *Model, prefix string, properties map[string]*swagger.Propertie) {
+	for _, name := range getSortedKeys(properties) {
+		propertie := properties[name]
+		if propertie == nil {
+			continue
+		}
+		constraints := getConstraints(propertie.Enum, propertie.Default, propertie.Minimum, propertie.Maximum, propertie.MinLength, propertie.MaxLength)
+		for _, flag := range []struct {
+			name string
+			set  bool
+		}{{"readOnly", propertie.ReadOnly}, {"writeOnly", propertie.WriteOnly}, {"nullable", propertie.Nullable}} {
+			if flag.set {
+				constraints = joinConstraints(constraints, flag.name)
+			}
+		}
+		model.Fields = append(model.Fields, &Field{
+			Name:        prefix + name,
+			Type:        b.getPropertieType(propertie),
+			Required:    propertie.Required,
+			Description: propertie.Description,
+			Constraints: constraints,
+		})
+		b.addFields(model, prefix+name+".", propertie.Properties)
+		if propertie.Items != nil {
+			b.addFields(model, prefix+name+"[].", propertie.Items.Properties)
+		}
+	}
+}
+
+// getConstraints like "enum: a, b; min: 1; maxLength: 20"
+func getConstraints(enum []interface{}, defaultValue interface{}, minimum, maximum *float64, minLength, maxLength *int64) string {
+	constraints := ""
+	if len(enum) > 0 {
+		values := []string{}
+		for _, value := range enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		constraints = joinConstraints(constraints, "enum: "+strings.Join(values, ", "))
+	}
+	if defaultValue != nil {
+		constraints = joinConstraints(constraints, fmt.Sprint("default: ", defaultValue))
+	}
+	if minimum != nil {
+		constraints = joinConstraints(constraints, fmt.Sprint("min: ", *minimum))
+	}
+	if maximum != nil {
+		constraints = joinConstraints(constraints, fmt.Sprint("max: ", *maximum))
+	}
+	if minLength != nil {
+		constraints = joinConstraints(constraints, fmt.Sprint("minLength: ", *minLength))
+	}
+	if maxLength != nil {
+		constraints = joinConstraints(constraints, fmt.Sprint("maxLength: ", *maxLength))
+	}
+	return constraints
+}
+
+func joinConstraints(constraints, constraint string) string {
+	if constraints == "" {
+		return constraint
+	}
+	return constraints + "; " + constraint
+}
+
+func toJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// getSortedKeys the sorted keys of a map[string]T
+func getSortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string]*swagger.Schema:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Propertie:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*swagger.Response:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
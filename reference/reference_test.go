@@ -0,0 +1,134 @@
+package reference
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+const testDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "book store APIS", "description": "the books", "version": "v1"},
+  "host": "api.example.com",
+  "basePath": "/v1",
+  "schemes": ["https"],
+  "tags": [{"name": "books", "description": "the books"}],
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "tags": ["books"],
+        "summary": "Get a book",
+        "operationId": "getBook",
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "fields", "in": "query", "type": "string", "enum": ["all", "brief"], "description": "the fields | brief"}
+        ],
+        "responses": {
+          "200": {"description": "ok", "schema": {"$ref": "#/definitions/Book"}},
+          "404": {"description": "not found"}
+        }
+      },
+      "put": {
+        "tags": ["books"],
+        "deprecated": true,
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "book", "in": "body", "schema": {"$ref": "#/definitions/Book"}, "x-examples": {"application/json": {"title": "Demo book"}}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    },
+    "/health": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  },
+  "definitions": {
+    "Book": {
+      "type": "object",
+      "description": "a book",
+      "properties": {
+        "id": {"type": "string", "readOnly": true},
+        "title": {"type": "string", "required": true, "example": "Go", "maxLength": 100},
+        "password": {"type": "string", "x-writeOnly": true},
+        "price": {"type": "number", "minimum": 0},
+        "related": {"type": "array", "items": {"$ref": "#/definitions/Book"}}
+      }
+    }
+  }
+}`
+
+func testParseDocument(t *testing.T) *swagger.Swagger {
+	doc := &swagger.Swagger{}
+	if err := json.Unmarshal([]byte(testDocument), doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestNewReference(t *testing.T) {
+	ref := NewReference(testParseDocument(t))
+	if ref.Title != "book store APIS" || ref.BaseURL != "https://api.example.com/v1" {
+		t.Error("the title and the base url should be set", ref.Title, ref.BaseURL)
+	}
+	if len(ref.Tags) != 2 || ref.Tags[0].Name != "books" || len(ref.Tags[0].Operations) != 2 || ref.Tags[1].Name != "default" {
+		t.Fatal("the operations should be grouped by the tags", ref.Tags)
+	}
+	get := ref.Tags[0].Operations[0]
+	if get.Method != "GET" || len(get.Parameters) != 2 || get.Parameters[1].Constraints != "enum: all, brief" {
+		t.Error("the parameters should have the constraints", get.Parameters)
+	}
+	if len(get.Responses) != 2 || get.Responses[0].Type != "Book" || !strings.Contains(get.Responses[0].Example, `"title": "Go"`) {
+		t.Error("the response should have the type and the example", get.Responses[0])
+	}
+	if strings.Contains(get.Responses[0].Example, "password") {
+		t.Error("the writeOnly properties should not be in the response example")
+	}
+	put := ref.Tags[0].Operations[1]
+	if !put.Deprecated || put.RequestType != "Book" || put.RequestExample != "{\n  \"title\": \"Demo book\"\n}" {
+		t.Error("the request body should have the example of the document", put.RequestType, put.RequestExample)
+	}
+	book := ref.Models[0]
+	if book.Name != "Book" || len(book.Fields) != 5 || book.Fields[0].Name != "id" || book.Fields[0].Constraints != "readOnly" {
+		t.Fatal("the fields should be sorted by the names", book.Fields)
+	}
+	if title := book.Fields[4]; title.Name != "title" || !title.Required || title.Constraints != "maxLength: 100" {
+		t.Error("the field title should be required with the constraints", title)
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	doc := testParseDocument(t)
+	renderer := NewRenderer()
+	markdown := &bytes.Buffer{}
+	if err := renderer.Markdown(markdown, doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"# book store APIS\n",
+		"* [GET /books/{id}](#get-booksid) Get a book\n",
+		"### PUT /books/{id}\n\n**Deprecated**\n",
+		"| fields | query | string | no | the fields \\| brief | enum: all, brief |\n",
+		"## Models\n",
+	} {
+		if !strings.Contains(markdown.String(), s) {
+			t.Error("the Markdown should contain " + s)
+		}
+	}
+	html := &bytes.Buffer{}
+	if err := renderer.HTML(html, doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), `<h3 id="get-booksid"><span class="method GET">GET</span> /books/{id}</h3>`) ||
+		!strings.Contains(html.String(), "&#34;title&#34;: &#34;Demo book&#34;") {
+		t.Error("the HTML should contain the operations and the escaped examples")
+	}
+
+	renderer.MarkdownTemplate, _ = ParseMarkdownTemplate("{{range .Tags}}{{.Name}} {{end}}")
+	markdown.Reset()
+	if err := renderer.Markdown(markdown, doc); err != nil || markdown.String() != "books default " {
+		t.Error("the template should be overridden", markdown.String(), err)
+	}
+}
@@ -0,0 +1,248 @@
+package reference
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Renderer render the swagger document with the templates, the data of the templates is *Reference.
+// The templates can be overridden, see ParseMarkdownTemplate and ParseHTMLTemplate.
+type Renderer struct {
+	MarkdownTemplate *texttemplate.Template
+	HTMLTemplate     *htmltemplate.Template
+}
+
+// NewRenderer a renderer with the default templates
+func NewRenderer() *Renderer {
+	return &Renderer{
+		MarkdownTemplate: texttemplate.Must(ParseMarkdownTemplate(DefaultMarkdownTemplate)),
+		HTMLTemplate:     htmltemplate.Must(ParseHTMLTemplate(DefaultHTMLTemplate)),
+	}
+}
+
+// Markdown render the Markdown reference
+func (r *Renderer) Markdown(w io.Writer, doc *swagger.Swagger) error {
+	return r.MarkdownTemplate.Execute(w, NewReference(doc))
+}
+
+// HTML render the self-contained HTML reference
+func (r *Renderer) HTML(w io.Writer, doc *swagger.Swagger) error {
+	return r.HTMLTemplate.Execute(w, NewReference(doc))
+}
+
+// ParseMarkdownTemplate parse the Markdown template with the functions:
+//   anchor -- the anchor of a title, like "GET /books/{id}" -> "get-booksid"
+//   cell -- escape the text in a table cell
+func ParseMarkdownTemplate(text string) (*texttemplate.Template, error) {
+	return texttemplate.New("markdown").Funcs(texttemplate.FuncMap{
+		"anchor": anchor,
+		"cell":   cell,
+	}).Parse(text)
+}
+
+// ParseHTMLTemplate parse the HTML template with the function anchor (see ParseMarkdownTemplate)
+func ParseHTMLTemplate(text string) (*htmltemplate.Template, error) {
+	return htmltemplate.New("html").Funcs(htmltemplate.FuncMap{
+		"anchor": anchor,
+	}).Parse(text)
+}
+
+var anchorInvalidChars = regexp.MustCompile(`[^a-z0-9 _-]`)
+
+// anchor the anchor of a title like GitHub, lower case, the spaces are replaced by "-" and the punctuation is removed
+func anchor(title string) string {
+	return strings.Replace(anchorInvalidChars.ReplaceAllString(strings.ToLower(title), ""), " ", "-", -1)
+}
+
+func cell(text string) string {
+	text = strings.Replace(text, "|", `\|`, -1)
+	return strings.Replace(strings.TrimSpace(text), "\n", "<br>", -1)
+}
+
+// DefaultMarkdownTemplate the default Markdown template
+const DefaultMarkdownTemplate = `# {{.Title}}
+{{with .Description}}
+{{.}}
+{{end}}
+{{with .Version}}Version: {{.}}
+{{end}}{{with .BaseURL}}Base URL: ` + "`{{.}}`" + `
+{{end}}
+## Contents
+{{range .Tags}}
+* [{{.Name}}](#{{anchor .Name}})
+{{- range .Operations}}
+  * [{{.Method}} {{.Path}}](#{{anchor (print .Method " " .Path)}}){{with .Summary}} {{.}}{{end}}
+{{- end}}
+{{- end}}
+{{- if .Models}}
+* [Models](#models)
+{{- end}}
+{{range .Tags}}
+## {{.Name}}
+{{with .Description}}
+{{.}}
+{{end}}
+{{- range .Operations}}
+### {{.Method}} {{.Path}}
+{{if .Deprecated}}
+**Deprecated**
+{{end}}{{with .Summary}}
+{{.}}
+{{end}}{{with .Description}}
+{{.}}
+{{end}}{{with .OperationID}}
+OperationId: ` + "`{{.}}`" + `
+{{end}}{{if .Parameters}}
+#### Parameters
+
+| Name | In | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- | --- |
+{{range .Parameters}}| {{.Name}} | {{.In}} | {{cell .Type}} | {{if .Required}}yes{{else}}no{{end}} | {{cell .Description}} | {{cell .Constraints}} |
+{{end}}{{end}}{{if .RequestType}}
+#### Request body
+
+Type: {{.RequestType}}
+{{with .RequestExample}}
+` + "```json" + `
+{{.}}
+` + "```" + `
+{{end}}{{end}}{{if .Responses}}
+#### Responses
+
+| Code | Description | Type |
+| --- | --- | --- |
+{{range .Responses}}| {{.Code}} | {{cell .Description}} | {{cell .Type}} |
+{{end}}{{range .Responses}}{{if .Example}}
+Example of the response {{.Code}}:
+
+` + "```json" + `
+{{.Example}}
+` + "```" + `
+{{end}}{{end}}{{end}}
+{{- end}}
+{{- end}}
+{{- if .Models}}
+## Models
+{{range .Models}}
+### {{.Name}}
+{{with .Description}}
+{{.}}
+{{end}}{{if .Fields}}
+| Field | Type | Required | Description | Constraints |
+| --- | --- | --- | --- | --- |
+{{range .Fields}}| {{.Name}} | {{cell .Type}} | {{if .Required}}yes{{else}}no{{end}} | {{cell .Description}} | {{cell .Constraints}} |
+{{end}}{{end}}{{with .Example}}
+` + "```json" + `
+{{.}}
+` + "```" + `
+{{end}}{{end}}{{end}}`
+
+// DefaultHTMLTemplate the default HTML template, the styles are inline, so the file is self-contained
+const DefaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #24292e; }
+nav { position: fixed; top: 0; bottom: 0; left: 0; width: 280px; overflow-y: auto; padding: 16px; background: #f6f8fa; border-right: 1px solid #e1e4e8; box-sizing: border-box; font-size: 14px; }
+nav ul { list-style: none; padding-left: 12px; }
+nav a { color: #0366d6; text-decoration: none; }
+main { margin-left: 280px; padding: 16px 32px; max-width: 960px; }
+table { border-collapse: collapse; width: 100%; margin: 8px 0; }
+th, td { border: 1px solid #dfe2e5; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+pre { background: #f6f8fa; padding: 12px; overflow-x: auto; }
+.method { display: inline-block; min-width: 64px; padding: 2px 6px; border-radius: 3px; color: #fff; background: #6a737d; text-align: center; font-size: 14px; }
+.GET { background: #0366d6; } .POST { background: #28a745; } .PUT, .PATCH { background: #e36209; } .DELETE { background: #d73a49; }
+.deprecated { color: #d73a49; font-weight: bold; }
+</style>
+</head>
+<body>
+<nav>
+<strong>{{.Title}}</strong>
+<ul>
+{{- range .Tags}}
+<li><a href="#{{anchor .Name}}">{{.Name}}</a>
+<ul>
+{{- range .Operations}}
+<li><a href="#{{anchor (print .Method " " .Path)}}">{{.Method}} {{.Path}}</a></li>
+{{- end}}
+</ul>
+</li>
+{{- end}}
+{{- if .Models}}
+<li><a href="#models">Models</a></li>
+{{- end}}
+</ul>
+</nav>
+<main>
+<h1>{{.Title}}</h1>
+{{with .Description}}<p>{{.}}</p>{{end}}
+{{with .Version}}<p>Version: {{.}}</p>{{end}}
+{{with .BaseURL}}<p>Base URL: <code>{{.}}</code></p>{{end}}
+{{- range .Tags}}
+<h2 id="{{anchor .Name}}">{{.Name}}</h2>
+{{with .Description}}<p>{{.}}</p>{{end}}
+{{- range .Operations}}
+<h3 id="{{anchor (print .Method " " .Path)}}"><span class="method {{.Method}}">{{.Method}}</span> {{.Path}}</h3>
+{{if .Deprecated}}<p class="deprecated">Deprecated</p>{{end}}
+{{with .Summary}}<p>{{.}}</p>{{end}}
+{{with .Description}}<p>{{.}}</p>{{end}}
+{{with .OperationID}}<p>OperationId: <code>{{.}}</code></p>{{end}}
+{{- if .Parameters}}
+<h4>Parameters</h4>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th><th>Constraints</th></tr>
+{{- range .Parameters}}
+<tr><td>{{.Name}}</td><td>{{.In}}</td><td>{{.Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td>{{.Description}}</td><td>{{.Constraints}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .RequestType}}
+<h4>Request body</h4>
+<p>Type: {{.RequestType}}</p>
+{{with .RequestExample}}<pre><code>{{.}}</code></pre>{{end}}
+{{- end}}
+{{- if .Responses}}
+<h4>Responses</h4>
+<table>
+<tr><th>Code</th><th>Description</th><th>Type</th></tr>
+{{- range .Responses}}
+<tr><td>{{.Code}}</td><td>{{.Description}}</td><td>{{.Type}}</td></tr>
+{{- end}}
+</table>
+{{- range .Responses}}
+{{- if .Example}}
+<p>Example of the response {{.Code}}:</p>
+<pre><code>{{.Example}}</code></pre>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Models}}
+<h2 id="models">Models</h2>
+{{- range .Models}}
+<h3 id="model-{{anchor .Name}}">{{.Name}}</h3>
+{{with .Description}}<p>{{.}}</p>{{end}}
+{{- if .Fields}}
+<table>
+<tr><th>Field</th><th>Type</th><th>Required</th><th>Description</th><th>Constraints</th></tr>
+{{- range .Fields}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td>{{.Description}}</td><td>{{.Constraints}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{with .Example}}<pre><code>{{.}}</code></pre>{{end}}
+{{- end}}
+{{- end}}
+</main>
+</body>
+</html>
+`
@@ -1,13 +1,13 @@
 package ehttp
 
 import (
+	"encoding/json"
 	"errors"
 	"log"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 )
 
 const _definitions = "#/definitions/"
@@ -114,11 +114,30 @@ func isValueTypeBool(valueType string) bool {
 //    StructName -- the name of struct. (like: StructDoc)
 //    GoPkgPath -- the package path of the struct. (like: github.com/enjoy-web/ehttp.StructDoc)
 //    StructFields -- StructFields in the struct.
+//    Description -- the Go doc comment of the struct (see DocComments)
+//    Discriminator -- if the document is a registered interface (see RegisterPolymorphic), the json name of the discriminator property
+//    OneOf -- if the document is a registered interface, the UUIDs of the struct types (oneOf)
+//    AnyOf -- if the document is a registered interface, the UUIDs of the struct types (anyOf)
+//    DiscriminatorMapping -- if the document is a registered interface, the value of the discriminator -> UUID of the struct type
+//    AllOf -- the UUIDs of the registered interfaces (with a discriminator) that the struct belongs to
+//    XML -- the XML representation of the struct, from the XMLName field (like: XMLName xml.Name `xml:"http://example.com/book book"`)
 type StructDoc struct {
-	UUID         string
-	StructName   string
-	GoPkgPath    string
-	StructFields []*StructField
+	UUID                 string
+	StructName           string
+	GoPkgPath            string
+	StructFields         []*StructField
+	Description          string
+	Discriminator        string
+	OneOf                []string
+	AnyOf                []string
+	DiscriminatorMapping map[string]string
+	AllOf                []string
+	XML                  *XMLInfo
+}
+
+// IsPolymorphic check if the document is a registered interface
+func (doc StructDoc) IsPolymorphic() bool {
+	return len(doc.OneOf) > 0 || len(doc.AnyOf) > 0
 }
 
 // StructField A structure that can display all the information in the field.
@@ -129,8 +148,15 @@ type StructDoc struct {
 //   ValueType -- type of the value
 //   Name -- the filed name. If not be set the tag(like: FieldName string), the filed name is FieldName,
 //           else(like: FieldName string `json:"fieldName" xml:"fieldName"`) the filed name is the value(fieldName) from the tag.
-//           note! the json tag must be equal to the xml tag
+//           If the json name is not equal to the xml name, the filed name is the json name, and the xml name is XML.Name
 //   Description -- description for the struct field, the description from the tag in the filed(like: IsArray bool `desc:"is array"`, the Description = "is array"")
+//   ReadOnly -- the field is only sent in responses, and must not be sent in requests (like: ID string `readonly:"true"`)
+//   WriteOnly -- the field is only sent in requests, and must not be sent in responses (like: Password string `writeonly:"true"`)
+//   Nullable -- the value of the field may be null. A pointer field is nullable, unless the tag nullable is false (like: Note *string `nullable:"false"`)
+//   Omitempty -- the field is not output if it is empty (like: Note string `json:"note,omitempty"`)
+//   Example -- example of the value, from the tag example. (like: Title string `example:"Demo book"`, Tags []string `example:"[\"a\",\"b\"]"`)
+//              The example of an array is a JSON array.
+//   XML -- the XML representation of the field, nil if it is same as the property (like: ID string `json:"id" xml:"ID,attr"`, see XMLInfo)
 type StructField struct {
 	IsArray       bool
 	IsStruct      bool
@@ -145,37 +171,47 @@ type StructField struct {
 	MaxLen        *int64
 	Required      bool
 	Default       interface{}
+	ReadOnly      bool
+	WriteOnly     bool
+	Nullable      bool
+	Omitempty     bool
+	Example       interface{}
+	XML           *XMLInfo
 }
 
-var docChecker = &structDocChecker{}
+// Annotations appended to the description of an omitempty struct field.
+const (
+	OmitemptyAnnotationCN = "(为空则不输出)"
+	OmitemptyAnnotationEN = "(omitted if empty)"
+)
 
-type structDocChecker struct {
-	lock sync.Mutex
-	m    map[string]*StructDoc
-}
+// OmitemptyAnnotation is appended to the description of the struct field which has the json tag option omitempty.
+// Set it to OmitemptyAnnotationEN, another localized text, or "" to disable the annotation.
+var OmitemptyAnnotation = OmitemptyAnnotationCN
 
-func (s structDocChecker) checkConfliction(docs map[string]*StructDoc) error {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-	if s.m == nil {
-		s.m = map[string]*StructDoc{}
-	}
+// checkStructNameConfliction check if the different structs of a model have the same name (like: model.Book and store.Book).
+// Only the structs of the model are checked, the models of the engines are independent.
+func checkStructNameConfliction(docs map[string]*StructDoc) error {
+	m := map[string]*StructDoc{}
 	for _, doc := range docs {
-		v, ok := s.m[doc.StructName]
+		v, ok := m[doc.StructName]
 		if ok {
 			if v.UUID != doc.UUID {
 				return errors.New("Conflict between " + v.UUID + " and " + doc.UUID)
 			}
 		} else {
-			s.m[doc.StructName] = doc
+			m[doc.StructName] = doc
 		}
 	}
 	return nil
 }
 
 // StructDocCreater is a creator specifically responsible for getting documents from objects
+// Fields:
+//   commentsFromSource -- get the doc comments which are not registered from the Go source of the packages (see Config.DocCommentsFromSource)
 type StructDocCreater struct {
-	structDocsMap map[string]*StructDoc
+	structDocsMap      map[string]*StructDoc
+	commentsFromSource bool
 }
 
 // GetStructDocMap return struct documents map from the object
@@ -197,7 +233,7 @@ func (sc *StructDocCreater) GetStructDocMap(obj interface{}) (map[string]*Struct
 	if err != nil {
 		return nil, err
 	}
-	err = docChecker.checkConfliction(sc.structDocsMap)
+	err = checkStructNameConfliction(sc.structDocsMap)
 	if err != nil {
 		return nil, err
 	}
@@ -205,31 +241,53 @@ func (sc *StructDocCreater) GetStructDocMap(obj interface{}) (map[string]*Struct
 }
 
 // scanStructInStructType is a recursive function. Scan the structure, and collect the structure information.
+// If the structType is a registered interface, scan the struct types of it.
 func (sc *StructDocCreater) scanStructInStructType(structType reflect.Type) error {
+	if isPolymorphicType(structType) {
+		for _, t := range getPolymorphicStructTypes(structType) {
+			if err := sc.scanReflectType(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, polymorphic := range polymorphicTypes.getByStructType(structType) {
+		if err := sc.scanReflectType(polymorphic.ifaceType); err != nil {
+			return err
+		}
+	}
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
+		if isXMLNameField(field) {
+			continue
+		}
 		t := getReflectTypeFromStructField(field)
-		if t.Kind() == reflect.Struct {
-			structUUID, err := getStructUUID(t)
-			if err != nil {
+		if t.Kind() == reflect.Struct || isPolymorphicType(t) {
+			if err := sc.scanReflectType(t); err != nil {
 				return err
 			}
-			if !sc.isStructUUIDExist(structUUID) {
-				structDoc, err := sc.getStructDocFromReflectStruct(t)
-				if err != nil {
-					return err
-				}
-				sc.addStructDoc(structDoc)
-				err = sc.scanStructInStructType(t)
-				if err != nil {
-					return err
-				}
-			}
 		}
 	}
 	return nil
 }
 
+// scanReflectType add the StructDoc of the type (a struct or a registered interface) if it is not exist, and scan it.
+func (sc *StructDocCreater) scanReflectType(t reflect.Type) error {
+	structUUID, err := getStructUUID(t)
+	if err != nil {
+		return err
+	}
+	if sc.isStructUUIDExist(structUUID) {
+		return nil
+	}
+	structDoc, err := sc.getStructDocFromReflectStruct(t)
+	if err != nil {
+		return err
+	}
+	sc.addStructDoc(structDoc)
+	return sc.scanStructInStructType(t)
+}
+
 // check if the StructUUID is already in the structDocsMap
 func (sc *StructDocCreater) isStructUUIDExist(structUUID string) bool {
 	_, ok := sc.structDocsMap[structUUID]
@@ -243,6 +301,9 @@ func (sc *StructDocCreater) addStructDoc(structDoc *StructDoc) {
 
 // GetStructDoc get StructDoc by a struct object
 func (sc *StructDocCreater) GetStructDoc(obj interface{}) (*StructDoc, error) {
+	if structType, err := getStructReflectType(obj); err == nil && isPolymorphicType(structType) {
+		return getStructDocFromPolymorphicType(structType, sc.commentsFromSource)
+	}
 	err := checkStructFieldsJSONNameAndXMLNameFromObject(obj)
 	if err != nil {
 		return nil, err
@@ -256,6 +317,9 @@ func (sc *StructDocCreater) GetStructDoc(obj interface{}) (*StructDoc, error) {
 
 //  The argument to getStructName must be equal to reflect.Struct.
 func (sc *StructDocCreater) getStructDocFromReflectStruct(structType reflect.Type) (*StructDoc, error) {
+	if isPolymorphicType(structType) {
+		return getStructDocFromPolymorphicType(structType, sc.commentsFromSource)
+	}
 	doc := &StructDoc{}
 	structUUID, err := getStructUUID(structType)
 	if err != nil {
@@ -274,6 +338,13 @@ func (sc *StructDocCreater) getStructDocFromReflectStruct(structType reflect.Typ
 		return nil, err
 	}
 	doc.GoPkgPath = goPkgPath
+	doc.Description = docComments.get(goPkgPath, getDocCommentKey(structType), sc.commentsFromSource)
+
+	xmlInfo, err := getStructXML(structType)
+	if err != nil {
+		return nil, err
+	}
+	doc.XML = xmlInfo
 
 	structFieldNameType := structFiledNameTypeJSONAndXML
 	err = checkStructFieldsJSONNameAndXMLName(structType, &structFieldNameType, map[string]reflect.Type{})
@@ -281,15 +352,23 @@ func (sc *StructDocCreater) getStructDocFromReflectStruct(structType reflect.Typ
 		return nil, err
 	}
 
-	fields, err := getStructFields(structType)
+	fields, err := getStructFields(structType, sc.commentsFromSource)
 	if err != nil {
 		return nil, err
 	}
 	doc.StructFields = fields
+
+	for _, polymorphic := range polymorphicTypes.getByStructType(structType) {
+		polymorphicUUID, err := getStructUUID(polymorphic.ifaceType)
+		if err != nil {
+			return nil, err
+		}
+		doc.AllOf = append(doc.AllOf, polymorphicUUID)
+	}
 	return doc, nil
 }
 
-// The argument to getStructReflectType must be a struct object.
+// The argument to getStructReflectType must be a struct object, or a nil pointer to a registered interface (like: (*Event)(nil)).
 func getStructReflectType(obj interface{}) (reflect.Type, error) {
 	if obj == nil {
 		return nil, &invalidStructError{nil}
@@ -298,23 +377,179 @@ func getStructReflectType(obj interface{}) (reflect.Type, error) {
 	if t.Kind() == reflect.Ptr {
 		t = reflect.TypeOf(obj).Elem()
 	}
-	if t.Kind() != reflect.Struct {
+	if t.Kind() != reflect.Struct && !isPolymorphicType(t) {
 		return nil, &invalidStructError{t}
 	}
 	return t, nil
 }
 
 // The argument to getStructName must be equal to reflect.Struct.
+// An instantiated generic type (like: Page[github.com/x/model.Book]) gets a readable name (like: PageOfBook),
+// it is derived only from the type arguments, so it does not depend on the other types of the process.
+// The instantiations with the same readable name and different type arguments (like: Page[model.Book] and Page[store.Book])
+// have different UUIDs (see getStructUUID), so they conflict in a model (see checkStructNameConfliction).
 func getStructName(t reflect.Type) (string, error) {
-	if t.Kind() != reflect.Struct {
+	if t.Kind() != reflect.Struct && !isPolymorphicType(t) {
 		return "", &invalidStructError{t}
 	}
+	if isGenericTypeName(t.Name()) {
+		return getGenericStructName(t.Name())
+	}
 	return t.Name(), nil
 }
 
+// isGenericTypeName check if the type name is an instantiated generic type name (like: Page[int])
+func isGenericTypeName(name string) bool {
+	return strings.HasSuffix(name, "]") && strings.Contains(name, "[")
+}
+
+// getGenericStructName get a readable definition name from the name of an instantiated generic type
+// examples:
+//    Page[github.com/x/model.Book] -> PageOfBook
+//    Page[*github.com/x/model.Book] -> PageOfBook
+//    Page[[]string] -> PageOfListOfString
+//    Pair[string,int64] -> PairOfStringAndInt64
+//    Page[github.com/x/model.Page[github.com/x/model.Book]] -> PageOfPageOfBook
+//    Dict[map[string]github.com/x/model.Book] -> DictOfMapOfStringToBook
+func getGenericStructName(name string) (string, error) {
+	start := strings.Index(name, "[")
+	if start <= 0 || !strings.HasSuffix(name, "]") {
+		return "", errors.New("invalid generic type name " + name)
+	}
+	args, err := splitGenericTypeArgs(name[start+1 : len(name)-1])
+	if err != nil {
+		return "", errors.New("invalid generic type name " + name + ", " + err.Error())
+	}
+	argNames := []string{}
+	for _, arg := range args {
+		argName, err := getGenericTypeArgName(arg)
+		if err != nil {
+			return "", errors.New("invalid generic type name " + name + ", " + err.Error())
+		}
+		argNames = append(argNames, argName)
+	}
+	return getTypeBaseName(name[:start]) + "Of" + strings.Join(argNames, "And"), nil
+}
+
+// getGenericTypeArgName get a readable name of a type argument, the pointers and the packages are omitted
+func getGenericTypeArgName(arg string) (string, error) {
+	arg = strings.TrimSpace(arg)
+	switch {
+	case arg == "":
+		return "", errors.New("empty type argument")
+	case strings.HasPrefix(arg, "*"):
+		return getGenericTypeArgName(arg[1:])
+	case strings.HasPrefix(arg, "[]"):
+		name, err := getGenericTypeArgName(arg[2:])
+		if err != nil {
+			return "", err
+		}
+		return "ListOf" + name, nil
+	case strings.HasPrefix(arg, "["):
+		end := strings.Index(arg, "]")
+		if end < 0 {
+			return "", errors.New("invalid type argument " + arg)
+		}
+		name, err := getGenericTypeArgName(arg[end+1:])
+		if err != nil {
+			return "", err
+		}
+		return "ListOf" + name, nil
+	case strings.HasPrefix(arg, "map["):
+		end := findGenericClosingBracket(arg, len("map["))
+		if end < 0 {
+			return "", errors.New("invalid type argument " + arg)
+		}
+		key, err := getGenericTypeArgName(arg[len("map["):end])
+		if err != nil {
+			return "", err
+		}
+		value, err := getGenericTypeArgName(arg[end+1:])
+		if err != nil {
+			return "", err
+		}
+		return "MapOf" + key + "To" + value, nil
+	case arg == "interface {}" || arg == "any":
+		return "Any", nil
+	case strings.HasPrefix(arg, "interface {") || strings.HasPrefix(arg, "struct {") || strings.HasPrefix(arg, "func("):
+		return "", errors.New("type argument " + arg + " is not supported")
+	}
+	if start := strings.Index(arg, "["); start > 0 {
+		name, err := getGenericStructName(getTypeBaseName(arg[:start]) + arg[start:])
+		if err != nil {
+			return "", err
+		}
+		return upperFirstLetter(name), nil
+	}
+	return upperFirstLetter(getTypeBaseName(arg)), nil
+}
+
+// getTypeBaseName remove the package path of the type name (like: github.com/x/model.Book -> Book)
+func getTypeBaseName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// upperFirstLetter make the first letter uppercase (like: int64 -> Int64)
+func upperFirstLetter(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// splitGenericTypeArgs split the type arguments by the top level ','
+func splitGenericTypeArgs(args string) ([]string, error) {
+	list := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced brackets in " + args)
+			}
+		case ',':
+			if depth == 0 {
+				list = append(list, args[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced brackets in " + args)
+	}
+	return append(list, args[start:]), nil
+}
+
+// findGenericClosingBracket return the index of the ']' that closes the '[' before the index start
+func findGenericClosingBracket(str string, start int) int {
+	depth := 1
+	for i := start; i < len(str); i++ {
+		switch str[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 // The argument to getGoPkgPath must be equal to reflect.Struct.
 func getGoPkgPath(t reflect.Type) (string, error) {
-	if t.Kind() != reflect.Struct {
+	if t.Kind() != reflect.Struct && !isPolymorphicType(t) {
 		return "", &invalidStructError{t}
 	}
 	return t.PkgPath(), nil
@@ -325,11 +560,17 @@ func getStructUUIDFromPkgPathAndName(goPkgPath, structName string) string {
 }
 
 // The argument to getStructUUID must be equal to reflect.Struct.
+// The UUID of an instantiated generic type has the full names of the type arguments without the pointers
+// (like: Page[*github.com/x/model.Book] -> github.com/x/model.Page[github.com/x/model.Book]),
+// the pointers have the same document.
 func getStructUUID(structType reflect.Type) (string, error) {
 	structName, err := getStructName(structType)
 	if err != nil {
 		return "", err
 	}
+	if isGenericTypeName(structType.Name()) {
+		structName = strings.Replace(structType.Name(), "*", "", -1)
+	}
 	goPkgPath, err := getGoPkgPath(structType)
 	if err != nil {
 		return "", err
@@ -338,10 +579,14 @@ func getStructUUID(structType reflect.Type) (string, error) {
 }
 
 // The argument to getStructFields must be equal to reflect.Struct.
-func getStructFields(structType reflect.Type) ([]*StructField, error) {
+// If commentsFromSource is true, the doc comments which are not registered are got from the source (see StructDocCreater).
+func getStructFields(structType reflect.Type, commentsFromSource bool) ([]*StructField, error) {
 	structFields := []*StructField{}
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
+		if isXMLNameField(field) || isStructFieldIgnored(field) {
+			continue
+		}
 		if err := checkStructFieldNameFormat(field); err != nil {
 			return nil, &invalidStructFieldNameError{structType, field, err}
 		}
@@ -352,6 +597,12 @@ func getStructFields(structType reflect.Type) ([]*StructField, error) {
 		if err != nil {
 			return nil, &invalidStructFieldError{structType, field, err}
 		}
+		if _, ok := field.Tag.Lookup("desc"); !ok {
+			comment := docComments.get(structType.PkgPath(), getDocCommentKey(structType)+"."+field.Name, commentsFromSource)
+			if comment != "" {
+				structField.Description = getStructFieldDescriptionFromComment(field, comment)
+			}
+		}
 		structFields = append(structFields, structField)
 	}
 	return structFields, nil
@@ -397,6 +648,21 @@ func getStructField(field reflect.StructField) (*StructField, error) {
 	if err != nil {
 		return nil, err
 	}
+	readOnly, err := getStructFieldReadOnly(field)
+	if err != nil {
+		return nil, err
+	}
+	writeOnly, err := getStructFieldWriteOnly(field)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly && writeOnly {
+		return nil, errors.New("readonly and writeonly cann't both be true")
+	}
+	nullable, err := getStructFieldNullable(field)
+	if err != nil {
+		return nil, err
+	}
 
 	isArrary := checkStructFieldTypeIsSlice(field)
 	isStruct := checkStructFieldTypeIsStruct(field)
@@ -407,6 +673,14 @@ func getStructField(field reflect.StructField) (*StructField, error) {
 			return nil, err
 		}
 	}
+	example, err := getStructFieldExample(field)
+	if err != nil {
+		return nil, err
+	}
+	xmlInfo, err := getStructFieldXML(field, fieldName)
+	if err != nil {
+		return nil, err
+	}
 
 	structField := &StructField{
 		IsArray:     isArrary,
@@ -420,6 +694,12 @@ func getStructField(field reflect.StructField) (*StructField, error) {
 		MaxLen:      maxLen,
 		Required:    required,
 		Default:     defaultValue,
+		ReadOnly:    readOnly,
+		WriteOnly:   writeOnly,
+		Nullable:    nullable,
+		Omitempty:   isOmitempty(field),
+		Example:     example,
+		XML:         xmlInfo,
 	}
 	if structField.IsStruct {
 		structUUID, err := getStructUUIDFromStructField(field)
@@ -431,6 +711,9 @@ func getStructField(field reflect.StructField) (*StructField, error) {
 		t := getReflectTypeFromStructField(field)
 		structField.ValueType = valueTypes[t.Kind()]
 	}
+	if err := checkStructFieldExample(structField); err != nil {
+		return nil, err
+	}
 	return structField, nil
 }
 
@@ -488,6 +771,9 @@ func invalidKeyCheck(field reflect.StructField) error {
 		"xlm",
 		"jos",
 		"jso",
+		"readOnly",
+		"writeOnly",
+		"examples",
 	}
 	for _, invalidKey := range invalidKeys {
 		_, ok := field.Tag.Lookup(invalidKey)
@@ -545,6 +831,67 @@ func getStructFieldDefalutValue(field reflect.StructField) (interface{}, error)
 	return nil, nil
 }
 
+// getStructFieldExample parse the tag example by the type of the field.
+// The example of an array is a JSON array, a struct can't set the tag example.
+func getStructFieldExample(field reflect.StructField) (interface{}, error) {
+	str, ok := field.Tag.Lookup("example")
+	if !ok {
+		return nil, nil
+	}
+	if checkStructFieldTypeIsStruct(field) {
+		return nil, errors.New("struct cann't set example tag")
+	}
+	if checkStructFieldTypeIsSlice(field) {
+		var example []interface{}
+		if err := json.Unmarshal([]byte(str), &example); err != nil {
+			return nil, errors.New("the example of an array must be a JSON array, " + err.Error())
+		}
+		return example, nil
+	}
+	valueType, ok := valueTypes[getReflectTypeFromStructField(field).Kind()]
+	if !ok {
+		return nil, nil
+	}
+	return parseValueByValueType(str, valueType)
+}
+
+// checkStructFieldExample check if the example matches the type, enum, min, max, minlen and maxlen of the field
+func checkStructFieldExample(field *StructField) error {
+	if field.Example == nil {
+		return nil
+	}
+	value, err := marshalToJSONValue(field.Example)
+	if err != nil {
+		return err
+	}
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if err := checkStructFieldValue(field, item, "example"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkStructFieldValue(field, value, "example")
+}
+
+// parseValueByValueType parse the string to the value of the valueType
+func parseValueByValueType(str string, valueType string) (interface{}, error) {
+	switch {
+	case isValueTypeString(valueType):
+		return str, nil
+	case isValueTypeInt(valueType):
+		return strconv.ParseInt(str, 10, getValueTypeByteSize(valueType))
+	case isValueTypeUint(valueType):
+		return strconv.ParseUint(str, 10, getValueTypeByteSize(valueType))
+	case isValueTypeFloat(valueType):
+		return strconv.ParseFloat(str, getValueTypeByteSize(valueType))
+	case isValueTypeBool(valueType):
+		return strconv.ParseBool(str)
+	}
+	return nil, errors.New(valueType + " is not supported")
+}
+
 func checkStructFieldEnum(enumStr string, valueType string) error {
 	return checkEnumFormat(enumStr, valueType)
 }
@@ -569,6 +916,22 @@ func getStructFieldRequired(field reflect.StructField) (bool, error) {
 	return _getStructFieldBoolByTag(field, "req")
 }
 
+func getStructFieldReadOnly(field reflect.StructField) (bool, error) {
+	return _getStructFieldBoolByTag(field, "readonly")
+}
+
+func getStructFieldWriteOnly(field reflect.StructField) (bool, error) {
+	return _getStructFieldBoolByTag(field, "writeonly")
+}
+
+// getStructFieldNullable a pointer field is nullable by default, the tag nullable overrides it.
+func getStructFieldNullable(field reflect.StructField) (bool, error) {
+	if _, ok := field.Tag.Lookup("nullable"); ok {
+		return _getStructFieldBoolByTag(field, "nullable")
+	}
+	return field.Type.Kind() == reflect.Ptr, nil
+}
+
 func _getStructFieldFloat64ByTag(field reflect.StructField, tag string) (*float64, error) {
 	valueType, ok := valueTypes[field.Type.Kind()]
 	if !ok {
@@ -631,12 +994,20 @@ func isOmitempty(field reflect.StructField) bool {
 // get Description in the struct field(like: Id string, description=""; ID string `desc:"session ID", description="session ID"`)
 func getStructFieldDescription(field reflect.StructField) string {
 	desc := field.Tag.Get("desc")
-	if isOmitempty(field) {
-		desc += " (为空则不输出)"
+	if isOmitempty(field) && OmitemptyAnnotation != "" {
+		desc += " " + OmitemptyAnnotation
 	}
 	return desc
 }
 
+// get Description from the Go doc comment of the struct field, if the field has no tag desc
+func getStructFieldDescriptionFromComment(field reflect.StructField, comment string) string {
+	if isOmitempty(field) && OmitemptyAnnotation != "" {
+		comment += " " + OmitemptyAnnotation
+	}
+	return comment
+}
+
 func checkTagsIfIsArrayOrStruct(field reflect.StructField) error {
 	tags := []string{"enum", "min", "max", "default", "minlen", "maxlen"}
 	for _, tag := range tags {
@@ -658,10 +1029,7 @@ func getStructUUIDFromStructField(field reflect.StructField) (string, error) {
 			break
 		}
 	}
-	if t.Kind() != reflect.Struct {
-		return "", &invalidStructError{t}
-	}
-	return getStructUUIDFromPkgPathAndName(t.PkgPath(), t.Name()), nil
+	return getStructUUID(t)
 }
 
 // check if struct field is Slice
@@ -674,9 +1042,10 @@ func checkStructFieldTypeIsSlice(field reflect.StructField) bool {
 }
 
 // check if struct field is reflect.struct
+// A registered interface (see RegisterPolymorphic) is treated as a struct.
 func checkStructFieldTypeIsStruct(field reflect.StructField) bool {
 	t := getReflectTypeFromStructField(field)
-	return t.Kind() == reflect.Struct
+	return t.Kind() == reflect.Struct || isPolymorphicType(t)
 }
 
 func getJsonNameFromTag(jsonTag string) string {
@@ -686,12 +1055,21 @@ func getJsonNameFromTag(jsonTag string) string {
 	}
 	return ""
 }
+
+// get the xml name from the tag (like: `xml:"http://example.com/book title,omitempty"`, the name is title).
+// If the name is a path (like: `xml:"tags>tag"`), the name is the first element of the path.
 func getXmlNameFromTag(xmlTag string) string {
-	strs := strings.Split(xmlTag, ",")
-	if len(strs) > 0 {
-		return strs[0]
+	_, name, _ := parseXMLTag(xmlTag)
+	return strings.Split(name, ">")[0]
+}
+
+// isStructFieldIgnored check if the field is ignored by the tag (like: Password string `json:"-"`)
+func isStructFieldIgnored(field reflect.StructField) bool {
+	jsonName := getJsonNameFromTag(field.Tag.Get("json"))
+	if jsonName != "" {
+		return jsonName == "-"
 	}
-	return ""
+	return getXmlNameFromTag(field.Tag.Get("xml")) == "-"
 }
 
 func getStructFieldName(field reflect.StructField) (string, error) {
@@ -711,13 +1089,7 @@ func getStructFieldName(field reflect.StructField) (string, error) {
 	if jsonName == "" {
 		return xmlName, nil
 	}
-	if xmlName == "" {
-		return jsonName, nil
-	}
-	if jsonName == xmlName {
-		return jsonName, nil
-	}
-	return "", errors.New(jsonName + "!=" + xmlName + ",json name must be equal to xml name")
+	return jsonName, nil
 }
 
 func checkStructFieldNameFormat(field reflect.StructField) error {
@@ -776,7 +1148,7 @@ func checkStructFieldType(field reflect.StructField) error {
 		break
 	}
 
-	if t.Kind() == reflect.Struct {
+	if t.Kind() == reflect.Struct || isPolymorphicType(t) {
 		return nil
 	}
 
@@ -813,7 +1185,7 @@ const (
 //   Checks if all Json names and xml names of the object structure fields comply with one of the following rules.
 //   1. only contains the Json name
 //   2. only contains the xml name
-//   3. json name  is equal to xml name
+//   3. contains the json name and the xml name (the names may be different)
 func checkStructFieldsJSONNameAndXMLNameFromObject(obj interface{}) error {
 	structType, err := getStructReflectType(obj)
 	if err != nil {
@@ -827,7 +1199,7 @@ func checkStructFieldsJSONNameAndXMLNameFromObject(obj interface{}) error {
 // Checks if all Json names and xml names of the structure fields comply with one of the following rules.
 //   1. only contains the Json name
 //   2. only contains the xml name
-//   3. json name  is equal to xml name
+//   3. contains the json name and the xml name (the names may be different)
 // Note! The parameter structFieldNameType should be structFiledNameTypeJSONAndXML at the first level of recursion.
 //       The parameter structMap ensure that a structure only checks once
 func checkStructFieldsJSONNameAndXMLName(structType reflect.Type, structFieldNameType *structFiledNameType, structMap map[string]reflect.Type) error {
@@ -842,6 +1214,9 @@ func checkStructFieldsJSONNameAndXMLName(structType reflect.Type, structFieldNam
 
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
+		if isXMLNameField(field) || isStructFieldIgnored(field) {
+			continue
+		}
 		fieldReflectType := getReflectTypeFromStructField(field)
 		if fieldReflectType.Kind() == reflect.Struct {
 			if err := checkStructFieldsJSONNameAndXMLName(fieldReflectType, structFieldNameType, structMap); err != nil {
@@ -858,11 +1233,12 @@ func checkStructFieldsJSONNameAndXMLName(structType reflect.Type, structFieldNam
 
 func _checkStructFieldsJSONNameAndXMLName(structType reflect.Type, field reflect.StructField, structFieldNameType *structFiledNameType) error {
 	jsonName := getJsonNameFromTag(field.Tag.Get("json"))
+	_, hasXMLTag := field.Tag.Lookup("xml")
 	xmlName := getXmlNameFromTag(field.Tag.Get("xml"))
-	if jsonName == "" && xmlName == "" {
+	if jsonName == "" && !hasXMLTag {
 		return nil
 	}
-	if jsonName != "" && xmlName == "" {
+	if jsonName != "" && !hasXMLTag {
 		if *structFieldNameType == structFiledNameTypeOnlyXML && field.Name != jsonName {
 			err := errors.New("The struct may be only contains the xml name, but the field (" + field.Name + ") contains the json name")
 			return &invalidStructFieldNameError{structType, field, err}
@@ -878,19 +1254,18 @@ func _checkStructFieldsJSONNameAndXMLName(structType reflect.Type, field reflect
 		*structFieldNameType = structFiledNameTypeOnlyXML
 		return nil
 	}
-	if jsonName == xmlName {
-		if *structFieldNameType == structFiledNameTypeOnlyXML {
-			err := errors.New("The struct may be only contains the xml name, but the field (" + field.Name + ") contains the xml and json names")
-			return &invalidStructFieldNameError{structType, field, err}
-		}
-		if *structFieldNameType == structFiledNameTypeOnlyJSON {
-			err := errors.New("The struct may be only contains the json name, but the field (" + field.Name + ") contains the xml and json names")
-			return &invalidStructFieldNameError{structType, field, err}
-		}
+	if jsonName == "" {
 		return nil
 	}
-	err := errors.New(jsonName + "!=" + xmlName + ",json name must be equal to xml name")
-	return &invalidStructFieldNameError{structType, field, err}
+	if *structFieldNameType == structFiledNameTypeOnlyXML {
+		err := errors.New("The struct may be only contains the xml name, but the field (" + field.Name + ") contains the xml and json names")
+		return &invalidStructFieldNameError{structType, field, err}
+	}
+	if *structFieldNameType == structFiledNameTypeOnlyJSON {
+		err := errors.New("The struct may be only contains the json name, but the field (" + field.Name + ") contains the xml and json names")
+		return &invalidStructFieldNameError{structType, field, err}
+	}
+	return nil
 }
 
 // An invalidStructError describes an invalid argument.
@@ -5,16 +5,20 @@ import (
 	"reflect"
 	"testing"
 	"unsafe"
+
+	"github.com/enjoy-web/ehttp/swagger"
 )
 
 func TestGetStructFieldName(t *testing.T) {
 	type VaildA struct {
 		ID    string
-		Name  string `json:"name" xml:"name"`
-		Addr  string `json:"addr" xml:"addr"`
-		Email string `json:"email" xml:"email"`
+		Name  string   `json:"name" xml:"name"`
+		Addr  string   `json:"addr" xml:"addr"`
+		Email string   `json:"email" xml:"email"`
+		Title string   `json:"title" xml:"Title,attr"`
+		Tags  []string `xml:"tags>tag"`
 	}
-	fieldNameList := []string{"ID", "name", "addr", "email"}
+	fieldNameList := []string{"ID", "name", "addr", "email", "title", "tags"}
 	a := VaildA{}
 	structType := reflect.TypeOf(a)
 	for i := 0; i < structType.NumField(); i++ {
@@ -192,9 +196,9 @@ func InvalidListForTestGetStructDoc() []*TestNodeForTestGetStructDoc {
 	type B struct {
 		ID string `json:"id+c?"`
 	}
-	// err : Id!=id,json name must be equal to xml name
+	// err : invalid xml path book>id, only the array items can be wrapped by one element
 	type C struct {
-		ID string `json:"Id" xml:"id"`
+		ID string `json:"id" xml:"book>id"`
 	}
 	// err: **int is not supported
 	type D struct {
@@ -438,6 +442,14 @@ func ValidListForCheckStructFieldsJSONNameAndXMLName() []*TestNodeForTestCheckSt
 		LoopA *LoopA
 	}
 
+	// the json name is not equal to the xml name
+	type JSONNotEqualXMLA struct {
+		ID    string   `json:"id" xml:"ID,attr"`
+		Name  string   `json:"name" xml:"Name"`
+		Tags  []string `json:"tags" xml:"tags>tag"`
+		Value string   `json:"value" xml:",chardata"`
+	}
+
 	return []*TestNodeForTestCheckStructFieldsJSONNameAndXMLName{
 		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&OnlyJSONA{}},
 		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&OnlyJSONB{}},
@@ -449,6 +461,7 @@ func ValidListForCheckStructFieldsJSONNameAndXMLName() []*TestNodeForTestCheckSt
 		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&JSONEqualXMLB{}},
 		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&JSONEqualXMLC{}},
 		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&LoopA{}},
+		&TestNodeForTestCheckStructFieldsJSONNameAndXMLName{&JSONNotEqualXMLA{}},
 	}
 }
 
@@ -496,3 +509,331 @@ func TestCheckStructFieldsJSONNameAndXMLName(t *testing.T) {
 		}
 	}
 }
+
+type testGenericBook struct {
+	ID string `json:"id" desc:"id of the book"`
+}
+
+type testGenericPage[T any] struct {
+	Total int64 `json:"total"`
+	Items []T   `json:"items"`
+	First T     `json:"first"`
+}
+
+type testGenericPair[K any, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+func TestGetGenericStructName(t *testing.T) {
+	cases := map[string]interface{}{
+		"testGenericPageOfTestGenericBook":                   testGenericPage[testGenericBook]{},
+		"testGenericPageOfString":                            &testGenericPage[string]{},
+		"testGenericPageOfTestGenericPageOfTestGenericBook":  testGenericPage[testGenericPage[testGenericBook]]{},
+		"testGenericPairOfStringAndTestGenericBook":          testGenericPair[string, *testGenericBook]{},
+		"testGenericPairOfListOfInt64AndMapOfStringToString": testGenericPair[[]int64, map[string]string]{},
+	}
+	for name, obj := range cases {
+		structType, err := getStructReflectType(obj)
+		if err != nil {
+			testError(t, err)
+			continue
+		}
+		structName, err := getStructName(structType)
+		if err != nil {
+			testError(t, err)
+			continue
+		}
+		if structName != name {
+			testError(t, structName+" should be equal to "+name)
+		}
+	}
+
+	invalidNames := []string{
+		"Page[",
+		"Page[]",
+		"Page[a.B]]",
+		"Page[struct {}]",
+	}
+	for _, name := range invalidNames {
+		if structName, err := getGenericStructName(name); err != nil {
+			testLog(t, err)
+		} else {
+			testError(t, name+" should be invalid, but got "+structName)
+		}
+	}
+}
+
+type testGenericList[T any] struct {
+	Items []T `json:"items"`
+}
+
+type testGenericTags struct {
+	Tags        testGenericList[Tag]         `json:"tags"`
+	SwaggerTags testGenericList[swagger.Tag] `json:"swagger_tags"`
+}
+
+func TestGetGenericStructName_Collisions(t *testing.T) {
+	cases := []struct {
+		obj  interface{}
+		name string
+		uuid string
+	}{
+		{testGenericList[testGenericBook]{}, "testGenericListOfTestGenericBook", "testGenericList[github.com/enjoy-web/ehttp.testGenericBook]"},
+		{testGenericList[*testGenericBook]{}, "testGenericListOfTestGenericBook", "testGenericList[github.com/enjoy-web/ehttp.testGenericBook]"},
+		{testGenericList[Tag]{}, "testGenericListOfTag", "testGenericList[github.com/enjoy-web/ehttp.Tag]"},
+		{testGenericList[swagger.Tag]{}, "testGenericListOfTag", "testGenericList[github.com/enjoy-web/ehttp/swagger.Tag]"},
+	}
+	for _, c := range cases {
+		structType, err := getStructReflectType(c.obj)
+		if err != nil {
+			testError(t, err)
+			continue
+		}
+		if structName, err := getStructName(structType); err != nil || structName != c.name {
+			testError(t, structName+" should be equal to "+c.name, err)
+		}
+		if uuid, err := getStructUUID(structType); err != nil || uuid != "github.com/enjoy-web/ehttp."+c.uuid {
+			testError(t, uuid+" should be equal to "+c.uuid, err)
+		}
+	}
+
+	// the pointers have the same document
+	docs, err := (&StructDocCreater{}).GetStructDocMap(&testGenericPair[testGenericList[*testGenericBook], testGenericList[testGenericBook]]{})
+	if err != nil {
+		testError(t, err)
+	} else if getDefinitionsFromStructDocMap(docs)["testGenericListOfTestGenericBook"] == nil {
+		testError(t, "definition testGenericListOfTestGenericBook should be exist", docs)
+	}
+	// the same name of the different type arguments conflicts
+	if _, err := (&StructDocCreater{}).GetStructDocMap(&testGenericTags{}); err == nil {
+		testError(t, "testGenericList[Tag] and testGenericList[swagger.Tag] should conflict")
+	}
+}
+
+func TestEngine_DefinitionConfliction(t *testing.T) {
+	newDoc := func(model interface{}) *APIDocCommon {
+		return &APIDocCommon{
+			Summary:   "Get the list",
+			Produces:  []string{Application_Json},
+			Responses: map[int]Response{200: Response{Description: "successful operation", Model: model}},
+		}
+	}
+	router := NewEngine(conf)
+	if err := router.GET("/tags", newDoc(&testGenericList[Tag]{}), handleFunc); err != nil {
+		testError(t, err)
+	}
+	if err := router.GET("/books", newDoc(&testGenericList[*testGenericBook]{}), handleFunc); err != nil {
+		testError(t, err)
+	}
+	// the same model can be used again
+	if err := router.GET("/tags/all", newDoc(&testGenericList[Tag]{}), handleFunc); err != nil {
+		testError(t, err)
+	}
+	// testGenericListOfTag is already the document of testGenericList[Tag]
+	if err := router.GET("/swagger/tags", newDoc(&testGenericList[swagger.Tag]{}), handleFunc); err != nil {
+		testLog(t, err)
+	} else {
+		testError(t, "testGenericList[Tag] and testGenericList[swagger.Tag] should conflict")
+	}
+}
+
+func TestGetStructDocMapWithGenericType(t *testing.T) {
+	creater := StructDocCreater{}
+	docs, err := creater.GetStructDocMap(&testGenericPage[testGenericBook]{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	definitions := getDefinitionsFromStructDocMap(docs)
+	page, ok := definitions["testGenericPageOfTestGenericBook"]
+	if !ok {
+		testError(t, "definition testGenericPageOfTestGenericBook should be exist")
+		return
+	}
+	if _, ok := definitions["testGenericBook"]; !ok {
+		testError(t, "definition testGenericBook should be exist")
+	}
+	if page.Properties["items"].Items.Ref != _definitions+"testGenericBook" {
+		testError(t, "items should refer to "+_definitions+"testGenericBook")
+	}
+	if page.Properties["first"].Ref != _definitions+"testGenericBook" {
+		testError(t, "first should refer to "+_definitions+"testGenericBook")
+	}
+
+	// nested generic types
+	docs, err = (&StructDocCreater{}).GetStructDocMap(&testGenericPage[testGenericPage[testGenericBook]]{})
+	if err != nil {
+		testError(t, err)
+	} else {
+		nested := getDefinitionsFromStructDocMap(docs)["testGenericPageOfTestGenericPageOfTestGenericBook"]
+		if nested == nil || nested.Properties["first"].Ref != _definitions+"testGenericPageOfTestGenericBook" {
+			testError(t, "first should refer to "+_definitions+"testGenericPageOfTestGenericBook")
+		}
+	}
+
+	ref, err := getRefFromObject(&testGenericPage[testGenericBook]{})
+	if err != nil {
+		testError(t, err)
+	} else if ref != _definitions+"testGenericPageOfTestGenericBook" {
+		testError(t, ref+" should be equal to "+_definitions+"testGenericPageOfTestGenericBook")
+	}
+}
+
+func TestGetStructFieldReadOnlyWriteOnlyNullable(t *testing.T) {
+	type A struct {
+		ID       string  `json:"id" readonly:"true"`
+		Password string  `json:"password" writeonly:"true"`
+		Note     *string `json:"note"`
+		Title    *string `json:"title" nullable:"false"`
+		Summary  string  `json:"summary" nullable:"true"`
+		Tags     string  `json:"tags,omitempty" desc:"tags"`
+	}
+	creater := StructDocCreater{}
+	doc, err := creater.GetStructDoc(&A{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	fields := map[string]*StructField{}
+	for _, field := range doc.StructFields {
+		fields[field.Name] = field
+	}
+	if !fields["id"].ReadOnly || fields["id"].WriteOnly {
+		testError(t, "id should be read only")
+	}
+	if !fields["password"].WriteOnly || fields["password"].ReadOnly {
+		testError(t, "password should be write only")
+	}
+	if !fields["note"].Nullable {
+		testError(t, "note should be nullable")
+	}
+	if fields["title"].Nullable {
+		testError(t, "title should not be nullable")
+	}
+	if !fields["summary"].Nullable {
+		testError(t, "summary should be nullable")
+	}
+	if !fields["tags"].Omitempty {
+		testError(t, "tags should be omitempty")
+	}
+
+	definitions := getDefinitionsFromStructDocMap(map[string]*StructDoc{doc.UUID: doc})
+	properties := definitions["A"].Properties
+	if !properties["id"].ReadOnly || !properties["password"].WriteOnly || !properties["note"].Nullable {
+		testError(t, "readOnly, writeOnly and nullable should be set in the properties")
+	}
+
+	// err: readonly and writeonly cann't both be true
+	type B struct {
+		ID string `json:"id" readonly:"true" writeonly:"true"`
+	}
+	if _, err := creater.GetStructDoc(&B{}); err != nil {
+		testLog(t, err)
+	} else {
+		testError(t, "err should not be nil")
+	}
+
+	// err: invalid tag name readOnly
+	type C struct {
+		ID string `json:"id" readOnly:"true"`
+	}
+	if _, err := creater.GetStructDoc(&C{}); err != nil {
+		testLog(t, err)
+	} else {
+		testError(t, "err should not be nil")
+	}
+}
+
+func TestOmitemptyAnnotation(t *testing.T) {
+	type A struct {
+		Tags string `json:"tags,omitempty" desc:"tags"`
+	}
+	field := reflect.TypeOf(A{}).Field(0)
+	defer func(annotation string) { OmitemptyAnnotation = annotation }(OmitemptyAnnotation)
+
+	OmitemptyAnnotation = OmitemptyAnnotationEN
+	if desc := getStructFieldDescription(field); desc != "tags "+OmitemptyAnnotationEN {
+		testError(t, desc+" should be equal to tags "+OmitemptyAnnotationEN)
+	}
+	OmitemptyAnnotation = ""
+	if desc := getStructFieldDescription(field); desc != "tags" {
+		testError(t, desc+" should be equal to tags")
+	}
+}
+
+func TestGetStructFieldExample(t *testing.T) {
+	type A struct {
+		Title string   `json:"title" example:"Demo book"`
+		Pages int      `json:"pages" min:"1" example:"100"`
+		Price float32  `json:"price" example:"9.9"`
+		Valid bool     `json:"valid" example:"true"`
+		Type  string   `json:"type" enum:"a b" example:"b"`
+		Tags  []string `json:"tags" example:"[\"a\",\"b\"]"`
+	}
+	creater := StructDocCreater{}
+	docs, err := creater.GetStructDocMap(&A{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	properties := getDefinitionsFromStructDocMap(docs)["A"].Properties
+	if properties["title"].Example != "Demo book" {
+		testError(t, "the example of title should be Demo book")
+	}
+	if properties["pages"].Example != int64(100) {
+		testError(t, "the example of pages should be 100")
+	}
+	if tags, ok := properties["tags"].Example.([]interface{}); !ok || len(tags) != 2 {
+		testError(t, "the example of tags should be [a b]")
+	}
+
+	type B struct {
+		Pages int `json:"pages" min:"1" example:"0"`
+	}
+	type C struct {
+		Type string `json:"type" enum:"a b" example:"c"`
+	}
+	type D struct {
+		Tags []string `json:"tags" example:"a"`
+	}
+	type E struct {
+		Tags []int `json:"tags" example:"[\"a\"]"`
+	}
+	type F struct {
+		A A `json:"a" example:"{}"`
+	}
+	type G struct {
+		Valid bool `json:"valid" example:"yes"`
+	}
+	for index, obj := range []interface{}{&B{}, &C{}, &D{}, &E{}, &F{}, &G{}} {
+		if _, err := creater.GetStructDoc(obj); err != nil {
+			testLog(t, index, err)
+		} else {
+			testError(t, index, "err should not be nil")
+		}
+	}
+}
+
+type testStructNameConflictionA struct {
+	Tag *Tag `json:"tag"`
+}
+
+type testStructNameConflictionB struct {
+	Tag  *Tag         `json:"tag"`
+	Tag2 *swagger.Tag `json:"tag2"`
+}
+
+func TestCheckStructNameConfliction(t *testing.T) {
+	creater := &StructDocCreater{}
+	if _, err := creater.GetStructDocMap(&testStructNameConflictionB{}); err == nil {
+		testError(t, "the different structs with the same name in one model should be an error")
+	}
+	// the models are checked independently
+	if _, err := (&StructDocCreater{}).GetStructDocMap(&testStructNameConflictionA{}); err != nil {
+		testError(t, err)
+	}
+	if _, err := (&StructDocCreater{}).GetStructDocMap(&swagger.Tag{}); err != nil {
+		testError(t, err)
+	}
+}
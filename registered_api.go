@@ -0,0 +1,60 @@
+package ehttp
+
+// API a registered API, it is the view of the registered APIs for the tools (like: the package ehttptest)
+// Fields:
+//   Method -- the method of the API (like: GET)
+//   Path -- the swagger style path without the Config.BasePath (like: /books/{id})
+//   OperationID -- the operationId of the API
+//   Consumes -- the MIME types of the request body
+//   Parameters -- the parameters (not in body) in the order of the document, the references of the global parameters are resolved
+//   Request -- the request body, nil if the API has no request body
+//   Responses -- the documented responses (the key -1 is the default response), only the APIDocCommon has the responses
+type API struct {
+	Method      string
+	Path        string
+	OperationID string
+	Consumes    []string
+	Parameters  []*APIParameter
+	Request     *Request
+	Responses   map[int]Response
+}
+
+// APIParameter a parameter (not in body) of a registered API
+// Fields:
+//   Name -- the name of the parameter
+//   In -- InPath, InHeader, InQuery or InFormData
+//   ValueInfo -- the value type info of the parameter
+type APIParameter struct {
+	Name      string
+	In        string
+	ValueInfo *ValueInfo
+}
+
+// IsRequired the parameters in path are always required
+func (p *APIParameter) IsRequired() bool {
+	return p.ValueInfo.Required || p.In == InPath
+}
+
+// GetAPIs get the registered APIs in the order of the registration
+func (e *Engine) GetAPIs() ([]*API, error) {
+	apis := []*API{}
+	for _, api := range e.apis {
+		parameters, err := e.getAPIParameters(api)
+		if err != nil {
+			return nil, err
+		}
+		a := &API{
+			Method:      api.method,
+			Path:        api.path,
+			OperationID: api.operation.OperationID,
+			Consumes:    api.operation.Consumes,
+			Request:     api.doc.GetRequest(),
+			Responses:   api.getResponses(),
+		}
+		for _, p := range parameters {
+			a.Parameters = append(a.Parameters, &APIParameter{Name: p.name, In: p.in, ValueInfo: p.valueInfo})
+		}
+		apis = append(apis, a)
+	}
+	return apis, nil
+}
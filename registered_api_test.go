@@ -0,0 +1,43 @@
+package ehttp
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEngineGetAPIs(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(&Config{})
+	if err := router.SetGlobalParameters(map[string]Parameter{
+		"X-Request-Id": Parameter{InHeader: &ValueInfo{Type: "string", Required: true}},
+	}); err != nil {
+		testError(t, err)
+		return
+	}
+	doc := &APIDocCommon{
+		OperationID:          "getBook",
+		GlobalParameterNames: []string{"X-Request-Id"},
+		Parameters:           map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "string"}}},
+		Responses:            map[int]Response{200: Response{Description: "ok"}},
+	}
+	if err := router.GET("/books/:id", doc, func(c *gin.Context, err error) {}); err != nil {
+		testError(t, err)
+		return
+	}
+	apis, err := router.GetAPIs()
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if len(apis) != 1 || apis[0].Method != GET || apis[0].Path != "/books/{id}" || apis[0].OperationID != "getBook" {
+		testError(t, "invalid APIs", apis)
+		return
+	}
+	if len(apis[0].Parameters) != 2 || apis[0].Parameters[0].Name != "X-Request-Id" || !apis[0].Parameters[1].IsRequired() {
+		testError(t, "the global parameters should be resolved, and the parameters in path are required")
+	}
+	if _, ok := apis[0].Responses[200]; !ok {
+		testError(t, "the responses should be documented")
+	}
+}
@@ -1,6 +1,9 @@
 package ehttp
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/enjoy-web/ehttp/swagger"
 )
 
@@ -8,22 +11,73 @@ import (
 // Fields
 //     Description -- Description of the Request model
 //     Model -- The Request Model (nil, struct, or []string )
+//     Example -- Example of the Request Model (a Model object, or the JSON data as string, []byte or json.RawMessage).
+//                It is checked with the Model when the API is registered.
 type Request struct {
 	Description string
 	Model       interface{}
+	Example     interface{}
 }
 
 // ToSwaggerSchema to swagger.Parameter
 func (r Request) toSwaggerParameter() (*swagger.Parameter, error) {
+	return r.toSwaggerParameterWithConsumes(nil)
+}
+
+func (r Request) toSwaggerParameterWithConsumes(consumes []string) (*swagger.Parameter, error) {
 	ref, err := getRefFromObject(r.Model)
 	if err != nil {
 		return nil, err
 	}
-	return &swagger.Parameter{
+	param := &swagger.Parameter{
 		Name:        "body",
 		In:          "body",
 		Description: r.Description,
 		Required:    true,
 		Schema:      &swagger.Schema{Ref: ref},
-	}, nil
+	}
+	if r.Example != nil {
+		example, err := getModelExample(r.Model, r.Example, modelInRequest)
+		if err != nil {
+			return nil, errors.New("invalid Request.Example, " + err.Error())
+		}
+		param.Examples = map[string]interface{}{}
+		for _, mimeType := range getExampleMIMETypes(consumes) {
+			param.Examples[mimeType] = example
+		}
+	}
+	return param, nil
+}
+
+// getModelExample check the example with the model, and return the example value decoded from JSON
+func getModelExample(model interface{}, example interface{}, direction modelDirection) (interface{}, error) {
+	if model == nil {
+		return nil, errors.New("the Model is nil")
+	}
+	validator, err := newModelValidator(model, direction)
+	if err != nil {
+		return nil, err
+	}
+	value, err := toJSONValue(example)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Check(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// getExampleMIMETypes get the JSON MIME types for examples, default is application/json
+func getExampleMIMETypes(mimeTypes []string) []string {
+	list := []string{}
+	for _, mimeType := range mimeTypes {
+		if strings.Contains(mimeType, "json") {
+			list = append(list, mimeType)
+		}
+	}
+	if len(list) == 0 {
+		list = append(list, Application_Json)
+	}
+	return list
 }
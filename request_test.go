@@ -24,3 +24,25 @@ func TestRequest_toSwaggerParameter(t *testing.T) {
 		testError(t, "invalidReq.toSwaggerParameter() err should not be nil")
 	}
 }
+
+func TestRequest_Example(t *testing.T) {
+	type book struct {
+		ID    string `json:"id" readonly:"true"`
+		Title string `json:"title" req:"true"`
+	}
+	req := &Request{Model: &book{}, Example: `{"title":"Demo book"}`}
+	param, err := req.toSwaggerParameterWithConsumes([]string{Application_Json_utf8})
+	if err != nil {
+		testError(t, err)
+	} else if _, ok := param.Examples[Application_Json_utf8]; !ok {
+		testError(t, "the example of "+Application_Json_utf8+" should be set")
+	}
+
+	// err: id is read only
+	req.Example = &book{ID: "123", Title: "Demo book"}
+	if _, err := req.toSwaggerParameter(); err != nil {
+		testLog(t, err)
+	} else {
+		testError(t, "err should not be nil")
+	}
+}
@@ -1,6 +1,8 @@
 package ehttp
 
 import (
+	"errors"
+
 	"github.com/enjoy-web/ehttp/swagger"
 )
 
@@ -9,14 +11,21 @@ import (
 //     Description -- Description of the response model
 //     Model -- The Response Model (nil, struct, or []string )
 //     Headers -- The Response info in the HTTP header
+//     Example -- Example of the Response Model (a Model object, or the JSON data as string, []byte or json.RawMessage).
+//                It is checked with the Model when the API is registered.
 type Response struct {
 	Description string
 	Model       interface{}
 	Headers     map[string]ValueInfo
+	Example     interface{}
 }
 
 // ToSwaggerResponse to *swagger.Response
 func (r Response) ToSwaggerResponse() (*swagger.Response, error) {
+	return r.toSwaggerResponseWithProduces(nil)
+}
+
+func (r Response) toSwaggerResponseWithProduces(produces []string) (*swagger.Response, error) {
 	resp := &swagger.Response{Description: r.Description}
 	if r.hasModel() {
 		schema, err := getSwaggerSchemaFromObj(r.Model)
@@ -25,6 +34,16 @@ func (r Response) ToSwaggerResponse() (*swagger.Response, error) {
 		}
 		resp.Schema = schema
 	}
+	if r.hasExample() {
+		example, err := getModelExample(r.Model, r.Example, modelInResponse)
+		if err != nil {
+			return nil, errors.New("invalid Response.Example, " + err.Error())
+		}
+		resp.Examples = map[string]interface{}{}
+		for _, mimeType := range getExampleMIMETypes(produces) {
+			resp.Examples[mimeType] = example
+		}
+	}
 	if r.hasHeaders() {
 		resp.Headers = make(map[string]*swagger.Header, 0)
 		for name, valueInfo := range r.Headers {
@@ -42,6 +61,10 @@ func (r Response) hasModel() bool {
 	return r.Model != nil
 }
 
+func (r Response) hasExample() bool {
+	return r.Example != nil
+}
+
 func (r Response) hasHeaders() bool {
 	return r.Headers != nil
 }
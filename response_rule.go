@@ -0,0 +1,243 @@
+package ehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inResponseHeader the parameter rules of the response headers get the values from the headers of the response
+const inResponseHeader = "responseHeader"
+
+// ResponseViolation a response of a handler which does not match the documented Responses (see Config.ValidateResponses)
+// Fields:
+//   Method -- the method of the API
+//   Path -- the path of the API (like: /books/:id)
+//   StatusCode -- the status code of the response
+//   Err -- the violation
+type ResponseViolation struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Err        error
+}
+
+func (v *ResponseViolation) Error() string {
+	return fmt.Sprintf("%s %s response %d: %s", v.Method, v.Path, v.StatusCode, v.Err.Error())
+}
+
+// responseRule the rule of a documented response
+type responseRule struct {
+	validator   *modelValidator
+	headerRules []parameterRule
+}
+
+// responsesRule the rules of the documented responses, the key -1 is the default response.
+// If a model has the write only fields (writeOnly is true), they are removed from the responses (see responsesRule.Strip).
+type responsesRule struct {
+	responses map[int]*responseRule
+	writeOnly bool
+}
+
+// newResponsesRule return nil if the APIDoc has no documented responses, only the APIDocCommon has the responses.
+// The headers are required, an invalid model is an error (like: []string), so the responses are not passed without the validation.
+func newResponsesRule(doc APIDoc) (*responsesRule, error) {
+	apiDoc, ok := doc.(*APIDocCommon)
+	if !ok || len(apiDoc.Responses) == 0 {
+		return nil, nil
+	}
+	rules := &responsesRule{responses: map[int]*responseRule{}}
+	for statusCode, response := range apiDoc.Responses {
+		rule := &responseRule{}
+		if response.hasModel() {
+			validator, err := newModelValidator(response.Model, modelInResponse)
+			if err != nil {
+				return nil, errors.New("invalid model of the response " + strconv.Itoa(statusCode) + ", " + err.Error())
+			}
+			rule.validator = validator
+			if validator.HasHidden() {
+				rules.writeOnly = true
+			}
+		}
+		for name, valueInfo := range response.Headers {
+			valueInfo.Required = true
+			headerRule, err := newParameterRule(name, inResponseHeader, &valueInfo)
+			if err != nil {
+				return nil, errors.New("invalid header " + name + " of the response " + strconv.Itoa(statusCode) + ", " + err.Error())
+			}
+			rule.headerRules = append(rule.headerRules, headerRule)
+		}
+		rules.responses[statusCode] = rule
+	}
+	return rules, nil
+}
+
+func (r *responsesRule) getRule(statusCode int) (*responseRule, bool) {
+	rule, ok := r.responses[statusCode]
+	if !ok {
+		rule, ok = r.responses[-1]
+	}
+	return rule, ok
+}
+
+// Strip remove the write only fields of the model from the JSON body written by the handler,
+// the body is not changed if it is not JSON or has no write only field
+func (r *responsesRule) Strip(c Context, statusCode int, body []byte) []byte {
+	rule, ok := r.getRule(statusCode)
+	if !ok || rule.validator == nil || !strings.Contains(c.ResponseHeader().Get("Content-Type"), "json") {
+		return body
+	}
+	// the numbers are kept as they are written
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || !rule.validator.Strip(value) {
+		return body
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	c.ResponseHeader().Del("Content-Length")
+	return data
+}
+
+// Check check the response written by the handler, the body is checked if it is JSON
+func (r *responsesRule) Check(c Context, statusCode int, body []byte) error {
+	rule, ok := r.getRule(statusCode)
+	if !ok {
+		return errors.New("the status code is not documented")
+	}
+	for _, headerRule := range rule.headerRules {
+		if err := headerRule.Check(c); err != nil {
+			return errors.New("invalid header, " + err.Error())
+		}
+	}
+	if rule.validator == nil || !strings.Contains(c.ResponseHeader().Get("Content-Type"), "json") {
+		return nil
+	}
+	if err := rule.validator.CheckJSON(body); err != nil {
+		return errors.New("invalid body, " + err.Error())
+	}
+	return nil
+}
+
+// checkRecordedResponse check the response recorded by the recorder (see Config.ValidateResponses and Config.StrictResponses),
+// the violation is reported. If the response is buffered, the write only fields are removed (see responsesRule.Strip),
+// and it is written to w after the check, or 500 with the violation is written instead (see Config.StrictResponses).
+func (e *Engine) checkRecordedResponse(c Context, route *Route, w http.ResponseWriter, recorder *responseRecorder) {
+	statusCode := recorder.status
+	body := recorder.body.Bytes()
+	if recorder.buffered {
+		body = route.responses.Strip(c, statusCode, body)
+	}
+	var violation *ResponseViolation
+	if e.Conf.ValidateResponses || e.Conf.StrictResponses {
+		if err := route.responses.Check(c, statusCode, body); err != nil {
+			violation = &ResponseViolation{Method: route.Method, Path: route.ginPath, StatusCode: statusCode, Err: err}
+			e.reportResponseViolation(violation)
+		}
+	}
+	if !recorder.buffered {
+		return
+	}
+	if violation != nil && e.Conf.StrictResponses {
+		writeText(w, http.StatusInternalServerError, violation.Error())
+		return
+	}
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+func (e *Engine) reportResponseViolation(violation *ResponseViolation) {
+	if e.Conf.ResponseViolationHook != nil {
+		e.Conf.ResponseViolationHook(violation)
+		return
+	}
+	logWarning("response " + violation.Error())
+}
+
+// responseRecorder the ResponseWriter capturing the status and the body of the response.
+// If it is buffered, the response is not written, it is written after the validation.
+type responseRecorder struct {
+	http.ResponseWriter
+	buffered    bool
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	if code > 0 && !w.wroteHeader {
+		w.status = code
+	}
+	if !w.buffered {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(data)
+	if w.buffered {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && !w.buffered {
+		flusher.Flush()
+	}
+}
+
+// ginResponseRecorder the gin.ResponseWriter of the responseRecorder, the handlers on gin write to it
+type ginResponseRecorder struct {
+	gin.ResponseWriter
+	recorder *responseRecorder
+}
+
+func (w *ginResponseRecorder) WriteHeader(code int) {
+	w.recorder.WriteHeader(code)
+}
+
+func (w *ginResponseRecorder) WriteHeaderNow() {
+	if !w.recorder.buffered {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *ginResponseRecorder) Write(data []byte) (int, error) {
+	return w.recorder.Write(data)
+}
+
+func (w *ginResponseRecorder) WriteString(s string) (int, error) {
+	return w.recorder.Write([]byte(s))
+}
+
+func (w *ginResponseRecorder) Status() int {
+	if w.recorder.buffered {
+		return w.recorder.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *ginResponseRecorder) Size() int {
+	if w.recorder.buffered {
+		return w.recorder.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+func (w *ginResponseRecorder) Written() bool {
+	if w.recorder.buffered {
+		return false
+	}
+	return w.ResponseWriter.Written()
+}
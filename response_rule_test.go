@@ -0,0 +1,162 @@
+package ehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testResponseBook struct {
+	ID     string `json:"id"`
+	Status string `json:"status" enum:"draft published"`
+	Pages  int    `json:"pages" min:"1"`
+}
+
+func newTestResponseEngine(conf *Config, respond func(c *gin.Context)) (*Engine, error) {
+	gin.SetMode(gin.ReleaseMode)
+	router := NewEngine(conf)
+	doc := &APIDocCommon{
+		Parameters: map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "string"}}},
+		Responses: map[int]Response{
+			200: Response{Model: &testResponseBook{}, Headers: map[string]ValueInfo{"X-Rate": ValueInfo{Type: "int32"}}},
+			404: Response{Description: "not found"},
+		},
+	}
+	return router, router.GET("/books/:id", doc, func(c *gin.Context, err error) {
+		respond(c)
+	})
+}
+
+func TestValidateResponses(t *testing.T) {
+	nodes := []struct {
+		respond   func(c *gin.Context)
+		violation string
+	}{
+		{func(c *gin.Context) {
+			c.Header("X-Rate", "10")
+			c.JSON(http.StatusOK, &testResponseBook{ID: "1", Status: "draft", Pages: 10})
+		}, ""},
+		{func(c *gin.Context) {
+			c.Status(http.StatusNotFound)
+		}, ""},
+		{func(c *gin.Context) {
+			c.String(http.StatusInternalServerError, "error")
+		}, "the status code is not documented"},
+		{func(c *gin.Context) {
+			c.JSON(http.StatusOK, &testResponseBook{ID: "1", Status: "draft", Pages: 10})
+		}, "invalid header, miss parameter X-Rate"},
+		{func(c *gin.Context) {
+			c.Header("X-Rate", "ten")
+			c.JSON(http.StatusOK, &testResponseBook{ID: "1", Status: "draft", Pages: 10})
+		}, "invalid header"},
+		{func(c *gin.Context) {
+			c.Header("X-Rate", "10")
+			c.JSON(http.StatusOK, &testResponseBook{ID: "1", Status: "sold", Pages: 10})
+		}, "invalid body, the field status should be one of"},
+		{func(c *gin.Context) {
+			c.Header("X-Rate", "10")
+			c.JSON(http.StatusOK, map[string]interface{}{"id": "1", "status": "draft", "pages": 0})
+		}, "invalid body, the field pages should be greater than or equal to 1"},
+	}
+	for index, node := range nodes {
+		violations := []*ResponseViolation{}
+		hook := func(violation *ResponseViolation) {
+			violations = append(violations, violation)
+		}
+		router, err := newTestResponseEngine(&Config{ValidateResponses: true, ResponseViolationHook: hook}, node.respond)
+		if err != nil {
+			testError(t, index, err)
+			continue
+		}
+		w := httptest.NewRecorder()
+		router.GinEngine().ServeHTTP(w, httptest.NewRequest(GET, "/books/1", nil))
+		if node.violation == "" {
+			if len(violations) != 0 {
+				testError(t, index, "unexpected violation", violations[0].Error())
+			}
+			continue
+		}
+		if len(violations) != 1 || !strings.Contains(violations[0].Err.Error(), node.violation) {
+			testError(t, index, "the violation should be "+node.violation, violations)
+			continue
+		}
+		if violations[0].Method != GET || violations[0].Path != "/books/:id" {
+			testError(t, index, "invalid violation", violations[0].Error())
+		}
+		// the response is not changed
+		if w.Code == http.StatusInternalServerError && w.Body.String() != "error" {
+			testError(t, index, "the response should not be changed, got", w.Body.String())
+		}
+	}
+}
+
+func TestStrictResponses(t *testing.T) {
+	book := &testResponseBook{ID: "1", Status: "draft", Pages: 10}
+	router, err := newTestResponseEngine(&Config{StrictResponses: true, ResponseViolationHook: func(*ResponseViolation) {}}, func(c *gin.Context) {
+		if c.Query("rate") != "" {
+			c.Header("X-Rate", c.Query("rate"))
+		}
+		c.JSON(http.StatusOK, book)
+	})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	w := httptest.NewRecorder()
+	router.GinEngine().ServeHTTP(w, httptest.NewRequest(GET, "/books/1?rate=10", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"1","status":"draft","pages":10}` || w.Header().Get("X-Rate") != "10" {
+		testError(t, "the valid response should be written", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.GinEngine().ServeHTTP(w, httptest.NewRequest(GET, "/books/1", nil))
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "GET /books/:id response 200: invalid header") {
+		testError(t, "the response with a violation should be 500", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Header().Get("Content-Type"), "json") {
+		testError(t, "the Content-Type of the violation should be text/plain")
+	}
+}
+
+func TestResponses_WriteOnly(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	for _, conf := range []*Config{{}, {ValidateResponses: true}, {StrictResponses: true}} {
+		violations := []*ResponseViolation{}
+		conf.ResponseViolationHook = func(violation *ResponseViolation) {
+			violations = append(violations, violation)
+		}
+		router := NewEngine(conf)
+		doc := &APIDocCommon{
+			Parameters: map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "string"}}},
+			Responses:  map[int]Response{200: Response{Model: &testReadOnlyBook{}}},
+		}
+		err := router.GET("/books/:id", doc, func(c *gin.Context, err error) {
+			c.JSON(http.StatusOK, &testReadOnlyBook{ID: c.Param("id"), Title: "Demo", Password: "secret"})
+		})
+		if err != nil {
+			testError(t, err)
+			return
+		}
+		w := httptest.NewRecorder()
+		router.GinEngine().ServeHTTP(w, httptest.NewRequest(GET, "/books/1", nil))
+		if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "password") || !strings.Contains(w.Body.String(), `"title":"Demo"`) {
+			testError(t, "the write only field should be removed from the response", conf, w.Code, w.Body.String())
+		}
+		if len(violations) != 0 {
+			testError(t, "the write only field should not be a violation", violations[0])
+		}
+	}
+}
+
+func TestNewResponsesRule_InvalidModel(t *testing.T) {
+	doc := &APIDocCommon{Responses: map[int]Response{200: Response{Model: []string{}}}}
+	if _, err := newResponsesRule(doc); err == nil || !strings.Contains(err.Error(), "invalid model of the response 200") {
+		testError(t, "the invalid model should be an error", err)
+	}
+	rules, err := newResponsesRule(&APIDocCommon{Responses: map[int]Response{200: Response{Model: &testResponseBook{}}}})
+	if err != nil || rules.responses[200].validator == nil {
+		testError(t, "the model should be validated", err)
+	}
+}
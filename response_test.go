@@ -44,3 +44,54 @@ func TestResponse_ToSwaggerResponse(t *testing.T) {
 		testError(t, "invalidResp.ToSwaggerResponse() err should not be not")
 	}
 }
+
+func TestResponse_Example(t *testing.T) {
+	type LoginInfo struct {
+		ID    string `json:"id" req:"true"`
+		Level int    `json:"level" min:"1" max:"3"`
+	}
+	resp := &Response{
+		Description: "login info",
+		Model:       &LoginInfo{},
+		Example:     &LoginInfo{ID: "abc", Level: 2},
+	}
+	swaggerResponse, err := resp.toSwaggerResponseWithProduces([]string{Application_Json, Application_Xml})
+	if err != nil {
+		testError(t, err)
+	} else {
+		if len(swaggerResponse.Examples) != 1 {
+			testError(t, "there should be one example")
+		}
+		example, ok := swaggerResponse.Examples[Application_Json].(map[string]interface{})
+		if !ok || example["id"] != "abc" {
+			testError(t, "the example of application/json should be set")
+		}
+	}
+
+	resp.Example = `{"id":"abc","level":1}`
+	if _, err := resp.ToSwaggerResponse(); err != nil {
+		testError(t, err)
+	}
+
+	invalidExamples := []interface{}{
+		&LoginInfo{ID: "abc", Level: 4},   // err: greater than max
+		`{"level":1}`,                      // err: miss id
+		`{"id":"abc","level":1,"name":""}`, // err: name is not defined
+		`{"id":1}`,                         // err: id should be a string
+		`{"id":`,                           // err: invalid JSON
+	}
+	for index, example := range invalidExamples {
+		resp.Example = example
+		if _, err := resp.ToSwaggerResponse(); err != nil {
+			testLog(t, index, err)
+		} else {
+			testError(t, index, "err should not be nil")
+		}
+	}
+
+	// err: Example without Model
+	invalidResp := &Response{Description: "login info", Example: `{"id":"abc"}`}
+	if _, err := invalidResp.ToSwaggerResponse(); err == nil {
+		testError(t, "err should not be nil")
+	}
+}
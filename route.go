@@ -0,0 +1,205 @@
+package ehttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+// Route a documented operation, it checks the requests with the document: the parameters, the request body and CORS.
+// The operations registered by the Engine (like: Engine.GET) are the Routes on gin,
+// the Routes on the other routers are created by Engine.NewRoute (see package httpadapter).
+// Fields:
+//   Method -- the method of the operation (like: GET)
+//   Path -- the swagger style path of the operation, without the BasePath (like: /books/{id})
+type Route struct {
+	Method             string
+	Path               string
+	ginPath            string
+	doc                APIDoc
+	operation          *swagger.Operation
+	rules              []parameterRule
+	accessControlAllow *accessControlAllow
+	deprecationHeaders map[string]string
+	responses          *responsesRule
+	engine             *Engine
+}
+
+// NewRoute document the operation, and return the Route which checks the requests of the operation.
+// The Route is not registered on gin, it is registered on the other routers by the adapters (see package httpadapter).
+//   relativePath -- the path without the BasePath, in the swagger style or the gin style (like: /books/{id}, /books/:id)
+//   handler -- the handler of the operation, the default operationId is derived from the name of the handler
+func (e *Engine) NewRoute(method string, relativePath string, doc APIDoc, handler interface{}) (*Route, error) {
+	return e.RegisterRoute(method, relativePath, doc, handler, nil)
+}
+
+// RegisterRoute new the Route of the operation, register it on the router by register, and then document the operation.
+// If the document or register fails (like: the router rejects the path), the operation is not documented.
+//   register -- register the Route on the router, it may be nil
+func (e *Engine) RegisterRoute(method string, relativePath string, doc APIDoc, handler interface{}, register func(route *Route) error) (*Route, error) {
+	if handler == nil {
+		return nil, &engineError{relativePath, method, errors.New("miss handler")}
+	}
+	return e.newRoute(method, relativePath, "", doc, nameOfFunction(handler), register)
+}
+
+// newRoute check the operation in the RouterGroup with the name group ("" if the operation is not in a group),
+// new the rules of the operation, register the Route by register (if it is not nil), and then document the operation
+func (e *Engine) newRoute(method string, relativePath string, group string, doc APIDoc, handlerName string, register func(route *Route) error) (*Route, error) {
+	// set method
+	if doc != nil {
+		doc.SetMethod(method)
+	}
+
+	// get swagger paths
+	var operation *swagger.Operation
+	var definitions map[string]*swagger.Schema
+	if doc != nil {
+		var err error
+		if operation, definitions, err = e.getSwaggerPath(relativePath, method, group, doc, handlerName); err != nil {
+			return nil, err
+		}
+	}
+
+	// to gin style path
+	swaggerPath, err := ginPathToSwaggerPath(relativePath)
+	if err != nil {
+		return nil, &engineError{relativePath, method, err}
+	}
+	path, err := swaggerPathToGinPath(swaggerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// check cors-origin
+	if e.Conf.AllowOrigin {
+		if _, err := getOriginByMethod(&corsInfos{}, method); err != nil {
+			return nil, err
+		}
+	}
+
+	route := &Route{Method: method, Path: swaggerPath, ginPath: path, doc: doc, operation: operation, engine: e}
+	if doc != nil {
+		if err := e.setRouteRules(route); err != nil {
+			return nil, err
+		}
+	}
+
+	// register the route, and then document it
+	if register != nil {
+		if err := register(route); err != nil {
+			return nil, err
+		}
+	}
+	if doc != nil {
+		e.setSwaggerPath(swaggerPath, method, doc, operation, definitions)
+	}
+	// init cors-origin (the method is checked above)
+	if e.Conf.AllowOrigin {
+		e.setAllownOrigin(method, path, doc)
+		if doc != nil {
+			route.accessControlAllow = e.getAccessControlAllow(method, path)
+		}
+	}
+	// the operations of the engine share one Mocker (see Config.MockMode)
+	if e.Conf.MockMode && e.mocker == nil {
+		e.mocker = &engineMocker{}
+	}
+	return route, nil
+}
+
+// setRouteRules new the rules of the parameters, the request body and the responses of the documented route
+func (e *Engine) setRouteRules(route *Route) error {
+	doc := route.doc
+	var err error
+	// get rules of paramters
+	route.rules, err = getParameterRules(doc.GetParameters())
+	if err != nil {
+		return err
+	}
+	// get rule of the request body
+	bodyRule, err := newRequestBodyRule(doc.GetRequest())
+	if err != nil {
+		return err
+	}
+	if bodyRule != nil {
+		route.rules = append(route.rules, bodyRule)
+	}
+	// headers of the deprecated operation
+	route.deprecationHeaders = getDeprecationHeaders(route.operation)
+	// rule of the responses (see Config.ValidateResponses and Config.StrictResponses),
+	// or the write only fields are removed from the responses
+	responses, err := newResponsesRule(doc)
+	if e.Conf.ValidateResponses || e.Conf.StrictResponses {
+		if err != nil {
+			return &engineError{route.ginPath, route.Method, err}
+		}
+		route.responses = responses
+	} else if err == nil && responses != nil && responses.writeOnly {
+		route.responses = responses
+	}
+	return nil
+}
+
+// Check check the request with the document, the headers of CORS and the deprecated operation are set to the response.
+// The error is passed to the handler, like the error of HandlerFunc.
+func (r *Route) Check(c Context) error {
+	if r.doc == nil {
+		return nil
+	}
+	setResponseHeaders(c, r.deprecationHeaders)
+	for _, rule := range r.rules {
+		if err := rule.Check(c); err != nil {
+			return err
+		}
+	}
+	if r.accessControlAllow != nil {
+		if err := r.accessControlAllow.cors(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serve check the request (see Route.Check), and serve it by the handler with the error of the check.
+// In the mock mode (Config.MockMode), the response synthesized from the document is written instead of calling the handler.
+// If Config.ValidateResponses or Config.StrictResponses is true, the response written by the handler is checked with the document.
+// The write only fields of the documented models are always removed from the JSON responses.
+// The Context and the ResponseWriter are of the same request, the handler must write the response to the ResponseWriter
+// passed to it. It is used by the adapters of the other routers (see package httpadapter), gin uses it too.
+func (r *Route) Serve(c Context, w http.ResponseWriter, handler func(w http.ResponseWriter, err error)) {
+	err := r.Check(c)
+	e := r.engine
+	if e.Conf.MockMode && r.operation != nil {
+		handler = func(w http.ResponseWriter, err error) {
+			e.writeMockResponse(w, c.Request(), r.operation, err)
+		}
+	}
+	if r.responses == nil {
+		handler(w, err)
+		return
+	}
+	recorder := &responseRecorder{ResponseWriter: w, buffered: e.Conf.StrictResponses || r.responses.writeOnly, status: http.StatusOK}
+	handler(recorder, err)
+	e.checkRecordedResponse(c, r, w, recorder)
+}
+
+// CheckPreflight check the CORS preflight request (OPTIONS) of the path, and set the CORS headers to the response.
+// The path is relative to the BasePath, in the swagger style or the gin style (like: /books/{id}, /books/:id),
+// it is used by the adapters of the other routers (see package httpadapter), gin is set up by Engine.Run.
+func (e *Engine) CheckPreflight(c Context, relativePath string) error {
+	swaggerPath, err := ginPathToSwaggerPath(relativePath)
+	if err != nil {
+		return err
+	}
+	path, err := swaggerPathToGinPath(swaggerPath)
+	if err != nil {
+		return err
+	}
+	cors, ok := e.pathCorsInfos[path]
+	if !ok {
+		return errors.New("the path " + relativePath + " has no CORS")
+	}
+	return cors.OPTIONS().toAccessControlAllow().cors(c)
+}
@@ -0,0 +1,107 @@
+package ehttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testRouteContext the Context of a router which is not gin
+type testRouteContext struct {
+	request *http.Request
+	params  map[string]string
+	header  http.Header
+}
+
+func (c *testRouteContext) Request() *http.Request {
+	return c.request
+}
+
+func (c *testRouteContext) Param(name string) string {
+	return c.params[name]
+}
+
+func (c *testRouteContext) ResponseHeader() http.Header {
+	return c.header
+}
+
+func newTestRouteContext(url string, params map[string]string) *testRouteContext {
+	request := httptest.NewRequest(http.MethodGet, url, nil)
+	request.Header.Set("Origin", "http://a.com")
+	return &testRouteContext{request: request, params: params, header: http.Header{}}
+}
+
+func TestEngineNewRoute(t *testing.T) {
+	router := NewEngine(&Config{AllowOrigin: true, Origins: []string{"http://a.com"}})
+	doc := &APIDocCommon{
+		Deprecated: true,
+		Parameters: map[string]Parameter{
+			"id":    Parameter{InPath: &ValueInfo{Type: "int64"}},
+			"limit": Parameter{InQuery: &ValueInfo{Type: "int32", Min: "1"}},
+		},
+		Responses: map[int]Response{200: Response{Description: "ok"}},
+	}
+	route, err := router.NewRoute(GET, "/books/:id", doc, TestEngineNewRoute)
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if route.Path != "/books/{id}" || router.Swagger.Paths["/books/{id}"].Get.OperationID != "TestEngineNewRoute" {
+		testError(t, "the route should be documented", route.Path)
+	}
+	c := newTestRouteContext("/books/1?limit=2", map[string]string{"id": "1"})
+	if err := route.Check(c); err != nil {
+		testError(t, err)
+	}
+	if c.header.Get("Access-Control-Allow-Origin") != "http://a.com" || c.header.Get("Deprecation") != "true" {
+		testError(t, "the headers of CORS and the deprecated operation should be set", c.header)
+	}
+	if err := route.Check(newTestRouteContext("/books/abc", map[string]string{"id": "abc"})); err == nil {
+		testError(t, "the invalid parameter in path should be an error")
+	}
+	if err := route.Check(newTestRouteContext("/books/1?limit=0", map[string]string{"id": "1"})); err == nil {
+		testError(t, "the invalid parameter in query should be an error")
+	}
+	c = newTestRouteContext("/books/1", nil)
+	if err := router.CheckPreflight(c, "/books/{id}"); err != nil || c.header.Get("Access-Control-Allow-Methods") != GET {
+		testError(t, "the preflight request should be allowed", err, c.header)
+	}
+	if err := router.CheckPreflight(c, "/authors"); err == nil {
+		testError(t, "the path without CORS should be an error")
+	}
+	if _, err := router.NewRoute(GET, "/authors", nil, nil); err == nil {
+		testError(t, "the route without the handler should be an error")
+	}
+}
+
+func TestEngineRegisterRoute(t *testing.T) {
+	router := NewEngine(&Config{AllowOrigin: true})
+	doc := &APIDocCommon{
+		Parameters: map[string]Parameter{"id": Parameter{InPath: &ValueInfo{Type: "int64"}}},
+		Responses:  map[int]Response{200: Response{Description: "ok", Model: &Book{}}},
+	}
+	// the router rejects the route, the operation is not documented
+	_, err := router.RegisterRoute(GET, "/books/{id}", doc, TestEngineRegisterRoute, func(route *Route) error {
+		return errors.New("the pattern is rejected")
+	})
+	if err == nil {
+		testError(t, "the error of register should be returned")
+	}
+	if len(router.Swagger.Paths) != 0 || len(router.Swagger.Definitions) != 0 || router.CheckPreflight(newTestRouteContext("/books/1", nil), "/books/{id}") == nil {
+		testError(t, "the rejected route should not be documented", router.Swagger.Paths, router.Swagger.Definitions)
+	}
+	// the operationId is not claimed by the rejected route
+	var registered *Route
+	route, err := router.RegisterRoute(GET, "/authors/{id}", doc, TestEngineRegisterRoute, func(route *Route) error {
+		registered = route
+		return nil
+	})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if registered != route || router.Swagger.Paths["/authors/{id}"].Get.OperationID != "TestEngineRegisterRoute" {
+		testError(t, "the route should be registered and documented", router.Swagger.Paths)
+	}
+}
@@ -0,0 +1,56 @@
+package swagger
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ParseDocument parse a swagger 2.0 JSON document.
+// The properties of the schemas are required by the properties (Propertie.Required),
+// so the lists of the required properties (like: "required": ["id", "name"]) are moved to the properties.
+func ParseDocument(data []byte) (*Swagger, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(moveRequiredToProperties(value))
+	if err != nil {
+		return nil, err
+	}
+	doc := &Swagger{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if doc.SwaggerVersion != "2.0" {
+		return nil, errors.New("it is not a swagger 2.0 document")
+	}
+	return doc, nil
+}
+
+// moveRequiredToProperties move the lists of the required properties to the properties, the examples are not changed
+func moveRequiredToProperties(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if key == "example" || key == "examples" || key == "x-examples" {
+				continue
+			}
+			value[key] = moveRequiredToProperties(v)
+		}
+		if required, ok := value["required"].([]interface{}); ok {
+			properties, _ := value["properties"].(map[string]interface{})
+			for _, name := range required {
+				name, _ := name.(string)
+				if propertie, ok := properties[name].(map[string]interface{}); ok {
+					propertie["required"] = true
+				}
+			}
+			delete(value, "required")
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = moveRequiredToProperties(v)
+		}
+	}
+	return value
+}
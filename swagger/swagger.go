@@ -69,6 +69,7 @@ type Operation struct {
 	Schemes      []string              `json:"schemes,omitempty" yaml:"schemes,omitempty"`
 	Deprecated   bool                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	Security     []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Sunset       string                `json:"x-sunset,omitempty" yaml:"x-sunset,omitempty"` // the HTTP-date when the deprecated operation will become unresponsive
 }
 
 // ExternalDocs Allows referencing an external resource for extended documentation.
@@ -95,6 +96,10 @@ type Parameter struct {
 	MinLength   *int64        `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	MaxLength   *int64        `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 	Ref         string        `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	// Swagger 2.0 has no parameter examples, use the vendor extensions.
+	// Example is the example of a non-body parameter, Examples is the examples of the body parameter (MIME type -> example)
+	Example  interface{}            `json:"x-example,omitempty" yaml:"x-example,omitempty"`
+	Examples map[string]interface{} `json:"x-examples,omitempty" yaml:"x-examples,omitempty"`
 }
 
 // Schema The Schema Object allows the definition of input and output data types.
@@ -111,6 +116,15 @@ type Schema struct {
 	Items       *Schema               `json:"items,omitempty" yaml:"items,omitempty"`
 	Properties  map[string]*Propertie `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Enum        []interface{}         `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Discriminator adds support for polymorphism. The value is the name of the property used to differentiate between other schemas that inherit this schema (by AllOf).
+	Discriminator string    `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	AllOf         []*Schema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	// Swagger 2.0 has no oneOf, anyOf and discriminator mapping, use the vendor extensions
+	OneOf                []*Schema         `json:"x-oneOf,omitempty" yaml:"x-oneOf,omitempty"`
+	AnyOf                []*Schema         `json:"x-anyOf,omitempty" yaml:"x-anyOf,omitempty"`
+	DiscriminatorMapping map[string]string `json:"x-discriminator-mapping,omitempty" yaml:"x-discriminator-mapping,omitempty"`
+	DiscriminatorValue   string            `json:"x-discriminator-value,omitempty" yaml:"x-discriminator-value,omitempty"`
+	XML                  *XML              `json:"xml,omitempty" yaml:"xml,omitempty"`
 }
 
 // Propertie properties are taken from the JSON Schema definition but their definitions were adjusted to the Swagger Specification.
@@ -121,10 +135,12 @@ type Propertie struct {
 	Description          string                `json:"description,omitempty" yaml:"description,omitempty"`
 	Default              interface{}           `json:"default,omitempty" yaml:"default,omitempty"`
 	Type                 string                `json:"type,omitempty" yaml:"type,omitempty"`
-	Example              string                `json:"example,omitempty" yaml:"example,omitempty"`
+	Example              interface{}           `json:"example,omitempty" yaml:"example,omitempty"`
 	Required             bool                  `json:"required,omitempty" yaml:"required,omitempty"`
 	Format               string                `json:"format,omitempty" yaml:"format,omitempty"`
 	ReadOnly             bool                  `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+	WriteOnly            bool                  `json:"x-writeOnly,omitempty" yaml:"x-writeOnly,omitempty"` // Swagger 2.0 has no writeOnly, use the vendor extension
+	Nullable             bool                  `json:"x-nullable,omitempty" yaml:"x-nullable,omitempty"`   // Swagger 2.0 has no nullable, use the vendor extension
 	Properties           map[string]*Propertie `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Items                *Propertie            `json:"items,omitempty" yaml:"items,omitempty"`
 	AdditionalProperties *Propertie            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
@@ -133,6 +149,18 @@ type Propertie struct {
 	Maximum              *float64              `json:"maximum,omitempty" yaml:"maximum,omitempty"`
 	MinLength            *int64                `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	MaxLength            *int64                `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	AllOf                []*Propertie          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	XML                  *XML                  `json:"xml,omitempty" yaml:"xml,omitempty"`
+}
+
+// XML a metadata object that allows for more fine-tuned XML model definitions.
+type XML struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty" yaml:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty" yaml:"wrapped,omitempty"`
+	Text      bool   `json:"x-text,omitempty" yaml:"x-text,omitempty"` // Swagger 2.0 has no text (character data), use the vendor extension
 }
 
 // Items A limited subset of JSON-Schema's items object. It is used by parameter definitions that are not located in "body".
@@ -146,10 +174,11 @@ type Items struct {
 
 // Response A container for the expected responses of an operation.
 type Response struct {
-	Description string             `json:"description" yaml:"description"`
-	Schema      *Schema            `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Headers     map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                 `json:"description" yaml:"description"`
+	Schema      *Schema                `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Headers     map[string]*Header     `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Examples    map[string]interface{} `json:"examples,omitempty" yaml:"examples,omitempty"` // MIME type -> example
+	Ref         string                 `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 }
 
 // Header describes the type of the header.
@@ -164,6 +193,7 @@ type Header struct {
 	Maximum     *float64      `json:"maximum,omitempty" yaml:"maximum,omitempty"`
 	MinLength   *int64        `json:"minLength,omitempty" yaml:"minLength,omitempty"`
 	MaxLength   *int64        `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Example     interface{}   `json:"x-example,omitempty" yaml:"x-example,omitempty"` // Swagger 2.0 has no header example, use the vendor extension
 }
 
 // Security Allows the definition of a security scheme that can be used by the operations
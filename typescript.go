@@ -0,0 +1,479 @@
+package ehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GenerateTypeScript generate the TypeScript interfaces of the models and a typed fetch client of the registered APIs.
+// The models are from the StructDocs of the Request and Response Models:
+//   the enums are union types (like: "all" | "brief"), the registered interfaces are the unions of the struct types,
+//   the fields with the tag req:"true" and the fields without omitempty (Go always encodes them) are required,
+//   the omitempty, readonly and writeonly fields are optional, and the readonly fields are readonly.
+// Every operation is an async method of the Client named from the operationId (like: GetBook -> getBook),
+// the parameters are the properties of the first argument (<OperationId>Params), the body is the last argument,
+// and a response of which the status code is not 2xx is thrown as ApiError.
+func (e *Engine) GenerateTypeScript() (string, error) {
+	structDocs, err := e.getStructDocs()
+	if err != nil {
+		return "", err
+	}
+	g := &typeScriptGenerator{structDocs: structDocs}
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by ehttp. DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "/** the base path of the APIs, the urls of the requests are baseURL + basePath + the path of the API */\nexport const basePath = %s;\n", toTypeScriptString(e.Conf.BasePath))
+	g.writeModels(b)
+	b.WriteString(typeScriptClientRuntime)
+	methods := &bytes.Buffer{}
+	for _, api := range e.getSortedAPIs() {
+		if err := g.writeOperation(b, methods, e, api); err != nil {
+			return "", &engineError{api.path, api.method, err}
+		}
+	}
+	b.WriteString(typeScriptClientClass)
+	b.Write(methods.Bytes())
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// getStructDocs the StructDocs of the Request and Response Models of the registered APIs
+func (e *Engine) getStructDocs() (map[string]*StructDoc, error) {
+	creater := StructDocCreater{structDocsMap: map[string]*StructDoc{}}
+	for _, api := range e.apis {
+		models := []interface{}{}
+		if request := api.doc.GetRequest(); request != nil {
+			models = append(models, request.Model)
+		}
+		for _, response := range api.getResponses() {
+			models = append(models, response.Model)
+		}
+		for _, model := range models {
+			if model == nil {
+				continue
+			}
+			t := getModelElemType(reflect.TypeOf(model))
+			if t.Kind() != reflect.Struct && !isPolymorphicType(t) {
+				continue
+			}
+			if _, err := creater.GetStructDocMap(reflect.New(t).Interface()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return creater.structDocsMap, nil
+}
+
+// getModelElemType the type without the pointers, the slices and the maps (like: []*Book -> Book)
+func getModelElemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// typeScriptClientRuntime the error and the options of the client
+const typeScriptClientRuntime = `
+/** the response of which the status code is not 2xx, the body is the decoded JSON (or the text if it is not JSON) */
+export class ApiError extends Error {
+  constructor(public status: number, public body: unknown) {
+    super("status code " + status);
+  }
+}
+
+/**
+ * the options of the client
+ * baseURL -- the scheme and the host of the server, like "https://api.example.com", default is "" (the same origin)
+ * headers -- the headers of all the requests (like: Authorization)
+ * fetch -- the fetch function, default is the global fetch
+ */
+export interface ClientOptions {
+  baseURL?: string;
+  headers?: Record<string, string>;
+  fetch?: typeof fetch;
+}
+`
+
+// typeScriptClientClass the beginning of the class Client, the methods of the operations follow it
+const typeScriptClientClass = `
+/** the client of the APIs */
+export class Client {
+  private baseURL: string;
+  private headers: Record<string, string>;
+  private fetchFunction: typeof fetch;
+
+  constructor(options: ClientOptions = {}) {
+    this.baseURL = (options.baseURL || "").replace(/\/$/, "");
+    this.headers = options.headers || {};
+    this.fetchFunction = options.fetch || ((input, init) => fetch(input, init));
+  }
+
+  private async request(method: string, path: string, query: Record<string, unknown>, headers: Record<string, unknown>,
+    body?: BodyInit, contentType?: string): Promise<unknown> {
+    const search = new URLSearchParams();
+    for (const key of Object.keys(query)) {
+      if (query[key] !== undefined && query[key] !== null) {
+        search.set(key, String(query[key]));
+      }
+    }
+    let url = this.baseURL + basePath + path;
+    if (search.toString() !== "") {
+      url += "?" + search.toString();
+    }
+    const requestHeaders: Record<string, string> = { Accept: "application/json", ...this.headers };
+    for (const key of Object.keys(headers)) {
+      if (headers[key] !== undefined && headers[key] !== null) {
+        requestHeaders[key] = String(headers[key]);
+      }
+    }
+    if (contentType) {
+      requestHeaders["Content-Type"] = contentType;
+    }
+    const response = await this.fetchFunction(url, { method, headers: requestHeaders, body });
+    const text = await response.text();
+    let data: unknown = undefined;
+    if (text !== "") {
+      try {
+        data = JSON.parse(text);
+      } catch (e) {
+        data = text;
+      }
+    }
+    if (!response.ok) {
+      throw new ApiError(response.status, data);
+    }
+    return data;
+  }
+`
+
+type typeScriptGenerator struct {
+	structDocs map[string]*StructDoc
+}
+
+func (g *typeScriptGenerator) writeModels(b *bytes.Buffer) {
+	docs := []*StructDoc{}
+	for _, doc := range g.structDocs {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].StructName < docs[j].StructName })
+	// the discriminator values of the struct types (like: EventCreated -> type: "created")
+	discriminators := map[string]map[string]string{}
+	for _, doc := range docs {
+		for value, structUUID := range doc.DiscriminatorMapping {
+			if discriminators[structUUID] == nil {
+				discriminators[structUUID] = map[string]string{}
+			}
+			discriminators[structUUID][doc.Discriminator] = value
+		}
+	}
+	for _, doc := range docs {
+		b.WriteString("\n")
+		writeTypeScriptComment(b, "", doc.Description)
+		if doc.IsPolymorphic() {
+			types := []string{}
+			for _, structUUID := range append(append([]string{}, doc.OneOf...), doc.AnyOf...) {
+				types = append(types, g.getStructName(structUUID))
+			}
+			fmt.Fprintf(b, "export type %s = %s;\n", doc.StructName, strings.Join(types, " | "))
+			continue
+		}
+		fmt.Fprintf(b, "export interface %s {\n", doc.StructName)
+		for _, field := range doc.StructFields {
+			description := field.Description
+			if field.WriteOnly {
+				description = strings.TrimSpace(description + " (write only)")
+			}
+			writeTypeScriptComment(b, "  ", description)
+			typ := g.getFieldType(field)
+			if value, ok := discriminators[doc.UUID][field.Name]; ok {
+				typ = toTypeScriptString(value)
+			}
+			modifier := ""
+			if field.ReadOnly {
+				modifier = "readonly "
+			}
+			optional := ""
+			if !field.Required && (field.Omitempty || field.ReadOnly || field.WriteOnly) {
+				optional = "?"
+			}
+			fmt.Fprintf(b, "  %s%s%s: %s;\n", modifier, toTypeScriptPropertyName(field.Name), optional, typ)
+		}
+		b.WriteString("}\n")
+	}
+}
+
+func (g *typeScriptGenerator) getStructName(structUUID string) string {
+	if doc, ok := g.structDocs[structUUID]; ok {
+		return doc.StructName
+	}
+	return "unknown"
+}
+
+func (g *typeScriptGenerator) getFieldType(field *StructField) string {
+	typ := ""
+	switch {
+	case field.IsStruct:
+		typ = g.getStructName(field.RefStructUUID)
+	case len(field.Enum) > 0:
+		typ = toTypeScriptUnion(field.Enum)
+	default:
+		typ = toTypeScriptValueType(field.ValueType)
+	}
+	if field.IsArray {
+		typ = toTypeScriptArray(typ)
+	}
+	if field.Nullable {
+		typ += " | null"
+	}
+	return typ
+}
+
+// getModelType the TypeScript type of a Model (like: Book[], Record<string, number>)
+func (g *typeScriptGenerator) getModelType(t reflect.Type) string {
+	if t.Kind() == reflect.Struct || isPolymorphicType(t) {
+		if name, err := getStructName(t); err == nil {
+			return name
+		}
+		return "unknown"
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.getModelType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string
+			return "string"
+		}
+		return toTypeScriptArray(g.getModelType(t.Elem()))
+	case reflect.Map:
+		return "Record<string, " + g.getModelType(t.Elem()) + ">"
+	}
+	if valueType, ok := valueTypes[t.Kind()]; ok {
+		return toTypeScriptValueType(valueType)
+	}
+	return "unknown"
+}
+
+func (g *typeScriptGenerator) writeOperation(b *bytes.Buffer, methods *bytes.Buffer, e *Engine, api *registeredAPI) error {
+	parameters, err := e.getAPIParameters(api)
+	if err != nil {
+		return err
+	}
+	name := toGoName(api.operation.OperationID)
+	methodName := strings.ToLower(name[:1]) + name[1:]
+	paramsType := name + "Params"
+
+	// the parameters interface
+	arguments := []string{}
+	if len(parameters) > 0 {
+		b.WriteString("\n")
+		writeTypeScriptComment(b, "", "the parameters of "+methodName)
+		fmt.Fprintf(b, "export interface %s {\n", paramsType)
+		allOptional := true
+		for _, p := range parameters {
+			writeTypeScriptComment(b, "  ", p.valueInfo.Desc)
+			optional := "?"
+			if p.isRequired() {
+				optional, allOptional = "", false
+			}
+			typ, err := getTypeScriptParameterType(p.valueInfo)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "  %s%s: %s;\n", toTypeScriptPropertyName(p.name), optional, typ)
+		}
+		b.WriteString("}\n")
+		if allOptional {
+			arguments = append(arguments, "params: "+paramsType+" = {}")
+		} else {
+			arguments = append(arguments, "params: "+paramsType)
+		}
+	}
+	if request := api.doc.GetRequest(); request != nil && request.Model != nil {
+		arguments = append(arguments, "body: "+g.getModelType(reflect.TypeOf(request.Model)))
+	}
+	resultType := "void"
+	errorTypes := []string{}
+	responses := api.getResponses()
+	for _, code := range getSortedResponseCodes(responses) {
+		model := responses[code].Model
+		switch {
+		case model == nil:
+		case code >= 200 && code < 300:
+			if resultType == "void" {
+				resultType = g.getModelType(reflect.TypeOf(model))
+			}
+		default:
+			errorTypes = append(errorTypes, fmt.Sprintf("%d %s", code, g.getModelType(reflect.TypeOf(model))))
+		}
+	}
+
+	// the method
+	summary := api.operation.Summary
+	if summary == "" {
+		summary = api.method + " " + api.path
+	} else {
+		summary += " (" + api.method + " " + api.path + ")"
+	}
+	comment := summary
+	if api.operation.Deprecated {
+		comment += "\n@deprecated"
+	}
+	if len(errorTypes) > 0 {
+		comment += "\n@throws ApiError the body is " + strings.Join(errorTypes, ", ")
+	}
+	methods.WriteString("\n")
+	writeTypeScriptComment(methods, "  ", comment)
+	fmt.Fprintf(methods, "  async %s(%s): Promise<%s> {\n", methodName, strings.Join(arguments, ", "), resultType)
+
+	byIn := map[string][]*apiParameter{}
+	for _, p := range parameters {
+		byIn[p.in] = append(byIn[p.in], p)
+	}
+	path := swaggerPathParameterRegexp.ReplaceAllStringFunc(api.path, func(match string) string {
+		name := match[1 : len(match)-1]
+		for _, p := range byIn[InPath] {
+			if p.name == name {
+				return "${encodeURIComponent(String(params" + toTypeScriptPropertyAccess(name) + "))}"
+			}
+		}
+		return match
+	})
+	path = "`" + strings.NewReplacer("`", "\\`").Replace(path) + "`"
+	getValues := func(parameters []*apiParameter) string {
+		values := []string{}
+		for _, p := range parameters {
+			values = append(values, toTypeScriptPropertyName(p.name)+": params"+toTypeScriptPropertyAccess(p.name))
+		}
+		if len(values) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(values, ", ") + " }"
+	}
+	requestArguments := []string{toTypeScriptString(api.method), path, getValues(byIn[InQuery]), getValues(byIn[InHeader])}
+	hasFile := false
+	for _, p := range byIn[InFormData] {
+		hasFile = hasFile || p.valueInfo.Type == "file"
+	}
+	switch {
+	case len(arguments) > 0 && strings.HasPrefix(arguments[len(arguments)-1], "body: "):
+		requestArguments = append(requestArguments, "JSON.stringify(body)", toTypeScriptString(Application_Json))
+	case len(byIn[InFormData]) > 0:
+		form := "new URLSearchParams()"
+		if hasFile {
+			form = "new FormData()"
+		}
+		fmt.Fprintf(methods, "    const form = %s;\n", form)
+		for _, p := range byIn[InFormData] {
+			value := "String(params" + toTypeScriptPropertyAccess(p.name) + ")"
+			if p.valueInfo.Type == "file" {
+				value = "params" + toTypeScriptPropertyAccess(p.name)
+			}
+			fmt.Fprintf(methods, "    if (params%s !== undefined) {\n      form.append(%s, %s);\n    }\n",
+				toTypeScriptPropertyAccess(p.name), toTypeScriptString(p.name), value)
+		}
+		if hasFile {
+			// the Content-Type with the boundary is set by fetch
+			requestArguments = append(requestArguments, "form")
+		} else {
+			requestArguments = append(requestArguments, "form.toString()", toTypeScriptString("application/x-www-form-urlencoded"))
+		}
+	}
+	if resultType == "void" {
+		fmt.Fprintf(methods, "    await this.request(%s);\n  }\n", strings.Join(requestArguments, ", "))
+	} else {
+		fmt.Fprintf(methods, "    return (await this.request(%s)) as %s;\n  }\n", strings.Join(requestArguments, ", "), resultType)
+	}
+	return nil
+}
+
+func getTypeScriptParameterType(valueInfo *ValueInfo) (string, error) {
+	if valueInfo.Type == "file" {
+		return "Blob", nil
+	}
+	enum, err := valueInfo.getEnum()
+	if err != nil {
+		return "", err
+	}
+	if len(enum) > 0 {
+		return toTypeScriptUnion(enum), nil
+	}
+	return toTypeScriptValueType(valueInfo.Type), nil
+}
+
+func toTypeScriptValueType(valueType string) string {
+	switch {
+	case isValueTypeNumber(valueType):
+		return "number"
+	case isValueTypeBool(valueType):
+		return "boolean"
+	case isValueTypeString(valueType):
+		return "string"
+	}
+	return "unknown"
+}
+
+// toTypeScriptUnion the union type of the literals (like: "all" | "brief")
+func toTypeScriptUnion(values []interface{}) string {
+	literals := []string{}
+	for _, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		literals = append(literals, string(data))
+	}
+	return strings.Join(literals, " | ")
+}
+
+func toTypeScriptArray(typ string) string {
+	if strings.Contains(typ, " ") {
+		return "(" + typ + ")[]"
+	}
+	return typ + "[]"
+}
+
+func toTypeScriptString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+var typeScriptIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// toTypeScriptPropertyName the name of the property, quoted if it is not an identifier (like: "X-Request-Id")
+func toTypeScriptPropertyName(name string) string {
+	if typeScriptIdentifierRegexp.MatchString(name) {
+		return name
+	}
+	return toTypeScriptString(name)
+}
+
+// toTypeScriptPropertyAccess like .id or ["X-Request-Id"]
+func toTypeScriptPropertyAccess(name string) string {
+	if typeScriptIdentifierRegexp.MatchString(name) {
+		return "." + name
+	}
+	return "[" + toTypeScriptString(name) + "]"
+}
+
+// writeTypeScriptComment write the JSDoc comment if the text is not empty
+func writeTypeScriptComment(b *bytes.Buffer, indent string, text string) {
+	text = strings.Replace(strings.TrimSpace(text), "*/", "*\\/", -1)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
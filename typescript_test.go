@@ -0,0 +1,81 @@
+package ehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEngine_GenerateTypeScript(t *testing.T) {
+	source, err := newTestDocumentEngine(t).GenerateTypeScript()
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	for _, expected := range []string{
+		`export const basePath = "/v1";`,
+		"export interface testDocumentBook {\n  /** the id */\n  readonly id?: string;\n  title: string;\n  note: string | null;\n}",
+		"export type testEvent = testEventCreated | testEventDeleted;",
+		`  type: "created";`,
+		"  events: testEvent[];",
+		`  fields?: "all" | "brief";`,
+		"  async testHandleOperation(params: TestHandleOperationParams): Promise<testDocumentBook> {",
+		"`/books/${encodeURIComponent(String(params.id))}`, { fields: params.fields }, {})",
+		"  async postBooks(body: testDocumentBook): Promise<testDocumentBook> {",
+		`JSON.stringify(body), "application/json"`,
+		"  async postCovers(params: PostCoversParams = {}): Promise<void> {\n    const form = new FormData();",
+	} {
+		if !strings.Contains(source, expected) {
+			testError(t, "the TypeScript should contain "+expected)
+		}
+	}
+
+	source, err = newTestGoClientEngine(t).GenerateTypeScript()
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	for _, expected := range []string{
+		`  "X-Request-Id": string;`,
+		`{ "X-Request-Id": params["X-Request-Id"] }`,
+		"   * @throws ApiError the body is 404 testGoClientError",
+		"   * @deprecated",
+		`form.toString(), "application/x-www-form-urlencoded"`,
+	} {
+		if !strings.Contains(source, expected) {
+			testError(t, "the TypeScript should contain "+expected)
+		}
+	}
+}
+
+func TestEngine_TypeScriptURL(t *testing.T) {
+	router := newTestDocumentEngine(t)
+	router.openAPIDocumentURL()
+	w := httptest.NewRecorder()
+	router.GinEngine().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/docs/client.ts", nil))
+	if w.Code != 200 || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/typescript") || !strings.Contains(w.Body.String(), "export class Client {") {
+		testError(t, "the TypeScript client should be served", w.Code)
+	}
+}
+
+func TestToTypeScriptType(t *testing.T) {
+	g := &typeScriptGenerator{}
+	for _, c := range []struct {
+		model    interface{}
+		expected string
+	}{
+		{[]string{}, "string[]"},
+		{map[string]int64{}, "Record<string, number>"},
+		{[]*testDocumentBook{}, "testDocumentBook[]"},
+		{[]byte{}, "string"},
+	} {
+		if typ := g.getModelType(reflect.TypeOf(c.model)); typ != c.expected {
+			testError(t, "the type should be "+c.expected+", got "+typ)
+		}
+	}
+	if typ := toTypeScriptArray(toTypeScriptUnion([]interface{}{"a", 1})); typ != `("a" | 1)[]` {
+		testError(t, "the array of the union should be parenthesized, got "+typ)
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"log"
 	"reflect"
 	"runtime"
+	"sort"
 
 	"github.com/enjoy-web/ehttp/swagger"
 )
 
+func logWarning(message string) {
+	log.Println("[ehttp-warning] " + message)
+}
+
 func nameOfFunction(f interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
 }
@@ -131,6 +137,10 @@ func newSwaggerParameter(name, in string, valueInfo *ValueInfo) (*swagger.Parame
 	if err != nil {
 		return nil, err
 	}
+	example, err := valueInfo.getExample()
+	if err != nil {
+		return nil, err
+	}
 
 	return &swagger.Parameter{
 		Name:        name,
@@ -145,13 +155,19 @@ func newSwaggerParameter(name, in string, valueInfo *ValueInfo) (*swagger.Parame
 		MinLength:   minLen,
 		MaxLength:   maxLen,
 		Default:     defaultValue,
+		Example:     example,
 	}, nil
 }
 
 func getDefinitionsFromStructDocMap(docMap map[string]*StructDoc) map[string]*swagger.Schema {
 	definitions := map[string]*swagger.Schema{}
 	for _, doc := range docMap {
-		definitions[doc.StructName] = &swagger.Schema{}
+		definitions[doc.StructName] = &swagger.Schema{Description: doc.Description, XML: doc.XML.toSwaggerXML()}
+		if doc.IsPolymorphic() {
+			definitions[doc.StructName] = getPolymorphicSchemaFromStructDoc(doc, docMap)
+			continue
+		}
+		setAllOfFromStructDoc(definitions[doc.StructName], doc, docMap)
 		if len(doc.StructFields) == 0 {
 			continue
 		}
@@ -176,23 +192,99 @@ func getDefinitionsFromStructDocMap(docMap map[string]*StructDoc) map[string]*sw
 					MaxLength:   field.MaxLen,
 					Default:     field.Default,
 				}
+				if !field.IsArray {
+					propertie.Example = field.Example
+				}
 			}
+			propertie = setXMLOfPropertie(propertie, field.XML.toSwaggerXML())
 			if field.IsArray {
 				propertie.Description = ""
-				properties[field.Name] = &swagger.Propertie{
+				propertie = &swagger.Propertie{
 					Description: field.Description,
 					Type:        "array",
 					Items:       propertie,
+					Example:     field.Example,
+					XML:         field.XML.toSwaggerWrapperXML(),
 				}
-			} else {
-				properties[field.Name] = propertie
 			}
+			propertie.ReadOnly = field.ReadOnly
+			propertie.WriteOnly = field.WriteOnly
+			propertie.Nullable = field.Nullable
+			properties[field.Name] = propertie
 		}
 		definitions[doc.StructName].Properties = properties
 	}
 	return definitions
 }
 
+// setXMLOfPropertie set the XML object of the propertie.
+// The siblings of $ref are ignored, so a reference propertie with the XML object is wrapped by allOf.
+func setXMLOfPropertie(propertie *swagger.Propertie, xml *swagger.XML) *swagger.Propertie {
+	if xml == nil {
+		return propertie
+	}
+	if propertie.Ref != "" {
+		return &swagger.Propertie{AllOf: []*swagger.Propertie{propertie}, XML: xml}
+	}
+	propertie.XML = xml
+	return propertie
+}
+
+// getPolymorphicSchemaFromStructDoc the schema of a registered interface.
+// It has the discriminator property, and refers to the struct types by x-oneOf (or x-anyOf).
+func getPolymorphicSchemaFromStructDoc(doc *StructDoc, docMap map[string]*StructDoc) *swagger.Schema {
+	schema := &swagger.Schema{Type: "object", Description: doc.Description}
+	for _, structUUID := range doc.OneOf {
+		schema.OneOf = append(schema.OneOf, &swagger.Schema{Ref: _definitions + docMap[structUUID].StructName})
+	}
+	for _, structUUID := range doc.AnyOf {
+		schema.AnyOf = append(schema.AnyOf, &swagger.Schema{Ref: _definitions + docMap[structUUID].StructName})
+	}
+	if doc.Discriminator == "" {
+		return schema
+	}
+	schema.Discriminator = doc.Discriminator
+	schema.DiscriminatorMapping = map[string]string{}
+	enum := []interface{}{}
+	for _, value := range getSortedKeys(doc.DiscriminatorMapping) {
+		schema.DiscriminatorMapping[value] = _definitions + docMap[doc.DiscriminatorMapping[value]].StructName
+		enum = append(enum, value)
+	}
+	schema.Properties = map[string]*swagger.Propertie{
+		doc.Discriminator: &swagger.Propertie{
+			Type:     "string",
+			Enum:     enum,
+			Required: true,
+		},
+	}
+	return schema
+}
+
+// setAllOfFromStructDoc the struct type of registered interfaces inherits the schemas of the interfaces
+func setAllOfFromStructDoc(schema *swagger.Schema, doc *StructDoc, docMap map[string]*StructDoc) {
+	for _, polymorphicUUID := range doc.AllOf {
+		polymorphicDoc, ok := docMap[polymorphicUUID]
+		if !ok {
+			continue
+		}
+		schema.AllOf = append(schema.AllOf, &swagger.Schema{Ref: _definitions + polymorphicDoc.StructName})
+		for value, structUUID := range polymorphicDoc.DiscriminatorMapping {
+			if structUUID == doc.UUID {
+				schema.DiscriminatorValue = value
+			}
+		}
+	}
+}
+
+func getSortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func ginPathToSwaggerPath(path string) (string, error) {
 	b := bytes.Buffer{}
 	flag := false
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/enjoy-web/ehttp/swagger"
 )
@@ -18,6 +19,7 @@ import (
 //     Max  -- Maximum of the value.
 //             (Only supports the following types: int, int32, int64, uint,  uint32, uint64, float32, float64)
 //     Desc -- Description of the value
+//     Example -- Example of the value, it must match the Type, Enum, Min, Max, MinLen and MaxLen
 type ValueInfo struct {
 	Type     string
 	Enum     string
@@ -28,6 +30,7 @@ type ValueInfo struct {
 	MaxLen   string
 	Default  string
 	Required bool
+	Example  string
 }
 
 func (v ValueInfo) checkWithHTTPIn(in string) error {
@@ -61,6 +64,11 @@ func (v ValueInfo) check() error {
 			return err
 		}
 	}
+	if v.hasExample() {
+		if err := v.checkExample(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -76,6 +84,10 @@ func (v ValueInfo) hasMax() bool {
 	return v.Max != ""
 }
 
+func (v ValueInfo) hasExample() bool {
+	return v.Example != ""
+}
+
 func (v ValueInfo) checkValuetype() error {
 	switch v.Type {
 	case "string":
@@ -105,6 +117,55 @@ func (v ValueInfo) checkEnum() error {
 	return checkEnumFormat(v.Enum, v.Type)
 }
 
+func (v ValueInfo) checkExample() error {
+	example, err := v.getExample()
+	if err != nil {
+		return errors.New("invalid Example (" + v.Example + "), " + err.Error())
+	}
+	if v.hasEnum() {
+		found := false
+		for _, str := range strings.Fields(v.Enum) {
+			value, err := parseValueByValueType(str, v.Type)
+			if err == nil && value == example {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("the Example (" + v.Example + ") is not in the Enum (" + v.Enum + ")")
+		}
+	}
+	if v.isNumber() {
+		num, err := strconv.ParseFloat(v.Example, 64)
+		if err != nil {
+			return err
+		}
+		if min, err := v.getMinimum(); err == nil && min != nil && num < *min {
+			return errors.New("the Example (" + v.Example + ") is less than the Min (" + v.Min + ")")
+		}
+		if max, err := v.getMaximum(); err == nil && max != nil && num > *max {
+			return errors.New("the Example (" + v.Example + ") is greater than the Max (" + v.Max + ")")
+		}
+	}
+	if v.isString() {
+		length := int64(utf8.RuneCountInString(v.Example))
+		if minLen, err := v.getMinLen(); err == nil && minLen != nil && length < *minLen {
+			return errors.New("the length of the Example (" + v.Example + ") is less than the MinLen (" + v.MinLen + ")")
+		}
+		if maxLen, err := v.getMaxLen(); err == nil && maxLen != nil && length > *maxLen {
+			return errors.New("the length of the Example (" + v.Example + ") is greater than the MaxLen (" + v.MaxLen + ")")
+		}
+	}
+	return nil
+}
+
+func (v ValueInfo) getExample() (interface{}, error) {
+	if v.Example == "" {
+		return nil, nil
+	}
+	return parseValueByValueType(v.Example, v.Type)
+}
+
 func (v ValueInfo) checkMinimum() error {
 	if !v.isNumber() {
 		return errors.New("the paramter value type is " + v.Type + ",  can't set Min and Min")
@@ -264,6 +325,11 @@ func (v ValueInfo) toSwaggerHeader() (*swagger.Header, error) {
 	if v.Type == "file" {
 		return nil, errors.New("type file is not supported")
 	}
+	if v.hasExample() {
+		if err := v.checkExample(); err != nil {
+			return nil, err
+		}
+	}
 	dataType, ok := dataTypes[v.Type]
 	if !ok {
 		return nil, errors.New("type " + v.Type + " is not supported")
@@ -295,6 +361,10 @@ func (v ValueInfo) toSwaggerHeader() (*swagger.Header, error) {
 	if err != nil {
 		return nil, err
 	}
+	example, err := v.getExample()
+	if err != nil {
+		return nil, err
+	}
 	return &swagger.Header{
 		Description: v.Desc,
 		Type:        dataType.typeName,
@@ -305,6 +375,7 @@ func (v ValueInfo) toSwaggerHeader() (*swagger.Header, error) {
 		MinLength:   minLen,
 		MaxLength:   maxLen,
 		Default:     defalutValue,
+		Example:     example,
 	}, nil
 }
 
@@ -224,3 +224,45 @@ func TestValueInfo_toSwaggerHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestValueInfo_checkExample(t *testing.T) {
+	validValues := []*ValueInfo{
+		&ValueInfo{Type: "string", Example: "abc"},
+		&ValueInfo{Type: "string", Enum: "TYPE1 TYPE2", Example: "TYPE2"},
+		&ValueInfo{Type: "string", MinLen: "2", MaxLen: "4", Example: "abc"},
+		&ValueInfo{Type: "int", Min: "1", Max: "10", Example: "5"},
+		&ValueInfo{Type: "uint64", Enum: "1 2 3", Example: "3"},
+		&ValueInfo{Type: "float64", Max: "9.9", Example: "1.5"},
+		&ValueInfo{Type: "bool", Example: "true"},
+	}
+	for index, v := range validValues {
+		if err := v.check(); err != nil {
+			testError(t, index, err)
+		}
+	}
+	invalidValues := []*ValueInfo{
+		&ValueInfo{Type: "string", Enum: "TYPE1 TYPE2", Example: "TYPE3"},
+		&ValueInfo{Type: "string", MaxLen: "2", Example: "abc"},
+		&ValueInfo{Type: "int", Min: "1", Max: "10", Example: "11"},
+		&ValueInfo{Type: "int", Example: "abc"},
+		&ValueInfo{Type: "bool", Example: "yes"},
+		&ValueInfo{Type: "file", Example: "abc"},
+	}
+	for index, v := range invalidValues {
+		if err := v.check(); err != nil {
+			testLog(t, index, err)
+		} else {
+			testError(t, index, "err should not be nil")
+		}
+	}
+
+	header, err := ValueInfo{Type: "int32", Example: "100"}.toSwaggerHeader()
+	if err != nil {
+		testError(t, err)
+	} else if header.Example != int64(100) {
+		testError(t, "header.Example should be 100")
+	}
+	if _, err := (ValueInfo{Type: "int32", Max: "10", Example: "100"}).toSwaggerHeader(); err == nil {
+		testError(t, "err should not be nil")
+	}
+}
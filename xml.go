@@ -0,0 +1,161 @@
+package ehttp
+
+import (
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/enjoy-web/ehttp/swagger"
+)
+
+var xmlNameType = reflect.TypeOf(xml.Name{})
+
+// XMLInfo the XML representation of a struct or a struct field, from the xml tag (see encoding/xml).
+// Fields:
+//   Name -- the element (or attribute) name. For an array, it is the name of the items (like: Tags []string `xml:"tags>tag"`, Name = "tag")
+//   Namespace -- the namespace of the element (like: Title string `xml:"http://example.com/book title"`, Namespace = "http://example.com/book")
+//   Prefix -- the prefix of the namespace, from the tag xmlprefix (like: Title string `xml:"http://example.com/book title" xmlprefix:"bk"`)
+//   Attribute -- the field is an attribute (like: ID string `xml:"id,attr"`)
+//   Text -- the field is the character data of the element (like: Value string `xml:",chardata"`)
+//   Wrapper -- the name of the element which wraps the items of an array (like: Tags []string `xml:"tags>tag"`, Wrapper = "tags")
+type XMLInfo struct {
+	Name      string
+	Namespace string
+	Prefix    string
+	Attribute bool
+	Text      bool
+	Wrapper   string
+}
+
+// IsWrapped check if the items of the array are wrapped by an element
+func (info *XMLInfo) IsWrapped() bool {
+	return info.Wrapper != ""
+}
+
+// isXMLNameField check if the field is the XMLName field (like: XMLName xml.Name `xml:"book"`).
+// The XMLName field names the element of the struct, it is not a property.
+func isXMLNameField(field reflect.StructField) bool {
+	return field.Name == "XMLName" && field.Type == xmlNameType
+}
+
+// parseXMLTag split the xml tag to the namespace, the name (like: a>b) and the options
+func parseXMLTag(xmlTag string) (namespace string, name string, options []string) {
+	strs := strings.Split(xmlTag, ",")
+	name = strs[0]
+	options = strs[1:]
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	return namespace, name, options
+}
+
+func hasXMLTagOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// getStructXML get the XMLInfo of a struct from the tag of the XMLName field, return nil if the struct has no XMLName field with a tag.
+func getStructXML(structType reflect.Type) (*XMLInfo, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	field, ok := structType.FieldByName("XMLName")
+	if !ok || !isXMLNameField(field) {
+		return nil, nil
+	}
+	xmlTag := field.Tag.Get("xml")
+	if xmlTag == "" {
+		return nil, nil
+	}
+	namespace, name, options := parseXMLTag(xmlTag)
+	if strings.Contains(name, ">") || len(options) > 0 {
+		return nil, errors.New("invalid xml tag of XMLName in " + structType.String() + ": " + xmlTag)
+	}
+	info := &XMLInfo{Name: name, Namespace: namespace}
+	if err := setXMLPrefix(info, field); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// getStructFieldXML get the XMLInfo of the struct field, return nil if the XML representation is same as the property.
+//   The fieldName is the name of the property (see getStructFieldName).
+func getStructFieldXML(field reflect.StructField, fieldName string) (*XMLInfo, error) {
+	xmlTag, ok := field.Tag.Lookup("xml")
+	if !ok || xmlTag == "-" {
+		return nil, nil
+	}
+	namespace, name, options := parseXMLTag(xmlTag)
+	info := &XMLInfo{
+		Namespace: namespace,
+		Attribute: hasXMLTagOption(options, "attr"),
+		Text:      hasXMLTagOption(options, "chardata"),
+	}
+	if info.Attribute && info.Text {
+		return nil, errors.New("xml tag options attr and chardata cann't both be set")
+	}
+	isArray := checkStructFieldTypeIsSlice(field)
+	isStruct := checkStructFieldTypeIsStruct(field)
+	if (info.Attribute || info.Text) && (isArray || isStruct) {
+		return nil, errors.New("the xml attr or chardata field must not be an array or a struct")
+	}
+	if path := strings.Split(name, ">"); len(path) > 1 {
+		if !isArray || len(path) != 2 || path[0] == "" || path[1] == "" {
+			return nil, errors.New("invalid xml path " + name + ", only the array items can be wrapped by one element (like: xml:\"tags>tag\")")
+		}
+		info.Wrapper, name = path[0], path[1]
+	}
+	if name == "" {
+		name = field.Name
+	}
+	if !info.Text {
+		info.Name = name
+	}
+	if err := setXMLPrefix(info, field); err != nil {
+		return nil, err
+	}
+	if info.Name == fieldName && *info == (XMLInfo{Name: fieldName}) {
+		return nil, nil
+	}
+	return info, nil
+}
+
+// set the prefix of the namespace from the tag xmlprefix
+func setXMLPrefix(info *XMLInfo, field reflect.StructField) error {
+	prefix, ok := field.Tag.Lookup("xmlprefix")
+	if !ok {
+		return nil
+	}
+	if info.Namespace == "" {
+		return errors.New("the tag xmlprefix must be used with a xml namespace")
+	}
+	info.Prefix = prefix
+	return nil
+}
+
+// toSwaggerXML the swagger XML object of an element (or an attribute).
+func (info *XMLInfo) toSwaggerXML() *swagger.XML {
+	if info == nil {
+		return nil
+	}
+	return &swagger.XML{
+		Name:      info.Name,
+		Namespace: info.Namespace,
+		Prefix:    info.Prefix,
+		Attribute: info.Attribute,
+		Text:      info.Text,
+	}
+}
+
+// toSwaggerWrapperXML the swagger XML object of an array, return nil if the items are not wrapped.
+func (info *XMLInfo) toSwaggerWrapperXML() *swagger.XML {
+	if info == nil || !info.IsWrapped() {
+		return nil
+	}
+	return &swagger.XML{Name: info.Wrapper, Wrapped: true}
+}
@@ -0,0 +1,121 @@
+package ehttp
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+type testXMLAuthor struct {
+	Name string `json:"name" xml:"name"`
+}
+
+type testXMLBook struct {
+	XMLName xml.Name        `json:"-" xml:"http://example.com/book book" xmlprefix:"bk"`
+	ID      string          `json:"id" xml:"id,attr"`
+	Title   string          `json:"title" xml:"Title"`
+	Tags    []string        `json:"tags" xml:"tags>tag"`
+	Authors []testXMLAuthor `json:"authors" xml:"author"`
+	Editor  *testXMLAuthor  `json:"editor" xml:"chief-editor"`
+	Note    string          `json:"note" xml:",chardata"`
+	Secret  string          `json:"-" xml:"-"`
+}
+
+func TestGetStructFieldXML(t *testing.T) {
+	type A struct {
+		ID      string   `json:"id" xml:"id"`
+		Code    string   `json:"code" xml:"code,attr"`
+		Name    string   `json:"name" xml:"Name"`
+		Title   string   `json:"title" xml:"http://example.com/book title" xmlprefix:"bk"`
+		Tags    []string `json:"tags" xml:"tags>tag"`
+		Value   string   `json:"value" xml:",chardata"`
+		Comment string   `json:"comment"`
+	}
+	expected := []*XMLInfo{
+		nil,
+		&XMLInfo{Name: "code", Attribute: true},
+		&XMLInfo{Name: "Name"},
+		&XMLInfo{Name: "title", Namespace: "http://example.com/book", Prefix: "bk"},
+		&XMLInfo{Name: "tag", Wrapper: "tags"},
+		&XMLInfo{Text: true},
+		nil,
+	}
+	structType := reflect.TypeOf(A{})
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldName, err := getStructFieldName(field)
+		if err != nil {
+			testError(t, i, err)
+			continue
+		}
+		info, err := getStructFieldXML(field, fieldName)
+		if err != nil {
+			testError(t, i, err)
+			continue
+		}
+		if !reflect.DeepEqual(info, expected[i]) {
+			testError(t, i, info, "should be equal to", expected[i])
+		}
+	}
+
+	type B struct {
+		Name   string   `xml:"a>name"`
+		Tags   []string `xml:"a>b>tag"`
+		Items  []string `xml:"items,attr"`
+		Author struct {
+			Name string `xml:"name"`
+		} `xml:",chardata"`
+		Title string `xml:"title" xmlprefix:"bk"`
+		Value string `xml:"value,attr,chardata"`
+	}
+	structType = reflect.TypeOf(B{})
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if _, err := getStructFieldXML(field, field.Name); err == nil {
+			testError(t, i, "err should not be nil")
+		} else {
+			testLog(t, i, err)
+		}
+	}
+}
+
+func TestXMLDefinitions(t *testing.T) {
+	creater := StructDocCreater{}
+	docs, err := creater.GetStructDocMap(&testXMLBook{})
+	if err != nil {
+		testError(t, err)
+		return
+	}
+	if len(docs) != 2 {
+		testError(t, "the xml.Name should not be a model", docs)
+	}
+	definitions := getDefinitionsFromStructDocMap(docs)
+	schema := definitions["testXMLBook"]
+	if schema.XML == nil || schema.XML.Name != "book" || schema.XML.Namespace != "http://example.com/book" || schema.XML.Prefix != "bk" {
+		testError(t, "the xml of testXMLBook should be from the XMLName", schema.XML)
+	}
+	if len(schema.Properties) != 6 {
+		testError(t, "XMLName and the ignored fields should not be properties", schema.Properties)
+	}
+	if p := schema.Properties["id"]; p.XML == nil || !p.XML.Attribute || p.XML.Name != "id" {
+		testError(t, "id should be an xml attribute", p.XML)
+	}
+	if p := schema.Properties["title"]; p.XML == nil || p.XML.Name != "Title" {
+		testError(t, "the xml name of title should be Title", p.XML)
+	}
+	if p := schema.Properties["tags"]; p.XML == nil || !p.XML.Wrapped || p.XML.Name != "tags" || p.Items.XML == nil || p.Items.XML.Name != "tag" {
+		testError(t, "tags should be wrapped by the element tags", p.XML, p.Items.XML)
+	}
+	if p := schema.Properties["authors"]; p.XML != nil || p.Items.XML == nil || p.Items.XML.Name != "author" || len(p.Items.AllOf) != 1 {
+		testError(t, "the items of authors should be named author", p.XML, p.Items)
+	}
+	if p := schema.Properties["editor"]; p.XML == nil || p.XML.Name != "chief-editor" || len(p.AllOf) != 1 || p.AllOf[0].Ref != _definitions+"testXMLAuthor" {
+		testError(t, "the reference of editor should be wrapped by allOf", p)
+	}
+	if p := schema.Properties["note"]; p.XML == nil || !p.XML.Text {
+		testError(t, "note should be the xml text", p.XML)
+	}
+	if p := definitions["testXMLAuthor"].Properties["name"]; p.XML != nil {
+		testError(t, "the xml of name should be nil", p.XML)
+	}
+}